@@ -0,0 +1,83 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"github.com/rs/zerolog/log"
+	"os"
+	"os/signal"
+	"userservice/internal/application"
+	"userservice/internal/config"
+	"userservice/internal/domain/model/listusers"
+	"userservice/internal/domain/model/replayusers"
+)
+
+var (
+	configPath     = flag.String("config", "config/debug.json", "Path to config file")
+	replayID       = flag.String("id", "", "Name of the replay, an unfinished replay with the same name is resumed. Generated when empty")
+	filterField    = flag.String("filter-field", "", "Only replay users where this field matches, one of first_name, last_name, nickname, email or country")
+	filterComparer = flag.String("filter-comparer", "eq", "How the filter field is compared to the filter value, one of eq, gt, gte, lt or lte")
+	filterValue    = flag.String("filter-value", "", "Value the filter field is compared to")
+	batchSize      = flag.Int64("batch-size", 100, "Number of users enqueued and checkpointed at once")
+	usersPerSecond = flag.Int64("users-per-second", 200, "Maximum number of users enqueued per second")
+)
+
+var userFields = map[string]listusers.UserField{
+	"first_name": listusers.UserFieldFirstName,
+	"last_name":  listusers.UserFieldSecondName,
+	"nickname":   listusers.UserFieldNickname,
+	"email":      listusers.UserFieldEmail,
+	"country":    listusers.UserFieldCountry,
+}
+
+var comparers = map[string]listusers.Comparer{
+	"eq":  listusers.ComparerEqual,
+	"gt":  listusers.ComparerGreaterThan,
+	"gte": listusers.ComparerGreaterThanEqual,
+	"lt":  listusers.ComparerLessThan,
+	"lte": listusers.ComparerLessThanEqual,
+}
+
+func parseFilter() (*listusers.FilterInfo, error) {
+	if *filterField == "" {
+		return nil, nil
+	}
+	field, ok := userFields[*filterField]
+	if !ok {
+		return nil, fmt.Errorf("unknown filter field %s", *filterField)
+	}
+	comparer, ok := comparers[*filterComparer]
+	if !ok {
+		return nil, fmt.Errorf("unknown filter comparer %s", *filterComparer)
+	}
+	return &listusers.FilterInfo{Left: field, Comparer: comparer, Right: *filterValue}, nil
+}
+
+func main() {
+	flag.Parse()
+
+	cfg, err := config.ReadConfig(*configPath)
+	if err != nil {
+		panic(fmt.Sprintf("failed to read config: %v", err))
+	}
+	filter, err := parseFilter()
+	if err != nil {
+		panic(err)
+	}
+
+	// interrupting keeps the checkpoint, running again with the same id resumes
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	checkpoint, err := application.RunReplay(ctx, cfg, replayusers.Request{
+		ID:             *replayID,
+		Filtering:      filter,
+		BatchSize:      *batchSize,
+		UsersPerSecond: *usersPerSecond,
+	})
+	if err != nil {
+		log.Fatal().Err(err).Msgf("replay %s stopped after %d users, run again with -id %s to resume", checkpoint.ID, checkpoint.Enqueued, checkpoint.ID)
+	}
+	log.Info().Msgf("replay %s enqueued %d users", checkpoint.ID, checkpoint.Enqueued)
+}
@@ -0,0 +1,84 @@
+package functionaltest
+
+import (
+	"context"
+	"github.com/confluentinc/confluent-kafka-go/v2/kafka"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/bson"
+	"google.golang.org/protobuf/proto"
+	"testing"
+	"time"
+	"userservice/internal/infrastructure/mongodb"
+	"userservice/internal/util/crypto"
+	"userservice/proto/kafkaschema"
+)
+
+func sendCommand(t *testing.T, topic string, command proto.Message) {
+	producer, err := kafka.NewProducer(&kafka.ConfigMap{"bootstrap.servers": testerApp.serverConfig.Kafka.Commands.BootstrapServers})
+	require.NoError(t, err)
+	defer producer.Close()
+
+	value, err := testerApp.serializer.Serialize(topic, command)
+	require.NoError(t, err)
+	deliveryChan := make(chan kafka.Event, 1)
+	err = producer.Produce(&kafka.Message{
+		TopicPartition: kafka.TopicPartition{Topic: &topic, Partition: kafka.PartitionAny},
+		Value:          value,
+	}, deliveryChan)
+	require.NoError(t, err)
+	delivered := (<-deliveryChan).(*kafka.Message)
+	require.NoError(t, delivered.TopicPartition.Error)
+}
+
+func insertCommandTestUser(t *testing.T, ctx context.Context) mongodb.DBUser {
+	testUser := mongodb.DBUser{
+		ID:        uuid.New().String(),
+		FirstName: "Hest",
+		LastName:  "Petersen",
+		Nickname:  "Hesty",
+		Password:  "Alalal",
+		Email:     "hest@example.com",
+		Country:   "DK",
+		Salt:      crypto.GenerateSalt(),
+		CreatedAt: time.Now(),
+		UpdatedAt: time.Now(),
+	}
+	_, err := testerApp.userCollection.InsertOne(ctx, testUser)
+	require.NoError(t, err)
+	return testUser
+}
+
+func TestCommandsDeleteAndSuspendUser(t *testing.T) {
+	ctx := context.Background()
+	defer testerApp.clearDB()
+	commandsConfig := testerApp.serverConfig.Kafka.Commands
+
+	toDelete := insertCommandTestUser(t, ctx)
+	toSuspend := insertCommandTestUser(t, ctx)
+
+	sendCommand(t, commandsConfig.DeleteUserTopicName, &kafkaschema.DeleteUserCommand{
+		CommandId:   uuid.New().String(),
+		UserId:      toDelete.ID,
+		RequestedBy: "gdpr",
+	})
+	sendCommand(t, commandsConfig.SuspendUserTopicName, &kafkaschema.SuspendUserCommand{
+		CommandId:   uuid.New().String(),
+		UserId:      toSuspend.ID,
+		RequestedBy: "fraud",
+		Reason:      "chargebacks",
+	})
+
+	require.Eventually(t, func() bool {
+		count, err := testerApp.userCollection.CountDocuments(ctx, bson.D{{"id", toDelete.ID}})
+		require.NoError(t, err)
+		return count == 0
+	}, waitingTime, 100*time.Millisecond)
+
+	require.Eventually(t, func() bool {
+		var suspended mongodb.DBUser
+		err := testerApp.userCollection.FindOne(ctx, bson.D{{"id", toSuspend.ID}}).Decode(&suspended)
+		require.NoError(t, err)
+		return suspended.Suspended && suspended.SuspensionReason == "chargebacks"
+	}, waitingTime, 100*time.Millisecond)
+}
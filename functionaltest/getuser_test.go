@@ -0,0 +1,85 @@
+package functionaltest
+
+import (
+	"context"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"testing"
+	"time"
+	"userservice/internal/infrastructure/mongodb"
+	"userservice/internal/util/crypto"
+	proto "userservice/proto/grpc"
+)
+
+func TestGetUser(t *testing.T) {
+	ctx := context.Background()
+	defer testerApp.clearDB()
+
+	testUser := mongodb.DBUser{
+		ID:        uuid.New().String(),
+		FirstName: "Hest",
+		LastName:  "Petersen",
+		Nickname:  "Hesty",
+		Password:  "Alalal",
+		Email:     "hest@example.com",
+		Country:   "SWE",
+		Salt:      crypto.GenerateSalt(),
+		CreatedAt: time.Now(),
+		UpdatedAt: time.Now(),
+	}
+	_, err := testerApp.userCollection.InsertOne(ctx, testUser)
+	require.NoError(t, err)
+
+	resp, err := testerApp.grpcClient.GetUser(ctx, &proto.GetUserRequest{UserID: testUser.ID})
+	require.NoError(t, err)
+	require.Equal(t, testUser.ID, resp.User.Id)
+	require.Equal(t, testUser.Nickname, resp.User.Nickname)
+
+	// unknown user
+	_, err = testerApp.grpcClient.GetUser(ctx, &proto.GetUserRequest{UserID: uuid.New().String()})
+	require.Error(t, err)
+	require.Equal(t, codes.NotFound, status.Code(err))
+}
+
+func TestBatchGetUsers(t *testing.T) {
+	ctx := context.Background()
+	defer testerApp.clearDB()
+
+	var userIDs []string
+	for range 2 {
+		testUser := mongodb.DBUser{
+			ID:        uuid.New().String(),
+			FirstName: "Hest",
+			LastName:  "Petersen",
+			Nickname:  "Hesty",
+			Password:  "Alalal",
+			Email:     "hest@example.com",
+			Country:   "SWE",
+			Salt:      crypto.GenerateSalt(),
+			CreatedAt: time.Now(),
+			UpdatedAt: time.Now(),
+		}
+		_, err := testerApp.userCollection.InsertOne(ctx, testUser)
+		require.NoError(t, err)
+		userIDs = append(userIDs, testUser.ID)
+	}
+	missingID := uuid.New().String()
+
+	resp, err := testerApp.grpcClient.BatchGetUsers(ctx, &proto.BatchGetUsersRequest{
+		UserIDs: []string{userIDs[1], missingID, userIDs[0]},
+	})
+	require.NoError(t, err)
+	require.Len(t, resp.Results, 3)
+
+	require.Equal(t, userIDs[1], resp.Results[0].UserID)
+	require.Equal(t, userIDs[1], resp.Results[0].GetUser().Id)
+
+	require.Equal(t, missingID, resp.Results[1].UserID)
+	require.Nil(t, resp.Results[1].GetUser())
+	require.Equal(t, int32(codes.NotFound), resp.Results[1].GetError().Code)
+
+	require.Equal(t, userIDs[0], resp.Results[2].UserID)
+	require.Equal(t, userIDs[0], resp.Results[2].GetUser().Id)
+}
@@ -0,0 +1,70 @@
+package functionaltest
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/health/grpc_health_v1"
+	"io"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func getHealthEndpoint(t *testing.T, path string) int {
+	url := fmt.Sprintf("http://localhost:%d%s", testerApp.serverConfig.HealthChecker.OrdinaryHealthCheckListeningPort, path)
+	response, err := http.Get(url)
+	require.NoError(t, err)
+	defer response.Body.Close()
+	return response.StatusCode
+}
+
+func TestProbes(t *testing.T) {
+	require.Equal(t, http.StatusOK, getHealthEndpoint(t, "/livez"))
+	require.Equal(t, http.StatusOK, getHealthEndpoint(t, "/readyz"))
+}
+
+func TestAggregateGRPCHealth(t *testing.T) {
+	response, err := testerApp.healthClient.Check(context.Background(), &grpc_health_v1.HealthCheckRequest{})
+	require.NoError(t, err)
+	require.Equal(t, grpc_health_v1.HealthCheckResponse_SERVING, response.Status)
+}
+
+func TestOutboxLagIsInHealthz(t *testing.T) {
+	ctx := context.Background()
+	defer testerApp.clearDB()
+	insertDeadLetteredMessage(t, ctx)
+
+	url := fmt.Sprintf("http://localhost:%d/healthz", testerApp.serverConfig.HealthChecker.OrdinaryHealthCheckListeningPort)
+	require.Eventually(t, func() bool {
+		response, err := http.Get(url)
+		require.NoError(t, err)
+		defer response.Body.Close()
+
+		var diagnostics struct {
+			Dependencies map[string]struct {
+				Details map[string]float64 `json:"details"`
+			} `json:"dependencies"`
+		}
+		require.NoError(t, json.NewDecoder(response.Body).Decode(&diagnostics))
+		return diagnostics.Dependencies["OutboxLag"].Details["deadLettered"] == 1
+	}, time.Duration(testerApp.serverConfig.HealthChecker.HealthCheckTickerIntervalSeconds+5)*time.Second, time.Second)
+}
+
+func TestMetricsEndpoint(t *testing.T) {
+	_, err := testerApp.healthClient.Check(context.Background(), &grpc_health_v1.HealthCheckRequest{})
+	require.NoError(t, err)
+
+	url := fmt.Sprintf("http://localhost:%d/metrics", testerApp.serverConfig.HealthChecker.OrdinaryHealthCheckListeningPort)
+	response, err := http.Get(url)
+	require.NoError(t, err)
+	defer response.Body.Close()
+	require.Equal(t, http.StatusOK, response.StatusCode)
+
+	body, err := io.ReadAll(response.Body)
+	require.NoError(t, err)
+	require.Contains(t, string(body), `userservice_grpc_requests_total{code="OK",method="/grpc.health.v1.Health/Check"}`)
+	require.Contains(t, string(body), `userservice_health_status{service="MongoDB"} 1`)
+	require.Contains(t, string(body), `userservice_mongodb_operation_duration_seconds_count`)
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"encoding/json"
 	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/bson"
 	"testing"
 	"time"
 	"userservice/internal/infrastructure/messaging"
@@ -38,3 +39,169 @@ func TestInsertAndWait(t *testing.T) {
 	require.Len(t, entries, 1)
 	require.Equal(t, mongodb.StateFinished.String(), entries[0].State)
 }
+
+func TestInsertBatchAndWait(t *testing.T) {
+	ctx := context.Background()
+	defer testerApp.clearDB()
+
+	// more than a single batch, all of them should be drained in one wake up
+	messageCount := 2*testerApp.serverConfig.Kafka.Outbox.BatchSize + 1
+	for range messageCount {
+		internalMsg := messaging.NewInternalMessage(testerApp.serverConfig.Kafka.Topics.UserAddedTopicName, []byte("hello"), []byte("value"))
+
+		data, err := json.Marshal(&internalMsg)
+		require.NoError(t, err)
+
+		_, err = testerApp.kafkaOutboxCollection.InsertOne(ctx, mongodb.Message{
+			MsgID:     internalMsg.ID,
+			Data:      data,
+			NextRetry: time.Now().UTC(),
+			SentAt:    time.Time{},
+			Retries:   0,
+			State:     mongodb.StateWaiting.String(),
+		})
+		require.NoError(t, err)
+	}
+
+	// sleep a bit, waiting for messages to get picked up and sent
+	time.Sleep(15 * time.Second)
+
+	entries := getAllKafkaDBEntries(ctx, testerApp.kafkaOutboxCollection)
+	require.Len(t, entries, messageCount)
+	for _, entry := range entries {
+		require.Equal(t, mongodb.StateFinished.String(), entry.State)
+	}
+}
+
+func insertLeasedMessage(t *testing.T, ctx context.Context, leaseExpiresAt time.Time) string {
+	internalMsg := messaging.NewInternalMessage(testerApp.serverConfig.Kafka.Topics.UserAddedTopicName, []byte("hello"), []byte("value"))
+
+	data, err := json.Marshal(&internalMsg)
+	require.NoError(t, err)
+
+	// a message claimed by an instance that crashed before the delivery report came back
+	_, err = testerApp.kafkaOutboxCollection.InsertOne(ctx, mongodb.Message{
+		MsgID:          internalMsg.ID,
+		Data:           data,
+		NextRetry:      time.Now().UTC(),
+		State:          mongodb.StateProcessing.String(),
+		LeaseOwner:     "crashed-instance",
+		LeaseExpiresAt: leaseExpiresAt,
+	})
+	require.NoError(t, err)
+	return internalMsg.ID
+}
+
+func TestExpiredLeaseIsRecovered(t *testing.T) {
+	ctx := context.Background()
+	defer testerApp.clearDB()
+
+	insertLeasedMessage(t, ctx, time.Now().UTC().Add(-time.Minute))
+
+	// sleep a bit, waiting for the reaper to release the message and the outbox to send it
+	time.Sleep(25 * time.Second)
+
+	entries := getAllKafkaDBEntries(ctx, testerApp.kafkaOutboxCollection)
+	require.Len(t, entries, 1)
+	require.Equal(t, mongodb.StateFinished.String(), entries[0].State)
+	require.Equal(t, int64(1), entries[0].Retries)
+	require.Empty(t, entries[0].LeaseOwner)
+}
+
+func TestActiveLeaseIsKept(t *testing.T) {
+	ctx := context.Background()
+	defer testerApp.clearDB()
+
+	insertLeasedMessage(t, ctx, time.Now().UTC().Add(time.Hour))
+
+	time.Sleep(25 * time.Second)
+
+	entries := getAllKafkaDBEntries(ctx, testerApp.kafkaOutboxCollection)
+	require.Len(t, entries, 1)
+	require.Equal(t, mongodb.StateProcessing.String(), entries[0].State)
+	require.Equal(t, "crashed-instance", entries[0].LeaseOwner)
+}
+
+func TestMessagesOfAUserAreSentInOrder(t *testing.T) {
+	ctx := context.Background()
+	defer testerApp.clearDB()
+
+	key := []byte("user-1")
+	partition := messaging.PartitionForKey(key, testerApp.serverConfig.Kafka.Outbox.Partitions)
+	insert := func(createdAt time.Time, nextRetry time.Time) string {
+		internalMsg := messaging.NewInternalMessage(testerApp.serverConfig.Kafka.Topics.UserAddedTopicName, key, []byte("value"))
+		data, err := json.Marshal(&internalMsg)
+		require.NoError(t, err)
+
+		_, err = testerApp.kafkaOutboxCollection.InsertOne(ctx, mongodb.Message{
+			MsgID:     internalMsg.ID,
+			Topic:     internalMsg.TopicID,
+			Key:       string(key),
+			Partition: partition,
+			Data:      data,
+			CreatedAt: createdAt,
+			NextRetry: nextRetry,
+			State:     mongodb.StateWaiting.String(),
+		})
+		require.NoError(t, err)
+		return internalMsg.ID
+	}
+
+	// the first message is waiting for a retry, the second one is due but has to wait for the first
+	firstID := insert(time.Now().UTC().Add(-time.Minute), time.Now().UTC().Add(time.Hour))
+	secondID := insert(time.Now().UTC(), time.Now().UTC())
+
+	time.Sleep(15 * time.Second)
+
+	for _, entry := range getAllKafkaDBEntries(ctx, testerApp.kafkaOutboxCollection) {
+		require.Contains(t, []string{firstID, secondID}, entry.MsgID)
+		require.Equal(t, mongodb.StateWaiting.String(), entry.State, entry.MsgID)
+	}
+
+	_, err := testerApp.kafkaOutboxCollection.UpdateOne(ctx, bson.D{{"msg_id", firstID}}, bson.D{{"$set", bson.D{{"next_retry", time.Now().UTC()}}}})
+	require.NoError(t, err)
+
+	time.Sleep(30 * time.Second)
+
+	sentAt := map[string]time.Time{}
+	for _, entry := range getAllKafkaDBEntries(ctx, testerApp.kafkaOutboxCollection) {
+		require.Equal(t, mongodb.StateFinished.String(), entry.State, entry.MsgID)
+		sentAt[entry.MsgID] = entry.SentAt
+	}
+	require.Len(t, sentAt, 2)
+	require.True(t, sentAt[firstID].Before(sentAt[secondID]))
+}
+
+func TestChangeStreamSendsWithoutWaitingForThePoll(t *testing.T) {
+	if !testerApp.serverConfig.Kafka.Outbox.ChangeStream {
+		t.Skip("change stream mode is disabled")
+	}
+	ctx := context.Background()
+	defer testerApp.clearDB()
+
+	internalMsg := messaging.NewInternalMessage(testerApp.serverConfig.Kafka.Topics.UserAddedTopicName, []byte("hello"), []byte("value"))
+	data, err := json.Marshal(&internalMsg)
+	require.NoError(t, err)
+
+	_, err = testerApp.kafkaOutboxCollection.InsertOne(ctx, mongodb.Message{
+		MsgID:     internalMsg.ID,
+		Data:      data,
+		CreatedAt: time.Now().UTC(),
+		NextRetry: time.Now().UTC(),
+		State:     mongodb.StateWaiting.String(),
+	})
+	require.NoError(t, err)
+
+	require.Eventually(t, func() bool {
+		entries := getAllKafkaDBEntries(ctx, testerApp.kafkaOutboxCollection)
+		return len(entries) == 1 && entries[0].State == mongodb.StateFinished.String()
+	}, time.Duration(testerApp.serverConfig.Kafka.Outbox.SleepIntervalSeconds)*time.Second/2, 100*time.Millisecond)
+
+	// the position in the change stream is kept for the next start, it is saved every few seconds at most
+	leaseCollection := testerApp.db.Collection(testerApp.serverConfig.Database.KafkaOutboxLeaseCollectionName)
+	var resumeToken mongodb.ResumeToken
+	require.Eventually(t, func() bool {
+		err := leaseCollection.FindOne(ctx, bson.D{{"kind", "resume_token"}}).Decode(&resumeToken)
+		return err == nil && len(resumeToken.Token) > 0
+	}, 10*time.Second, 100*time.Millisecond)
+}
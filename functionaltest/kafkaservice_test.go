@@ -2,15 +2,17 @@ package functionaltest
 
 import (
 	"context"
-	"encoding/json"
 	"github.com/confluentinc/confluent-kafka-go/v2/kafka"
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/metadata"
 	"k8s.io/apimachinery/pkg/util/rand"
 	"testing"
 	"time"
+	"userservice/internal/infrastructure/messaging"
 	"userservice/internal/infrastructure/mongodb"
 	"userservice/internal/util/crypto"
+	"userservice/internal/util/tracing"
 	proto "userservice/proto/grpc"
 	"userservice/proto/kafkaschema"
 )
@@ -51,7 +53,7 @@ func TestKafkaServiceAddUser(t *testing.T) {
 		messageTopic := *msg.TopicPartition.Topic
 		require.Equal(t, testerApp.serverConfig.Kafka.Topics.UserAddedTopicName, messageTopic)
 		var parsed kafkaschema.UserAddedMessage
-		err := json.Unmarshal(msg.Value, &parsed)
+		err := testerApp.serializer.Deserialize(messageTopic, msg.Value, &parsed)
 		require.NoError(t, err)
 		require.Equal(t, parsed.Id, respUser.Id)
 		require.Equal(t, parsed.Country, respUser.Country)
@@ -107,8 +109,105 @@ func TestKafkaServiceRemoveUser(t *testing.T) {
 		messageTopic := *msg.TopicPartition.Topic
 		require.Equal(t, testerApp.serverConfig.Kafka.Topics.UserRemovedTopicName, messageTopic)
 		var parsed kafkaschema.UserRemovedMessage
-		err := json.Unmarshal(msg.Value, &parsed)
+		err := testerApp.serializer.Deserialize(messageTopic, msg.Value, &parsed)
 		require.NoError(t, err)
 		require.Equal(t, parsed.Id, respUser.Id)
 	}
 }
+
+func TestKafkaServiceUpdateUser(t *testing.T) {
+	ctx := context.Background()
+	defer testerApp.clearDB()
+
+	waitingChannel := make(chan kafka.Message)
+	testerApp.consumer.setCommunicationChannel(waitingChannel)
+	defer testerApp.consumer.clearCommunicationChannel()
+
+	testUser := mongodb.DBUser{
+		ID:        uuid.New().String(),
+		FirstName: "Hest",
+		LastName:  "Petersen",
+		Nickname:  "Hesty",
+		Password:  "Alalal",
+		Email:     "hest@example.com",
+		Country:   "SE",
+		Salt:      crypto.GenerateSalt(),
+		CreatedAt: time.Now(),
+		UpdatedAt: time.Now(),
+	}
+	_, err := testerApp.userCollection.InsertOne(ctx, testUser)
+	require.NoError(t, err)
+
+	newCountry := "DK"
+	newPassword := "newPassword"
+	_, err = testerApp.grpcClient.UpdateUser(ctx, &proto.UpdateUserRequest{
+		UserID: testUser.ID,
+		User: &proto.UpdateUserRequestUser{
+			Country:  &newCountry,
+			Password: &newPassword,
+		},
+	})
+	require.NoError(t, err)
+
+	// KAFKA STATE
+	timeOutTimer := time.NewTimer(waitingTime)
+	select {
+	case <-timeOutTimer.C:
+		require.FailNow(t, "timed out waiting for kafka message to arrive!")
+	case msg := <-waitingChannel:
+		messageTopic := *msg.TopicPartition.Topic
+		require.Equal(t, testerApp.serverConfig.Kafka.Topics.UserUpdatedTopicName, messageTopic)
+		require.NotContains(t, string(msg.Value), newPassword)
+		var parsed kafkaschema.UserUpdatedMessage
+		err := testerApp.serializer.Deserialize(messageTopic, msg.Value, &parsed)
+		require.NoError(t, err)
+		require.Equal(t, testUser.ID, parsed.Id)
+		require.True(t, parsed.PasswordChanged)
+		require.Len(t, parsed.Changes, 1)
+		require.Equal(t, "country", parsed.Changes[0].Field)
+		require.Equal(t, testUser.Country, parsed.Changes[0].OldValue)
+		require.Equal(t, newCountry, parsed.Changes[0].NewValue)
+	}
+}
+
+func TestKafkaServiceEventHeaders(t *testing.T) {
+	defer testerApp.clearDB()
+
+	waitingChannel := make(chan kafka.Message)
+	testerApp.consumer.setCommunicationChannel(waitingChannel)
+	defer testerApp.consumer.clearCommunicationChannel()
+
+	traceID := "4bf92f3577b34da6a3ce929d0e0e4736"
+	ctx := metadata.AppendToOutgoingContext(context.Background(),
+		tracing.CorrelationIDHeader, "functional-correlation",
+		tracing.TraceParentHeader, "00-"+traceID+"-00f067aa0ba902b7-01",
+	)
+	_, err := testerApp.grpcClient.AddUser(ctx, &proto.AddUserRequest{
+		User: &proto.AddUserRequestUser{
+			FirstName: "Jessica",
+			LastName:  "Testerson",
+			Nickname:  rand.String(6),
+			Email:     "jess@example.com",
+			Password:  "superDuper",
+			Country:   "DK",
+		},
+	})
+	require.NoError(t, err)
+
+	timeOutTimer := time.NewTimer(waitingTime)
+	select {
+	case <-timeOutTimer.C:
+		require.FailNow(t, "timed out waiting for kafka message to arrive!")
+	case msg := <-waitingChannel:
+		headers := map[string]string{}
+		for _, header := range msg.Headers {
+			headers[header.Key] = string(header.Value)
+		}
+		require.Equal(t, "kafkaschema.UserAddedMessage", headers[messaging.HeaderEventType])
+		require.Equal(t, "1", headers[messaging.HeaderSchemaVersion])
+		require.NotEmpty(t, headers[messaging.HeaderMessageID])
+		require.NotEmpty(t, headers[messaging.HeaderOccurredAt])
+		require.Equal(t, "functional-correlation", headers[messaging.HeaderCorrelationID])
+		require.Contains(t, headers[messaging.HeaderTraceParent], traceID)
+	}
+}
@@ -11,9 +11,11 @@ import (
 	"go.mongodb.org/mongo-driver/mongo/options"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/health/grpc_health_v1"
 	"testing"
 	"time"
 	"userservice/internal/config"
+	"userservice/internal/infrastructure/serialization"
 	proto "userservice/proto/grpc"
 )
 
@@ -23,7 +25,10 @@ var (
 )
 
 type TesterApp struct {
-	grpcClient proto.UserServiceClient
+	grpcClient        proto.UserServiceClient
+	outboxAdminClient proto.OutboxAdminServiceClient
+	webhookClient     proto.WebhookServiceClient
+	healthClient      grpc_health_v1.HealthClient
 	// for verifying correct stage
 	consumer              *kafkaConsumerWrapper
 	dbConn                *mongo.Client
@@ -31,6 +36,8 @@ type TesterApp struct {
 	kafkaOutboxCollection *mongo.Collection
 	userCollection        *mongo.Collection
 	serverConfig          config.AppConfig
+	// decodes event values the same way the server encoded them
+	serializer serialization.Serializer
 }
 
 var testerApp = &TesterApp{}
@@ -48,6 +55,7 @@ func TestMain(m *testing.M) {
 	testerApp.serverConfig = readConfig
 
 	addDBConnection()
+	addSerializer()
 	addGRPCClient()
 	addKafkaConsumer()
 	go testerApp.consumer.listen()
@@ -86,6 +94,26 @@ func addDBConnection() {
 	testerApp.clearDB()
 }
 
+func addSerializer() {
+	serializationConfig := testerApp.serverConfig.Kafka.Serialization
+
+	var registry serialization.SchemaRegistry
+	if serializationConfig.SchemaRegistryPath != "" {
+		// the registry path is relative to the repository root, where the server is started from
+		fileRegistry, err := serialization.NewFileSchemaRegistry("../" + serializationConfig.SchemaRegistryPath)
+		if err != nil {
+			panic(err)
+		}
+		registry = fileRegistry
+	}
+
+	serializer, err := serialization.NewSerializer(serializationConfig, registry)
+	if err != nil {
+		panic(err)
+	}
+	testerApp.serializer = serializer
+}
+
 func addGRPCClient() {
 	conn, err := grpc.NewClient(*serverAddr, grpc.WithTransportCredentials(insecure.NewCredentials()))
 	if err != nil {
@@ -93,6 +121,9 @@ func addGRPCClient() {
 	}
 
 	testerApp.grpcClient = proto.NewUserServiceClient(conn)
+	testerApp.outboxAdminClient = proto.NewOutboxAdminServiceClient(conn)
+	testerApp.webhookClient = proto.NewWebhookServiceClient(conn)
+	testerApp.healthClient = grpc_health_v1.NewHealthClient(conn)
 }
 
 func addKafkaConsumer() {
@@ -109,7 +140,7 @@ func addKafkaConsumer() {
 
 	kc := testerApp.serverConfig.Kafka.Topics
 
-	err = c.SubscribeTopics([]string{kc.UserAddedTopicName, kc.UserRemovedTopicName}, nil)
+	err = c.SubscribeTopics([]string{kc.UserAddedTopicName, kc.UserRemovedTopicName, kc.UserUpdatedTopicName}, nil)
 	if err != nil {
 		panic(err)
 	}
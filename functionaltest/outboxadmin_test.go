@@ -0,0 +1,142 @@
+package functionaltest
+
+import (
+	"context"
+	"encoding/json"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"testing"
+	"time"
+	"userservice/internal/infrastructure/messaging"
+	"userservice/internal/infrastructure/mongodb"
+	proto "userservice/proto/grpc"
+)
+
+func insertDeadLetteredMessage(t *testing.T, ctx context.Context) string {
+	internalMsg := messaging.NewInternalMessage(testerApp.serverConfig.Kafka.Topics.UserAddedTopicName, []byte("hello"), []byte("value"))
+
+	data, err := json.Marshal(&internalMsg)
+	require.NoError(t, err)
+
+	_, err = testerApp.kafkaOutboxCollection.InsertOne(ctx, mongodb.Message{
+		MsgID:          internalMsg.ID,
+		Topic:          internalMsg.TopicID,
+		Key:            string(internalMsg.Key),
+		Data:           data,
+		CreatedAt:      time.Now().UTC(),
+		NextRetry:      time.Now().UTC(),
+		Retries:        int64(testerApp.serverConfig.Kafka.Outbox.MaxAttempts),
+		State:          mongodb.StateDeadLettered.String(),
+		LastError:      "broker down",
+		DeadLetteredAt: time.Now().UTC(),
+	})
+	require.NoError(t, err)
+	return internalMsg.ID
+}
+
+func TestDeadLetteredMessagesAreNotSent(t *testing.T) {
+	ctx := context.Background()
+	defer testerApp.clearDB()
+
+	msgID := insertDeadLetteredMessage(t, ctx)
+
+	// sleep a bit, giving the outbox a chance to pick it up
+	time.Sleep(10 * time.Second)
+
+	response, err := testerApp.outboxAdminClient.GetDeadLetteredMessage(ctx, &proto.GetDeadLetteredMessageRequest{MessageID: msgID})
+	require.NoError(t, err)
+	require.Equal(t, mongodb.StateDeadLettered.String(), response.Message.State)
+	require.Equal(t, "broker down", response.Message.LastError)
+	require.Equal(t, []byte("value"), response.Message.Value)
+
+	listResponse, err := testerApp.outboxAdminClient.ListDeadLetteredMessages(ctx, &proto.ListDeadLetteredMessagesRequest{})
+	require.NoError(t, err)
+	require.Len(t, listResponse.Messages, 1)
+	require.Equal(t, msgID, listResponse.Messages[0].Id)
+}
+
+func TestRequeueDeadLetteredMessage(t *testing.T) {
+	ctx := context.Background()
+	defer testerApp.clearDB()
+
+	msgID := insertDeadLetteredMessage(t, ctx)
+
+	response, err := testerApp.outboxAdminClient.RequeueDeadLetteredMessage(ctx, &proto.RequeueDeadLetteredMessageRequest{MessageID: msgID})
+	require.NoError(t, err)
+	require.Equal(t, mongodb.StateWaiting.String(), response.Message.State)
+	require.Equal(t, int64(0), response.Message.Retries)
+
+	// sleep a bit, waiting for message to get picked up and sent
+	time.Sleep(10 * time.Second)
+
+	entries := getAllKafkaDBEntries(ctx, testerApp.kafkaOutboxCollection)
+	require.Len(t, entries, 1)
+	require.Equal(t, mongodb.StateFinished.String(), entries[0].State)
+
+	_, err = testerApp.outboxAdminClient.RequeueDeadLetteredMessage(ctx, &proto.RequeueDeadLetteredMessageRequest{MessageID: msgID})
+	require.Equal(t, codes.FailedPrecondition, status.Code(err))
+}
+
+func TestDiscardDeadLetteredMessage(t *testing.T) {
+	ctx := context.Background()
+	defer testerApp.clearDB()
+
+	msgID := insertDeadLetteredMessage(t, ctx)
+
+	_, err := testerApp.outboxAdminClient.DiscardDeadLetteredMessage(ctx, &proto.DiscardDeadLetteredMessageRequest{MessageID: msgID})
+	require.NoError(t, err)
+	require.Empty(t, getAllKafkaDBEntries(ctx, testerApp.kafkaOutboxCollection))
+
+	_, err = testerApp.outboxAdminClient.GetDeadLetteredMessage(ctx, &proto.GetDeadLetteredMessageRequest{MessageID: msgID})
+	require.Equal(t, codes.NotFound, status.Code(err))
+}
+
+func TestGetOutboxStatus(t *testing.T) {
+	response, err := testerApp.outboxAdminClient.GetOutboxStatus(context.Background(), &proto.GetOutboxStatusRequest{})
+	require.NoError(t, err)
+	require.NotEmpty(t, response.Status.InstanceId)
+	require.NotEmpty(t, response.Status.OwnedPartitions)
+}
+
+func TestRepublishFinishedMessage(t *testing.T) {
+	ctx := context.Background()
+	defer testerApp.clearDB()
+
+	msgID := insertDeadLetteredMessage(t, ctx)
+	_, err := testerApp.outboxAdminClient.RequeueDeadLetteredMessage(ctx, &proto.RequeueDeadLetteredMessageRequest{MessageID: msgID})
+	require.NoError(t, err)
+
+	// sleep a bit, waiting for message to get picked up and sent
+	time.Sleep(10 * time.Second)
+
+	listResponse, err := testerApp.outboxAdminClient.ListOutboxMessages(ctx, &proto.ListOutboxMessagesRequest{State: mongodb.StateFinished.String(), Key: "hello"})
+	require.NoError(t, err)
+	require.Len(t, listResponse.Messages, 1)
+	require.Equal(t, msgID, listResponse.Messages[0].Id)
+
+	statusResponse, err := testerApp.outboxAdminClient.GetOutboxStatus(ctx, &proto.GetOutboxStatusRequest{})
+	require.NoError(t, err)
+	require.Equal(t, int64(1), statusResponse.Status.MessagesByState[mongodb.StateFinished.String()])
+
+	republishResponse, err := testerApp.outboxAdminClient.RepublishOutboxMessage(ctx, &proto.RepublishOutboxMessageRequest{MessageID: msgID})
+	require.NoError(t, err)
+	copyID := republishResponse.Message.Id
+	require.NotEqual(t, msgID, copyID)
+	require.Equal(t, mongodb.StateWaiting.String(), republishResponse.Message.State)
+	require.Equal(t, msgID, republishResponse.Message.Headers[messaging.HeaderRepublishedFrom])
+
+	time.Sleep(10 * time.Second)
+
+	getResponse, err := testerApp.outboxAdminClient.GetOutboxMessage(ctx, &proto.GetOutboxMessageRequest{MessageID: copyID})
+	require.NoError(t, err)
+	require.Equal(t, mongodb.StateFinished.String(), getResponse.Message.State)
+
+	// the copy was sent after the original, republishing the original again would put it after the copy
+	_, err = testerApp.outboxAdminClient.RepublishOutboxMessage(ctx, &proto.RepublishOutboxMessageRequest{MessageID: msgID})
+	require.Equal(t, codes.FailedPrecondition, status.Code(err))
+
+	// only waiting messages can be retried
+	_, err = testerApp.outboxAdminClient.RetryOutboxMessage(ctx, &proto.RetryOutboxMessageRequest{MessageID: msgID})
+	require.Equal(t, codes.FailedPrecondition, status.Code(err))
+}
@@ -5,6 +5,8 @@ import (
 	"encoding/json"
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 	"testing"
 	"time"
 	"userservice/internal/infrastructure/messaging"
@@ -54,6 +56,7 @@ func TestRemoveUser(t *testing.T) {
 		UserID: testUser.ID,
 	})
 	require.Error(t, err)
+	require.Equal(t, codes.NotFound, status.Code(err))
 
 	outboxEntries := getAllKafkaDBEntries(ctx, testerApp.kafkaOutboxCollection)
 	require.Len(t, outboxEntries, 1)
@@ -64,7 +67,7 @@ func TestRemoveUser(t *testing.T) {
 	require.Equal(t, testerApp.serverConfig.Kafka.Topics.UserRemovedTopicName, msg.TopicID)
 	require.Equal(t, testUser.ID, string(msg.Key))
 	var removedMsg kafkaschema.UserRemovedMessage
-	err = json.Unmarshal(msg.Value, &removedMsg)
+	err = testerApp.serializer.Deserialize(msg.TopicID, msg.Value, &removedMsg)
 	require.NoError(t, err)
 	require.Equal(t, testUser.ID, removedMsg.Id)
 }
@@ -0,0 +1,86 @@
+package functionaltest
+
+import (
+	"context"
+	"github.com/confluentinc/confluent-kafka-go/v2/kafka"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"testing"
+	"time"
+	"userservice/internal/infrastructure/mongodb"
+	"userservice/internal/util/crypto"
+	proto "userservice/proto/grpc"
+	"userservice/proto/kafkaschema"
+)
+
+func insertReplayTestUser(t *testing.T, ctx context.Context, country string) string {
+	user := mongodb.DBUser{
+		ID:        uuid.New().String(),
+		FirstName: "Replay",
+		LastName:  "Petersen",
+		Nickname:  "replayed",
+		Password:  "Alalal",
+		Email:     "replay@example.com",
+		Country:   country,
+		Salt:      crypto.GenerateSalt(),
+		CreatedAt: time.Now(),
+		UpdatedAt: time.Now(),
+	}
+	_, err := testerApp.userCollection.InsertOne(ctx, user)
+	require.NoError(t, err)
+	return user.ID
+}
+
+func TestReplayFilteredUsers(t *testing.T) {
+	ctx := context.Background()
+	defer testerApp.clearDB()
+
+	waitingChannel := make(chan kafka.Message)
+	testerApp.consumer.setCommunicationChannel(waitingChannel)
+	defer testerApp.consumer.clearCommunicationChannel()
+
+	// inserted directly, so only the replay produces events
+	danes := map[string]bool{}
+	for range 3 {
+		danes[insertReplayTestUser(t, ctx, "DK")] = true
+	}
+	insertReplayTestUser(t, ctx, "SE")
+
+	replayID := uuid.New().String()
+	started, err := testerApp.outboxAdminClient.StartReplay(ctx, &proto.StartReplayRequest{
+		ReplayID:  replayID,
+		Filtering: &proto.FilterInfo{Left: proto.UserField_USER_FIELD_COUNTRY, Comparer: proto.Comparer_COMPARER_EQUAL, Right: "DK"},
+		BatchSize: 2,
+	})
+	require.NoError(t, err)
+	require.Equal(t, replayID, started.Replay.Id)
+
+	for range danes {
+		select {
+		case <-time.After(waitingTime):
+			require.FailNow(t, "timed out waiting for replayed user")
+		case msg := <-waitingChannel:
+			messageTopic := *msg.TopicPartition.Topic
+			require.Equal(t, testerApp.serverConfig.Kafka.Topics.UserAddedTopicName, messageTopic)
+			var parsed kafkaschema.UserAddedMessage
+			require.NoError(t, testerApp.serializer.Deserialize(messageTopic, msg.Value, &parsed))
+			require.True(t, parsed.Replay)
+			require.True(t, danes[parsed.Id], "only danes are replayed")
+			delete(danes, parsed.Id)
+		}
+	}
+
+	replay, err := testerApp.outboxAdminClient.GetReplay(ctx, &proto.GetReplayRequest{ReplayID: replayID})
+	require.NoError(t, err)
+	require.NotNil(t, replay.Replay.FinishedAt)
+	require.EqualValues(t, 3, replay.Replay.Enqueued)
+
+	// starting a finished replay again does not enqueue anything
+	_, err = testerApp.outboxAdminClient.StartReplay(ctx, &proto.StartReplayRequest{ReplayID: replayID})
+	require.NoError(t, err)
+	select {
+	case <-time.After(3 * time.Second):
+	case <-waitingChannel:
+		require.FailNow(t, "finished replay was replayed again")
+	}
+}
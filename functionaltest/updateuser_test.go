@@ -5,6 +5,8 @@ import (
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/require"
 	"go.mongodb.org/mongo-driver/bson"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 	"testing"
 	"time"
 	"userservice/internal/infrastructure/mongodb"
@@ -76,6 +78,16 @@ func TestUpdateUser(t *testing.T) {
 		User:   nil,
 	})
 	require.Error(t, err)
+	require.Equal(t, codes.InvalidArgument, status.Code(err))
+	require.Nil(t, resp)
+
+	// unknown user
+	resp, err = testerApp.grpcClient.UpdateUser(ctx, &proto.UpdateUserRequest{
+		UserID: uuid.New().String(),
+		User:   &modifiedUser,
+	})
+	require.Error(t, err)
+	require.Equal(t, codes.NotFound, status.Code(err))
 	require.Nil(t, resp)
 
 	// empty request
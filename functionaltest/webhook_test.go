@@ -0,0 +1,132 @@
+package functionaltest
+
+import (
+	"context"
+	"encoding/json"
+	"github.com/stretchr/testify/require"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+	"userservice/internal/domain/model"
+	"userservice/internal/infrastructure/webhook"
+	"userservice/internal/util/crypto"
+	proto "userservice/proto/grpc"
+)
+
+type receivedWebhook struct {
+	header http.Header
+	body   []byte
+}
+
+// startWebhookEndpoint answers the first failures requests with an error, and every later one with 200
+func startWebhookEndpoint(t *testing.T, failures int) (*httptest.Server, <-chan receivedWebhook) {
+	received := make(chan receivedWebhook, 10)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		received <- receivedWebhook{header: r.Header.Clone(), body: body}
+		if failures > 0 {
+			failures--
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	t.Cleanup(server.Close)
+	return server, received
+}
+
+func addWebhookTestUser(t *testing.T, ctx context.Context) *proto.ResponseUser {
+	response, err := testerApp.grpcClient.AddUser(ctx, &proto.AddUserRequest{User: &proto.AddUserRequestUser{
+		FirstName: "Wendy",
+		LastName:  "Hooks",
+		Nickname:  "webhook",
+		Email:     "wendy@example.com",
+		Password:  "superDuper",
+		Country:   "DK",
+	}})
+	require.NoError(t, err)
+	return response.User
+}
+
+func TestWebhookDeliveryIsSigned(t *testing.T) {
+	ctx := context.Background()
+	defer testerApp.clearDB()
+
+	server, received := startWebhookEndpoint(t, 0)
+	subscription, err := testerApp.webhookClient.CreateWebhookSubscription(ctx, &proto.CreateWebhookSubscriptionRequest{
+		Url:        server.URL,
+		EventTypes: []string{model.EventTypeUserAdded},
+	})
+	require.NoError(t, err)
+	require.NotEmpty(t, subscription.Secret)
+
+	user := addWebhookTestUser(t, ctx)
+
+	var request receivedWebhook
+	select {
+	case request = <-received:
+	case <-time.After(10 * time.Second):
+		t.Fatal("webhook was not delivered")
+	}
+	timestamp, err := strconv.ParseInt(request.header.Get(webhook.HeaderTimestamp), 10, 64)
+	require.NoError(t, err)
+	require.True(t, crypto.VerifyWebhookSignature(subscription.Secret, timestamp, request.body, request.header.Get(webhook.HeaderSignature)))
+	require.Equal(t, model.EventTypeUserAdded, request.header.Get(webhook.HeaderEventType))
+
+	var payload struct {
+		Type string `json:"type"`
+		Data struct {
+			ID string `json:"id"`
+		} `json:"data"`
+	}
+	require.NoError(t, json.Unmarshal(request.body, &payload))
+	require.Equal(t, model.EventTypeUserAdded, payload.Type)
+	require.Equal(t, user.Id, payload.Data.ID)
+
+	// removing the user is filtered out
+	_, err = testerApp.grpcClient.RemoveUser(ctx, &proto.RemoveUserRequest{UserID: user.Id})
+	require.NoError(t, err)
+	select {
+	case <-received:
+		t.Fatal("subscription does not want removed users")
+	case <-time.After(3 * time.Second):
+	}
+
+	require.Eventually(t, func() bool {
+		deliveries, err := testerApp.webhookClient.ListWebhookDeliveries(ctx, &proto.ListWebhookDeliveriesRequest{SubscriptionID: subscription.Subscription.Id})
+		require.NoError(t, err)
+		return len(deliveries.Deliveries) == 1 && deliveries.Deliveries[0].State == "delivered"
+	}, 5*time.Second, 100*time.Millisecond)
+}
+
+func TestFailedWebhookDeliveryIsRetried(t *testing.T) {
+	ctx := context.Background()
+	defer testerApp.clearDB()
+
+	server, received := startWebhookEndpoint(t, 1)
+	subscription, err := testerApp.webhookClient.CreateWebhookSubscription(ctx, &proto.CreateWebhookSubscriptionRequest{Url: server.URL})
+	require.NoError(t, err)
+
+	addWebhookTestUser(t, ctx)
+
+	for range 2 {
+		select {
+		case <-received:
+		case <-time.After(30 * time.Second):
+			t.Fatal("webhook was not retried")
+		}
+	}
+
+	require.Eventually(t, func() bool {
+		deliveries, err := testerApp.webhookClient.ListWebhookDeliveries(ctx, &proto.ListWebhookDeliveriesRequest{SubscriptionID: subscription.Subscription.Id})
+		require.NoError(t, err)
+		if len(deliveries.Deliveries) != 1 || deliveries.Deliveries[0].State != "delivered" {
+			return false
+		}
+		attempts := deliveries.Deliveries[0].Attempts
+		return len(attempts) == 2 && attempts[0].StatusCode == http.StatusServiceUnavailable && attempts[1].StatusCode == http.StatusOK
+	}, 5*time.Second, 100*time.Millisecond)
+}
@@ -0,0 +1,61 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"github.com/rs/zerolog/log"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"userservice/internal/domain/domainerror"
+)
+
+var kindToCode = map[domainerror.Kind]codes.Code{
+	domainerror.KindInternal:           codes.Internal,
+	domainerror.KindInvalidArgument:    codes.InvalidArgument,
+	domainerror.KindNotFound:           codes.NotFound,
+	domainerror.KindAlreadyExists:      codes.AlreadyExists,
+	domainerror.KindFailedPrecondition: codes.FailedPrecondition,
+}
+
+// toStatusError translates errors coming from the domain into grpc status errors, field violations are attached as
+// errdetails.BadRequest so clients can point at the offending field
+func toStatusError(err error) error {
+	if err == nil {
+		return nil
+	}
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return status.FromContextError(err).Err()
+	}
+
+	domainErr, ok := domainerror.As(err)
+	if !ok {
+		// unclassified errors can leak details about the database, so only log them
+		log.Error().Err(err).Msg("API: unclassified error, reporting internal error")
+		return status.Error(codes.Internal, "internal error")
+	}
+
+	code, ok := kindToCode[domainErr.Kind]
+	if !ok {
+		code = codes.Internal
+	}
+
+	st := status.New(code, domainErr.Message)
+	if len(domainErr.Violations) == 0 {
+		return st.Err()
+	}
+
+	badRequest := &errdetails.BadRequest{}
+	for _, violation := range domainErr.Violations {
+		badRequest.FieldViolations = append(badRequest.FieldViolations, &errdetails.BadRequest_FieldViolation{
+			Field:       violation.Field,
+			Description: violation.Description,
+		})
+	}
+	detailedStatus, detailErr := st.WithDetails(badRequest)
+	if detailErr != nil {
+		log.Warn().Err(detailErr).Msg("API: failed attaching field violations to status")
+		return st.Err()
+	}
+	return detailedStatus.Err()
+}
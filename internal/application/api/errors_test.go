@@ -0,0 +1,57 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"testing"
+	"userservice/internal/domain/domainerror"
+)
+
+func TestToStatusErrorCodes(t *testing.T) {
+
+	tests := []struct {
+		err  error
+		code codes.Code
+	}{
+		{domainerror.InvalidArgument("bad"), codes.InvalidArgument},
+		{domainerror.NotFound("missing"), codes.NotFound},
+		{domainerror.AlreadyExists("exists"), codes.AlreadyExists},
+		{domainerror.FailedPrecondition("precondition"), codes.FailedPrecondition},
+		{domainerror.Internal("internal"), codes.Internal},
+		{fmt.Errorf("wrapped: %w", domainerror.NotFound("missing")), codes.NotFound},
+		{errors.New("some database error"), codes.Internal},
+		{context.DeadlineExceeded, codes.DeadlineExceeded},
+	}
+	for _, test := range tests {
+		st, ok := status.FromError(toStatusError(test.err))
+		require.True(t, ok)
+		require.Equal(t, test.code, st.Code(), test.err.Error())
+	}
+
+	require.NoError(t, toStatusError(nil))
+}
+
+func TestToStatusErrorHidesUnclassifiedMessages(t *testing.T) {
+	st, ok := status.FromError(toStatusError(errors.New("connection to 10.0.0.1 refused")))
+	require.True(t, ok)
+	require.NotContains(t, st.Message(), "10.0.0.1")
+}
+
+func TestToStatusErrorFieldViolations(t *testing.T) {
+	st, ok := status.FromError(toStatusError(domainerror.InvalidField("email", "request email is not valid")))
+	require.True(t, ok)
+	require.Equal(t, codes.InvalidArgument, st.Code())
+
+	details := st.Details()
+	require.Len(t, details, 1)
+	badRequest, ok := details[0].(*errdetails.BadRequest)
+	require.True(t, ok)
+	require.Len(t, badRequest.FieldViolations, 1)
+	require.Equal(t, "email", badRequest.FieldViolations[0].Field)
+	require.Equal(t, "request email is not valid", badRequest.FieldViolations[0].Description)
+}
@@ -5,32 +5,80 @@ import (
 	"encoding/json"
 	"fmt"
 	"github.com/rs/zerolog/log"
+	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/status"
 	"net/http"
 	"sync"
 	"time"
 	"userservice/internal/config"
 	"userservice/internal/infrastructure/health"
+	"userservice/internal/infrastructure/metrics"
 )
 
+// aggregateServiceName is the service name clients use for the health of the service as a whole
+const aggregateServiceName = ""
+
+// defaultHistorySize is used when no historySize is configured
+const defaultHistorySize = 100
+
+type Readiness string
+
+const (
+	ReadinessReady Readiness = "ready"
+	// ReadinessDegraded is still ready, only non critical dependencies are not serving
+	ReadinessDegraded Readiness = "degraded"
+	ReadinessNotReady Readiness = "not_ready"
+)
+
+// watcher is a Watch call, updates holds the latest status of its service that was not sent yet
+type watcher struct {
+	service string
+	updates chan grpc_health_v1.HealthCheckResponse_ServingStatus
+}
+
+// notify replaces a status the watcher did not pick up yet, it must be called with statusMapLock held so it is the
+// only sender
+func (w *watcher) notify(status grpc_health_v1.HealthCheckResponse_ServingStatus) {
+	select {
+	case <-w.updates:
+	default:
+	}
+	w.updates <- status
+}
+
 type HealthCheckController struct {
 	grpc_health_v1.UnimplementedHealthServer
 	config              config.HealthCheckerConfig
 	statusMapLock       sync.Mutex
 	statusMap           map[string]grpc_health_v1.HealthCheckResponse_ServingStatus
+	criticalities       map[string]health.Criticality
+	diagnostics         map[string]*health.Diagnostics
+	history             *health.History
+	watchers            map[*watcher]struct{}
 	healthReportChannel chan health.Report
 	startTime           time.Time
+	metrics             *metrics.Metrics
 }
 
-func NewHealthCheckController(config config.HealthCheckerConfig) *HealthCheckController {
+func NewHealthCheckController(config config.HealthCheckerConfig, metrics *metrics.Metrics) *HealthCheckController {
 	status := make(map[string]grpc_health_v1.HealthCheckResponse_ServingStatus)
+	historySize := config.HistorySize
+	if historySize <= 0 {
+		historySize = defaultHistorySize
+	}
 
 	return &HealthCheckController{
 		config:              config,
 		statusMapLock:       sync.Mutex{},
 		healthReportChannel: make(chan health.Report),
 		statusMap:           status,
+		criticalities:       make(map[string]health.Criticality),
+		diagnostics:         make(map[string]*health.Diagnostics),
+		history:             health.NewHistory(historySize),
+		watchers:            make(map[*watcher]struct{}),
 		startTime:           time.Now().UTC(),
+		metrics:             metrics,
 	}
 }
 
@@ -46,7 +94,13 @@ func convertToGRPCStatus(status health.Status) grpc_health_v1.HealthCheckRespons
 	return grpc_health_v1.HealthCheckResponse_UNKNOWN
 }
 
-func (h *HealthCheckController) RegisterHealthCheckable(ctx context.Context, service health.Checkable) {
+// RegisterHealthCheckable starts checking service, until it reported the service is not ready when it is critical
+func (h *HealthCheckController) RegisterHealthCheckable(ctx context.Context, service health.Checkable, criticality health.Criticality) {
+	h.statusMapLock.Lock()
+	h.criticalities[service.GetName()] = criticality
+	h.diagnostics[service.GetName()] = &health.Diagnostics{}
+	h.statusMapLock.Unlock()
+
 	h.setStatus(service.GetName(), grpc_health_v1.HealthCheckResponse_SERVICE_UNKNOWN)
 	service.RunHealthCheck(ctx, h.healthReportChannel, time.Duration(h.config.HealthCheckTickerIntervalSeconds)*time.Second)
 }
@@ -54,79 +108,288 @@ func (h *HealthCheckController) RegisterHealthCheckable(ctx context.Context, ser
 func (h *HealthCheckController) setStatus(service string, serving grpc_health_v1.HealthCheckResponse_ServingStatus) {
 	h.statusMapLock.Lock()
 	defer h.statusMapLock.Unlock()
+	h.updateStatus(service, serving, time.Now().UTC(), "")
+}
+
+// record takes in the outcome of a check
+func (h *HealthCheckController) record(report health.Report) {
+	h.statusMapLock.Lock()
+	defer h.statusMapLock.Unlock()
+	diagnostics, ok := h.diagnostics[report.ServiceName]
+	if !ok {
+		diagnostics = &health.Diagnostics{}
+		h.diagnostics[report.ServiceName] = diagnostics
+	}
+	diagnostics.Record(report)
+	h.updateStatus(report.ServiceName, convertToGRPCStatus(report.Status), report.CheckedAt, report.Error)
+}
+
+// updateStatus must be called with statusMapLock held. A change is kept in the history and the watchers are told about
+// it, of the service itself and of the aggregate status
+func (h *HealthCheckController) updateStatus(service string, serving grpc_health_v1.HealthCheckResponse_ServingStatus, at time.Time, cause string) {
+	previous, ok := h.statusMap[service]
+	if ok && previous == serving {
+		return
+	}
 	h.statusMap[service] = serving
+	h.metrics.SetHealthStatus(service, serving == grpc_health_v1.HealthCheckResponse_SERVING)
+	if ok {
+		h.history.Add(health.Transition{ServiceName: service, From: previous.String(), To: serving.String(), At: at, Error: cause})
+	}
+
+	for w := range h.watchers {
+		if w.service == service || w.service == aggregateServiceName {
+			status, _ := h.statusOf(w.service)
+			w.notify(status)
+		}
+	}
 }
 
-func (h *HealthCheckController) getStatus(serviceName string) grpc_health_v1.HealthCheckResponse_ServingStatus {
+// getStatus returns false for services that were never registered
+func (h *HealthCheckController) getStatus(serviceName string) (grpc_health_v1.HealthCheckResponse_ServingStatus, bool) {
 	h.statusMapLock.Lock()
 	defer h.statusMapLock.Unlock()
-	_, ok := h.statusMap[serviceName]
+	return h.statusOf(serviceName)
+}
+
+// statusOf must be called with statusMapLock held, services that were never registered are SERVICE_UNKNOWN
+func (h *HealthCheckController) statusOf(serviceName string) (grpc_health_v1.HealthCheckResponse_ServingStatus, bool) {
+	if serviceName == aggregateServiceName {
+		return h.aggregateStatus(), true
+	}
+	status, ok := h.statusMap[serviceName]
 	if !ok {
-		log.Warn().Msgf("serviceName %s is not found in status map! check constructor setting of map - setting value in map", serviceName)
-		h.statusMap[serviceName] = grpc_health_v1.HealthCheckResponse_NOT_SERVING
+		return grpc_health_v1.HealthCheckResponse_SERVICE_UNKNOWN, false
 	}
-	return h.statusMap[serviceName]
+	return status, true
 }
 
-func (h *HealthCheckController) Run(ctx context.Context) {
+// subscribe registers a watcher of service, its current status is waiting for it right away
+func (h *HealthCheckController) subscribe(service string) *watcher {
+	h.statusMapLock.Lock()
+	defer h.statusMapLock.Unlock()
+	w := &watcher{service: service, updates: make(chan grpc_health_v1.HealthCheckResponse_ServingStatus, 1)}
+	status, _ := h.statusOf(service)
+	w.notify(status)
+	h.watchers[w] = struct{}{}
+	return w
+}
+
+func (h *HealthCheckController) unsubscribe(w *watcher) {
+	h.statusMapLock.Lock()
+	defer h.statusMapLock.Unlock()
+	delete(h.watchers, w)
+}
 
-	sleepInterval := time.Duration(h.config.TickerIntervalSeconds) * time.Second
+// readiness must be called with statusMapLock held. Critical dependencies that did not report yet make the service not
+// ready
+func (h *HealthCheckController) readiness() Readiness {
+	readiness := ReadinessReady
+	for serviceName, status := range h.statusMap {
+		if status == grpc_health_v1.HealthCheckResponse_SERVING {
+			continue
+		}
+		if h.criticalities[serviceName] != health.CriticalityNonCritical {
+			return ReadinessNotReady
+		}
+		readiness = ReadinessDegraded
+	}
+	return readiness
+}
+
+// aggregateStatus must be called with statusMapLock held, a degraded service is serving
+func (h *HealthCheckController) aggregateStatus() grpc_health_v1.HealthCheckResponse_ServingStatus {
+	if h.readiness() == ReadinessNotReady {
+		return grpc_health_v1.HealthCheckResponse_NOT_SERVING
+	}
+	return grpc_health_v1.HealthCheckResponse_SERVING
+}
+
+func (h *HealthCheckController) Run(ctx context.Context) {
 
 	for {
-		log.Info().Msg("HealthChecker: checking status")
 		select {
 		case report := <-h.healthReportChannel:
-			h.setStatus(report.ServiceName, convertToGRPCStatus(report.Status))
+			h.record(report)
 		case <-ctx.Done():
-			break
+			return
 		}
-		time.Sleep(sleepInterval)
 	}
 }
 
 func (h *HealthCheckController) Check(ctx context.Context, req *grpc_health_v1.HealthCheckRequest) (*grpc_health_v1.HealthCheckResponse, error) {
-	h.statusMapLock.Lock()
-	defer h.statusMapLock.Unlock()
-
-	resp := &grpc_health_v1.HealthCheckResponse{}
-	resp.Status = h.getStatus(req.Service)
-	return resp, nil
+	serving, ok := h.getStatus(req.Service)
+	if !ok {
+		return nil, status.Errorf(codes.NotFound, "unknown service %s", req.Service)
+	}
+	return &grpc_health_v1.HealthCheckResponse{Status: serving}, nil
 }
 
+// Watch sends the status of the service right away and then every change of it, until the client goes away. Unknown
+// services are SERVICE_UNKNOWN until they are registered
 func (h *HealthCheckController) Watch(req *grpc_health_v1.HealthCheckRequest, server grpc_health_v1.Health_WatchServer) error {
+	w := h.subscribe(req.Service)
+	defer h.unsubscribe(w)
 
+	sent := false
+	var lastSent grpc_health_v1.HealthCheckResponse_ServingStatus
 	for {
-		time.Sleep(5 * time.Second)
-		err := server.Send(&grpc_health_v1.HealthCheckResponse{Status: h.getStatus(req.Service)})
-		if err != nil {
-			return err
+		select {
+		case <-server.Context().Done():
+			return status.Error(codes.Canceled, "stream has ended")
+		case serving := <-w.updates:
+			// statuses can flip back before the watcher got to them, that is not a change
+			if sent && serving == lastSent {
+				continue
+			}
+			err := server.Send(&grpc_health_v1.HealthCheckResponse{Status: serving})
+			if err != nil {
+				return err
+			}
+			sent = true
+			lastSent = serving
 		}
 	}
 }
 
-func (h *HealthCheckController) ServeOrdinaryHealthEndpoint() {
-	type OrdinaryHealthCheckResponse struct {
-		Uptime string   `json:"uptime"`
-		Status []string `json:"status"`
+type dependencyStatus struct {
+	Status   string `json:"status"`
+	Critical bool   `json:"critical"`
+}
+
+type readinessResponse struct {
+	Status       Readiness                   `json:"status"`
+	Dependencies map[string]dependencyStatus `json:"dependencies"`
+}
+
+func writeJSON(w http.ResponseWriter, statusCode int, body any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(statusCode)
+	err := json.NewEncoder(w).Encode(body)
+	if err != nil {
+		log.Warn().Err(err).Msg("failed writing health response")
+	}
+}
+
+// livez answers as long as the process can serve requests, the dependencies are left to readyz so a broken dependency
+// does not get the service restarted
+func (h *HealthCheckController) livez(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, http.StatusOK, map[string]string{
+		"status": "alive",
+		"uptime": time.Since(h.startTime).String(),
+	})
+}
+
+// readyz is 503 while a critical dependency is not serving, a degraded service keeps receiving traffic
+func (h *HealthCheckController) readyz(w http.ResponseWriter, r *http.Request) {
+	h.statusMapLock.Lock()
+	resp := readinessResponse{Status: h.readiness(), Dependencies: map[string]dependencyStatus{}}
+	for serviceName, status := range h.statusMap {
+		resp.Dependencies[serviceName] = dependencyStatus{
+			Status:   status.String(),
+			Critical: h.criticalities[serviceName] != health.CriticalityNonCritical,
+		}
 	}
-	http.HandleFunc("/healthz", func(w http.ResponseWriter, r *http.Request) {
+	h.statusMapLock.Unlock()
 
-		h.statusMapLock.Lock()
-		defer h.statusMapLock.Unlock()
+	statusCode := http.StatusOK
+	if resp.Status == ReadinessNotReady {
+		statusCode = http.StatusServiceUnavailable
+	}
+	writeJSON(w, statusCode, resp)
+}
+
+type dependencyDiagnostics struct {
+	Status              string     `json:"status"`
+	Critical            bool       `json:"critical"`
+	LatencyMillis       float64    `json:"latencyMillis"`
+	LastCheckedAt       *time.Time `json:"lastCheckedAt,omitempty"`
+	LastSuccessAt       *time.Time `json:"lastSuccessAt,omitempty"`
+	LastError           string     `json:"lastError,omitempty"`
+	LastErrorAt         *time.Time `json:"lastErrorAt,omitempty"`
+	ConsecutiveFailures int        `json:"consecutiveFailures"`
+	// Details are what the last check measured, like the lag of the outbox
+	Details health.Details `json:"details,omitempty"`
+}
+
+type transitionResponse struct {
+	Service string    `json:"service"`
+	From    string    `json:"from"`
+	To      string    `json:"to"`
+	At      time.Time `json:"at"`
+	Error   string    `json:"error,omitempty"`
+}
+
+type healthzResponse struct {
+	Uptime       string                           `json:"uptime"`
+	Status       Readiness                        `json:"status"`
+	Dependencies map[string]dependencyDiagnostics `json:"dependencies"`
+	// Transitions are the latest status changes, oldest first
+	Transitions []transitionResponse `json:"transitions"`
+}
+
+// optionalTime leaves out times that never happened
+func optionalTime(t time.Time) *time.Time {
+	if t.IsZero() {
+		return nil
+	}
+	return &t
+}
 
-		resp := OrdinaryHealthCheckResponse{
-			Status: []string{},
-			Uptime: fmt.Sprintf("up for %s", time.Since(h.startTime)),
+// healthz tells what the checks of every dependency found out, and how their statuses changed lately
+func (h *HealthCheckController) healthz(w http.ResponseWriter, r *http.Request) {
+	h.statusMapLock.Lock()
+	resp := healthzResponse{
+		Uptime:       fmt.Sprintf("up for %s", time.Since(h.startTime)),
+		Status:       h.readiness(),
+		Dependencies: map[string]dependencyDiagnostics{},
+		Transitions:  []transitionResponse{},
+	}
+	for serviceName, status := range h.statusMap {
+		dependency := dependencyDiagnostics{
+			Status:   status.String(),
+			Critical: h.criticalities[serviceName] != health.CriticalityNonCritical,
 		}
-		for s, status := range h.statusMap {
-			resp.Status = append(resp.Status, fmt.Sprintf("%s: %s", s, status.String()))
+		if diagnostics, ok := h.diagnostics[serviceName]; ok {
+			dependency.LatencyMillis = float64(diagnostics.Latency) / float64(time.Millisecond)
+			dependency.LastCheckedAt = optionalTime(diagnostics.LastCheckedAt)
+			dependency.LastSuccessAt = optionalTime(diagnostics.LastSuccessAt)
+			dependency.LastError = diagnostics.LastError
+			dependency.LastErrorAt = optionalTime(diagnostics.LastErrorAt)
+			dependency.ConsecutiveFailures = diagnostics.ConsecutiveFailures
+			dependency.Details = diagnostics.Details
 		}
-		w.Header().Set("Content-Type", "application/json")
-		json.NewEncoder(w).Encode(resp)
-	})
+		resp.Dependencies[serviceName] = dependency
+	}
+	for _, transition := range h.history.Transitions() {
+		resp.Transitions = append(resp.Transitions, transitionResponse{
+			Service: transition.ServiceName,
+			From:    transition.From,
+			To:      transition.To,
+			At:      transition.At,
+			Error:   transition.Error,
+		})
+	}
+	h.statusMapLock.Unlock()
+
+	writeJSON(w, http.StatusOK, resp)
+}
+
+// Handler serves the health endpoints, /livez and /readyz are meant for the liveness and readiness probes. The
+// prometheus metrics are served next to them on /metrics
+func (h *HealthCheckController) Handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/healthz", h.healthz)
+	mux.HandleFunc("/livez", h.livez)
+	mux.HandleFunc("/readyz", h.readyz)
+	mux.Handle("/metrics", h.metrics.Handler())
+	return mux
+}
+
+func (h *HealthCheckController) ServeOrdinaryHealthEndpoint() {
 	portString := fmt.Sprintf(":%d", h.config.OrdinaryHealthCheckListeningPort)
-	log.Info().Msgf("Serving healtcheck at %s/healthz", portString)
-	err := http.ListenAndServe(portString, nil)
+	log.Info().Msgf("Serving healtcheck at %s/healthz, %s/livez and %s/readyz and metrics at %s/metrics", portString, portString, portString, portString)
+	err := http.ListenAndServe(portString, h.Handler())
 	if err != nil {
 		log.Panic().Msgf("failed to listen on port %s", portString)
 	}
@@ -0,0 +1,341 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/status"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+	"userservice/internal/config"
+	"userservice/internal/infrastructure/health"
+	"userservice/internal/infrastructure/metrics"
+)
+
+// reportedCheckable leaves the reports to the test
+type reportedCheckable struct {
+	name string
+}
+
+func (r reportedCheckable) GetName() string {
+	return r.name
+}
+
+func (r reportedCheckable) RunHealthCheck(checkContext context.Context, reportChannel chan health.Report, checkTimeInterval time.Duration) {
+}
+
+func newTestHealthCheckController(t *testing.T) *HealthCheckController {
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+	controller := NewHealthCheckController(config.HealthCheckerConfig{HealthCheckTickerIntervalSeconds: 1}, metrics.NewMetrics())
+	controller.RegisterHealthCheckable(ctx, reportedCheckable{name: "MongoDB"}, health.CriticalityCritical)
+	controller.RegisterHealthCheckable(ctx, reportedCheckable{name: "Kafka"}, health.CriticalityNonCritical)
+	go controller.Run(ctx)
+	return controller
+}
+
+func report(t *testing.T, controller *HealthCheckController, serviceName string, serving health.Status) {
+	controller.healthReportChannel <- health.Report{ServiceName: serviceName, Status: serving}
+	require.Eventually(t, func() bool {
+		status, _ := controller.getStatus(serviceName)
+		return status == convertToGRPCStatus(serving)
+	}, time.Second, time.Millisecond)
+}
+
+func requireCheck(t *testing.T, controller *HealthCheckController, serviceName string, expected grpc_health_v1.HealthCheckResponse_ServingStatus) {
+	response, err := controller.Check(context.Background(), &grpc_health_v1.HealthCheckRequest{Service: serviceName})
+	require.NoError(t, err)
+	require.Equal(t, expected, response.Status)
+}
+
+func getReadiness(t *testing.T, controller *HealthCheckController) (int, readinessResponse) {
+	recorder := httptest.NewRecorder()
+	controller.Handler().ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/readyz", nil))
+	require.Equal(t, "application/json", recorder.Header().Get("Content-Type"))
+	var response readinessResponse
+	require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &response))
+	return recorder.Code, response
+}
+
+func TestNotReadyUntilCriticalDependenciesReported(t *testing.T) {
+	controller := newTestHealthCheckController(t)
+
+	code, response := getReadiness(t, controller)
+	require.Equal(t, http.StatusServiceUnavailable, code)
+	require.Equal(t, ReadinessNotReady, response.Status)
+	require.Equal(t, dependencyStatus{Status: "SERVICE_UNKNOWN", Critical: true}, response.Dependencies["MongoDB"])
+	require.Equal(t, dependencyStatus{Status: "SERVICE_UNKNOWN", Critical: false}, response.Dependencies["Kafka"])
+	requireCheck(t, controller, "", grpc_health_v1.HealthCheckResponse_NOT_SERVING)
+
+	report(t, controller, "MongoDB", health.StatusServing)
+	report(t, controller, "Kafka", health.StatusServing)
+	code, response = getReadiness(t, controller)
+	require.Equal(t, http.StatusOK, code)
+	require.Equal(t, ReadinessReady, response.Status)
+	requireCheck(t, controller, "", grpc_health_v1.HealthCheckResponse_SERVING)
+}
+
+func TestNonCriticalOutageIsDegradedButReady(t *testing.T) {
+	controller := newTestHealthCheckController(t)
+	report(t, controller, "MongoDB", health.StatusServing)
+	report(t, controller, "Kafka", health.StatusNotServing)
+
+	code, response := getReadiness(t, controller)
+	require.Equal(t, http.StatusOK, code)
+	require.Equal(t, ReadinessDegraded, response.Status)
+	requireCheck(t, controller, "", grpc_health_v1.HealthCheckResponse_SERVING)
+	requireCheck(t, controller, "Kafka", grpc_health_v1.HealthCheckResponse_NOT_SERVING)
+}
+
+func TestCriticalOutageIsNotReady(t *testing.T) {
+	controller := newTestHealthCheckController(t)
+	report(t, controller, "MongoDB", health.StatusNotServing)
+	report(t, controller, "Kafka", health.StatusServing)
+
+	code, response := getReadiness(t, controller)
+	require.Equal(t, http.StatusServiceUnavailable, code)
+	require.Equal(t, ReadinessNotReady, response.Status)
+	requireCheck(t, controller, "", grpc_health_v1.HealthCheckResponse_NOT_SERVING)
+	requireCheck(t, controller, "MongoDB", grpc_health_v1.HealthCheckResponse_NOT_SERVING)
+}
+
+func TestLivenessIgnoresDependencies(t *testing.T) {
+	controller := newTestHealthCheckController(t)
+	report(t, controller, "MongoDB", health.StatusNotServing)
+
+	recorder := httptest.NewRecorder()
+	controller.Handler().ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/livez", nil))
+	require.Equal(t, http.StatusOK, recorder.Code)
+}
+
+func TestCheckUnknownService(t *testing.T) {
+	controller := newTestHealthCheckController(t)
+
+	_, err := controller.Check(context.Background(), &grpc_health_v1.HealthCheckRequest{Service: "Redis"})
+	require.Equal(t, codes.NotFound, status.Code(err))
+}
+
+// watchStream collects what Watch sends, the grpc.ServerStream methods it does not use are left nil
+type watchStream struct {
+	grpc.ServerStream
+	ctx  context.Context
+	sent chan grpc_health_v1.HealthCheckResponse_ServingStatus
+}
+
+func (w *watchStream) Context() context.Context {
+	return w.ctx
+}
+
+func (w *watchStream) Send(response *grpc_health_v1.HealthCheckResponse) error {
+	w.sent <- response.Status
+	return nil
+}
+
+// startWatch returns the stream of the Watch call and the error it ended with
+func startWatch(t *testing.T, controller *HealthCheckController, serviceName string) (*watchStream, context.CancelFunc, chan error) {
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+	stream := &watchStream{ctx: ctx, sent: make(chan grpc_health_v1.HealthCheckResponse_ServingStatus, 10)}
+	done := make(chan error, 1)
+	go func() {
+		done <- controller.Watch(&grpc_health_v1.HealthCheckRequest{Service: serviceName}, stream)
+	}()
+	return stream, cancel, done
+}
+
+func requireSent(t *testing.T, stream *watchStream, expected grpc_health_v1.HealthCheckResponse_ServingStatus) {
+	select {
+	case status := <-stream.sent:
+		require.Equal(t, expected, status)
+	case <-time.After(time.Second):
+		require.Fail(t, "nothing sent", "expected %s", expected)
+	}
+}
+
+func requireNothingSent(t *testing.T, stream *watchStream) {
+	select {
+	case status := <-stream.sent:
+		require.Fail(t, "unexpected send", "sent %s", status)
+	case <-time.After(20 * time.Millisecond):
+	}
+}
+
+func watcherCount(controller *HealthCheckController) int {
+	controller.statusMapLock.Lock()
+	defer controller.statusMapLock.Unlock()
+	return len(controller.watchers)
+}
+
+func TestWatchSendsCurrentStatusRightAway(t *testing.T) {
+	controller := newTestHealthCheckController(t)
+	report(t, controller, "MongoDB", health.StatusServing)
+
+	stream, _, _ := startWatch(t, controller, "MongoDB")
+	requireSent(t, stream, grpc_health_v1.HealthCheckResponse_SERVING)
+	requireNothingSent(t, stream)
+
+	unknown, _, _ := startWatch(t, controller, "Redis")
+	requireSent(t, unknown, grpc_health_v1.HealthCheckResponse_SERVICE_UNKNOWN)
+}
+
+func TestWatchOnlySendsChanges(t *testing.T) {
+	controller := newTestHealthCheckController(t)
+	stream, _, _ := startWatch(t, controller, "MongoDB")
+	requireSent(t, stream, grpc_health_v1.HealthCheckResponse_SERVICE_UNKNOWN)
+
+	report(t, controller, "MongoDB", health.StatusServing)
+	requireSent(t, stream, grpc_health_v1.HealthCheckResponse_SERVING)
+
+	// the same status again, and changes of other services, are not sent
+	report(t, controller, "MongoDB", health.StatusServing)
+	report(t, controller, "Kafka", health.StatusNotServing)
+	requireNothingSent(t, stream)
+
+	report(t, controller, "MongoDB", health.StatusNotServing)
+	requireSent(t, stream, grpc_health_v1.HealthCheckResponse_NOT_SERVING)
+}
+
+func TestWatchAggregateStatus(t *testing.T) {
+	controller := newTestHealthCheckController(t)
+	stream, _, _ := startWatch(t, controller, "")
+	requireSent(t, stream, grpc_health_v1.HealthCheckResponse_NOT_SERVING)
+
+	report(t, controller, "MongoDB", health.StatusServing)
+	requireSent(t, stream, grpc_health_v1.HealthCheckResponse_SERVING)
+
+	// kafka going down only degrades the service, it keeps serving
+	report(t, controller, "Kafka", health.StatusNotServing)
+	requireNothingSent(t, stream)
+}
+
+func TestConcurrentWatchers(t *testing.T) {
+	controller := newTestHealthCheckController(t)
+
+	const watchersPerService = 20
+	// nothing reported yet, which makes the service not serving
+	initialStatus := map[string]grpc_health_v1.HealthCheckResponse_ServingStatus{
+		"":        grpc_health_v1.HealthCheckResponse_NOT_SERVING,
+		"MongoDB": grpc_health_v1.HealthCheckResponse_SERVICE_UNKNOWN,
+	}
+	var streams []*watchStream
+	var cancels []context.CancelFunc
+	var dones []chan error
+	for range watchersPerService {
+		for _, serviceName := range []string{"", "MongoDB"} {
+			stream, cancel, done := startWatch(t, controller, serviceName)
+			requireSent(t, stream, initialStatus[serviceName])
+			streams = append(streams, stream)
+			cancels = append(cancels, cancel)
+			dones = append(dones, done)
+		}
+	}
+	require.Equal(t, 2*watchersPerService, watcherCount(controller))
+
+	report(t, controller, "MongoDB", health.StatusServing)
+	for _, stream := range streams {
+		requireSent(t, stream, grpc_health_v1.HealthCheckResponse_SERVING)
+	}
+
+	// cancelled watchers end with CANCELED and are forgotten, the others keep receiving changes
+	for i := 0; i < len(cancels); i += 2 {
+		cancels[i]()
+		require.Equal(t, codes.Canceled, status.Code(<-dones[i]))
+	}
+	require.Equal(t, watchersPerService, watcherCount(controller))
+
+	report(t, controller, "MongoDB", health.StatusNotServing)
+	for i := 1; i < len(streams); i += 2 {
+		requireSent(t, streams[i], grpc_health_v1.HealthCheckResponse_NOT_SERVING)
+	}
+
+	for _, cancel := range cancels {
+		cancel()
+	}
+	require.Eventually(t, func() bool {
+		return watcherCount(controller) == 0
+	}, time.Second, time.Millisecond)
+}
+
+func getDiagnostics(t *testing.T, controller *HealthCheckController) healthzResponse {
+	recorder := httptest.NewRecorder()
+	controller.Handler().ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/healthz", nil))
+	require.Equal(t, http.StatusOK, recorder.Code)
+	var response healthzResponse
+	require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &response))
+	return response
+}
+
+func TestHealthzShowsDiagnostics(t *testing.T) {
+	controller := newTestHealthCheckController(t)
+	checkedAt := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+
+	controller.record(health.Report{ServiceName: "MongoDB", Status: health.StatusServing, CheckedAt: checkedAt, Latency: 3 * time.Millisecond})
+	controller.record(health.Report{ServiceName: "Kafka", Status: health.StatusNotServing, CheckedAt: checkedAt, Latency: 10 * time.Second, Error: "timed out"})
+	controller.record(health.Report{ServiceName: "Kafka", Status: health.StatusNotServing, CheckedAt: checkedAt.Add(time.Minute), Latency: 10 * time.Second, Error: "broker down"})
+
+	response := getDiagnostics(t, controller)
+	require.Equal(t, ReadinessDegraded, response.Status)
+	lastErrorAt := checkedAt.Add(time.Minute)
+	require.Equal(t, dependencyDiagnostics{
+		Status:              "NOT_SERVING",
+		Critical:            false,
+		LatencyMillis:       10000,
+		LastCheckedAt:       &lastErrorAt,
+		LastError:           "broker down",
+		LastErrorAt:         &lastErrorAt,
+		ConsecutiveFailures: 2,
+	}, response.Dependencies["Kafka"])
+	require.Equal(t, dependencyDiagnostics{
+		Status:        "SERVING",
+		Critical:      true,
+		LatencyMillis: 3,
+		LastCheckedAt: &checkedAt,
+		LastSuccessAt: &checkedAt,
+	}, response.Dependencies["MongoDB"])
+
+	// the repeated failure is no transition
+	require.Equal(t, []transitionResponse{
+		{Service: "MongoDB", From: "SERVICE_UNKNOWN", To: "SERVING", At: checkedAt},
+		{Service: "Kafka", From: "SERVICE_UNKNOWN", To: "NOT_SERVING", At: checkedAt, Error: "timed out"},
+	}, response.Transitions)
+}
+
+func TestHealthzHistoryIsBounded(t *testing.T) {
+	controller := NewHealthCheckController(config.HealthCheckerConfig{HistorySize: 3}, metrics.NewMetrics())
+	controller.RegisterHealthCheckable(context.Background(), reportedCheckable{name: "MongoDB"}, health.CriticalityCritical)
+
+	// a flapping dependency
+	statuses := []health.Status{health.StatusServing, health.StatusNotServing}
+	for i := range 10 {
+		controller.record(health.Report{ServiceName: "MongoDB", Status: statuses[i%2], CheckedAt: time.Now().UTC()})
+	}
+
+	transitions := getDiagnostics(t, controller).Transitions
+	require.Len(t, transitions, 3)
+	require.Equal(t, "NOT_SERVING", transitions[2].To)
+	require.Equal(t, "SERVING", transitions[1].To)
+}
+
+func TestHealthzShowsDetails(t *testing.T) {
+	controller := newTestHealthCheckController(t)
+	controller.RegisterHealthCheckable(context.Background(), reportedCheckable{name: "OutboxLag"}, health.CriticalityNonCritical)
+
+	controller.record(health.Report{
+		ServiceName: "OutboxLag",
+		Status:      health.StatusNotServing,
+		CheckedAt:   time.Now().UTC(),
+		Error:       "3 messages are dead lettered, above 2",
+		Details:     health.Details{"oldestWaitingAgeSeconds": 12, "processing": 4, "deadLettered": 3},
+	})
+
+	dependency := getDiagnostics(t, controller).Dependencies["OutboxLag"]
+	require.Equal(t, "NOT_SERVING", dependency.Status)
+	require.False(t, dependency.Critical)
+	// numbers come back from JSON as float64
+	require.Equal(t, health.Details{"oldestWaitingAgeSeconds": 12.0, "processing": 4.0, "deadLettered": 3.0}, dependency.Details)
+}
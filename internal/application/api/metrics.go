@@ -0,0 +1,29 @@
+package api
+
+import (
+	"context"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/status"
+	"time"
+	"userservice/internal/infrastructure/metrics"
+)
+
+// MetricsUnaryInterceptor counts and times every request by method and status code
+func MetricsUnaryInterceptor(m *metrics.Metrics) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		start := time.Now()
+		resp, err := handler(ctx, req)
+		m.ObserveRPC(info.FullMethod, status.Code(err).String(), time.Since(start))
+		return resp, err
+	}
+}
+
+// MetricsStreamInterceptor does the same for streams, which are timed until they end
+func MetricsStreamInterceptor(m *metrics.Metrics) grpc.StreamServerInterceptor {
+	return func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		start := time.Now()
+		err := handler(srv, ss)
+		m.ObserveRPC(info.FullMethod, status.Code(err).String(), time.Since(start))
+		return err
+	}
+}
@@ -0,0 +1,75 @@
+package api
+
+import (
+	"context"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+	"userservice/internal/config"
+	"userservice/internal/infrastructure/health"
+	"userservice/internal/infrastructure/metrics"
+)
+
+// scrape returns what prometheus would see on /metrics of the health server
+func scrape(t *testing.T, controller *HealthCheckController) string {
+	recorder := httptest.NewRecorder()
+	controller.Handler().ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	require.Equal(t, http.StatusOK, recorder.Code)
+	body, err := io.ReadAll(recorder.Body)
+	require.NoError(t, err)
+	return string(body)
+}
+
+func TestMetricsUnaryInterceptor(t *testing.T) {
+	m := metrics.NewMetrics()
+	interceptor := MetricsUnaryInterceptor(m)
+	info := &grpc.UnaryServerInfo{FullMethod: "/userservice.UserService/GetUser"}
+
+	_, err := interceptor(context.Background(), nil, info, func(ctx context.Context, req any) (any, error) {
+		return nil, nil
+	})
+	require.NoError(t, err)
+	_, err = interceptor(context.Background(), nil, info, func(ctx context.Context, req any) (any, error) {
+		return nil, status.Error(codes.NotFound, "user not found")
+	})
+	require.Equal(t, codes.NotFound, status.Code(err))
+
+	body := scrape(t, NewHealthCheckController(config.HealthCheckerConfig{}, m))
+	require.Contains(t, body, `userservice_grpc_requests_total{code="OK",method="/userservice.UserService/GetUser"} 1`)
+	require.Contains(t, body, `userservice_grpc_requests_total{code="NotFound",method="/userservice.UserService/GetUser"} 1`)
+	require.Contains(t, body, `userservice_grpc_request_duration_seconds_count{code="NotFound",method="/userservice.UserService/GetUser"} 1`)
+}
+
+func TestMetricsStreamInterceptor(t *testing.T) {
+	m := metrics.NewMetrics()
+	info := &grpc.StreamServerInfo{FullMethod: "/grpc.health.v1.Health/Watch", IsServerStream: true}
+
+	err := MetricsStreamInterceptor(m)(nil, nil, info, func(srv any, stream grpc.ServerStream) error {
+		return status.Error(codes.Canceled, "stream has ended")
+	})
+	require.Equal(t, codes.Canceled, status.Code(err))
+
+	body := scrape(t, NewHealthCheckController(config.HealthCheckerConfig{}, m))
+	require.Contains(t, body, `userservice_grpc_requests_total{code="Canceled",method="/grpc.health.v1.Health/Watch"} 1`)
+}
+
+func TestMetricsShowHealthStatus(t *testing.T) {
+	controller := NewHealthCheckController(config.HealthCheckerConfig{}, metrics.NewMetrics())
+	controller.RegisterHealthCheckable(context.Background(), reportedCheckable{name: "MongoDB"}, health.CriticalityCritical)
+	controller.RegisterHealthCheckable(context.Background(), reportedCheckable{name: "Kafka"}, health.CriticalityNonCritical)
+	require.Contains(t, scrape(t, controller), `userservice_health_status{service="MongoDB"} 0`)
+
+	controller.record(health.Report{ServiceName: "MongoDB", Status: health.StatusServing, CheckedAt: time.Now().UTC()})
+	controller.record(health.Report{ServiceName: "Kafka", Status: health.StatusNotServing, CheckedAt: time.Now().UTC()})
+
+	body := scrape(t, controller)
+	require.Contains(t, body, "# TYPE userservice_health_status gauge")
+	require.Contains(t, body, `userservice_health_status{service="MongoDB"} 1`)
+	require.Contains(t, body, `userservice_health_status{service="Kafka"} 0`)
+}
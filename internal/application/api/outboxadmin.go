@@ -0,0 +1,213 @@
+package api
+
+import (
+	"context"
+	"google.golang.org/protobuf/encoding/protojson"
+	"time"
+	"userservice/internal/domain/domainerror"
+	"userservice/internal/domain/replay"
+	"userservice/internal/infrastructure/outbox"
+	"userservice/internal/infrastructure/serialization"
+	"userservice/internal/util/converter"
+	"userservice/proto/grpc"
+)
+
+var ErrMessageIDIsRequired = domainerror.InvalidField("messageID", "message id is required")
+var ErrNegativeMinAge = domainerror.InvalidField("min_age_seconds", "min age can not be negative")
+var ErrInvalidMaxAge = domainerror.InvalidField("max_age_seconds", "max age can not be negative or below min age")
+
+type OutboxAdminController struct {
+	grpc.OutboxAdminServiceServer
+	adminRepo       outbox.AdminRepo
+	outbox          outbox.Outbox
+	replayComponent replay.Component
+	// serializer decodes the payloads of the messages
+	serializer serialization.Serializer
+}
+
+func NewOutboxAdminController(adminRepo outbox.AdminRepo, kafkaOutbox outbox.Outbox, replayComponent replay.Component, serializer serialization.Serializer) *OutboxAdminController {
+	return &OutboxAdminController{adminRepo: adminRepo, outbox: kafkaOutbox, replayComponent: replayComponent, serializer: serializer}
+}
+
+func (s OutboxAdminController) ListDeadLetteredMessages(ctx context.Context, request *grpc.ListDeadLetteredMessagesRequest) (*grpc.ListDeadLetteredMessagesResponse, error) {
+	if request == nil {
+		return nil, toStatusError(ErrRequestIsRequired)
+	}
+
+	limit := request.GetPaging().GetLimit()
+	entries, nextCursor, err := s.adminRepo.ListDeadLetteredMessages(ctx, limit, request.GetPaging().GetCursor())
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+
+	return converter.FromOutboxEntriesToListDeadLetteredResponse(entries, limit, nextCursor), nil
+}
+
+func (s OutboxAdminController) GetDeadLetteredMessage(ctx context.Context, request *grpc.GetDeadLetteredMessageRequest) (*grpc.GetDeadLetteredMessageResponse, error) {
+	if request == nil {
+		return nil, toStatusError(ErrRequestIsRequired)
+	}
+	if request.MessageID == "" {
+		return nil, toStatusError(ErrMessageIDIsRequired)
+	}
+
+	entry, err := s.adminRepo.GetDeadLetteredMessage(ctx, request.MessageID)
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+
+	return &grpc.GetDeadLetteredMessageResponse{Message: converter.FromOutboxEntryToOutboxMessage(entry)}, nil
+}
+
+func (s OutboxAdminController) RequeueDeadLetteredMessage(ctx context.Context, request *grpc.RequeueDeadLetteredMessageRequest) (*grpc.RequeueDeadLetteredMessageResponse, error) {
+	if request == nil {
+		return nil, toStatusError(ErrRequestIsRequired)
+	}
+	if request.MessageID == "" {
+		return nil, toStatusError(ErrMessageIDIsRequired)
+	}
+
+	entry, err := s.adminRepo.RequeueDeadLetteredMessage(ctx, request.MessageID)
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+
+	return &grpc.RequeueDeadLetteredMessageResponse{Message: converter.FromOutboxEntryToOutboxMessage(entry)}, nil
+}
+
+func (s OutboxAdminController) DiscardDeadLetteredMessage(ctx context.Context, request *grpc.DiscardDeadLetteredMessageRequest) (*grpc.DiscardDeadLetteredMessageResponse, error) {
+	if request == nil {
+		return nil, toStatusError(ErrRequestIsRequired)
+	}
+	if request.MessageID == "" {
+		return nil, toStatusError(ErrMessageIDIsRequired)
+	}
+
+	entry, err := s.adminRepo.DiscardDeadLetteredMessage(ctx, request.MessageID)
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+
+	return &grpc.DiscardDeadLetteredMessageResponse{Message: converter.FromOutboxEntryToOutboxMessage(entry)}, nil
+}
+
+func (s OutboxAdminController) GetOutboxStatus(ctx context.Context, request *grpc.GetOutboxStatusRequest) (*grpc.GetOutboxStatusResponse, error) {
+	if request == nil {
+		return nil, toStatusError(ErrRequestIsRequired)
+	}
+
+	counts, err := s.adminRepo.CountOutboxMessages(ctx)
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+
+	return converter.FromOutboxStatusToResponse(s.outbox.Status(), counts), nil
+}
+
+func (s OutboxAdminController) ListOutboxMessages(ctx context.Context, request *grpc.ListOutboxMessagesRequest) (*grpc.ListOutboxMessagesResponse, error) {
+	if request == nil {
+		return nil, toStatusError(ErrRequestIsRequired)
+	}
+	if request.MinAgeSeconds < 0 {
+		return nil, toStatusError(ErrNegativeMinAge)
+	}
+	if request.MaxAgeSeconds < 0 || (request.MaxAgeSeconds > 0 && request.MaxAgeSeconds < request.MinAgeSeconds) {
+		return nil, toStatusError(ErrInvalidMaxAge)
+	}
+
+	limit := request.GetPaging().GetLimit()
+	filter := converter.ToOutboxFilter(request, time.Now().UTC())
+	entries, nextCursor, err := s.adminRepo.ListOutboxMessages(ctx, filter, limit, request.GetPaging().GetCursor())
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+
+	return converter.FromOutboxEntriesToListOutboxMessagesResponse(entries, limit, nextCursor), nil
+}
+
+func (s OutboxAdminController) GetOutboxMessage(ctx context.Context, request *grpc.GetOutboxMessageRequest) (*grpc.GetOutboxMessageResponse, error) {
+	if request == nil {
+		return nil, toStatusError(ErrRequestIsRequired)
+	}
+	if request.MessageID == "" {
+		return nil, toStatusError(ErrMessageIDIsRequired)
+	}
+
+	entry, err := s.adminRepo.GetOutboxMessage(ctx, request.MessageID)
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+
+	// a payload that can not be decoded is reported next to the message, which is still worth looking at
+	response := &grpc.GetOutboxMessageResponse{Message: converter.FromOutboxEntryToOutboxMessage(entry)}
+	event, err := outbox.DecodePayload(s.serializer, entry)
+	if err == nil {
+		var payload []byte
+		payload, err = protojson.Marshal(event)
+		response.Payload = string(payload)
+	}
+	if err != nil {
+		response.PayloadError = err.Error()
+	}
+	return response, nil
+}
+
+func (s OutboxAdminController) RetryOutboxMessage(ctx context.Context, request *grpc.RetryOutboxMessageRequest) (*grpc.RetryOutboxMessageResponse, error) {
+	if request == nil {
+		return nil, toStatusError(ErrRequestIsRequired)
+	}
+	if request.MessageID == "" {
+		return nil, toStatusError(ErrMessageIDIsRequired)
+	}
+
+	entry, err := s.adminRepo.RetryOutboxMessage(ctx, request.MessageID)
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+
+	return &grpc.RetryOutboxMessageResponse{Message: converter.FromOutboxEntryToOutboxMessage(entry)}, nil
+}
+
+func (s OutboxAdminController) RepublishOutboxMessage(ctx context.Context, request *grpc.RepublishOutboxMessageRequest) (*grpc.RepublishOutboxMessageResponse, error) {
+	if request == nil {
+		return nil, toStatusError(ErrRequestIsRequired)
+	}
+	if request.MessageID == "" {
+		return nil, toStatusError(ErrMessageIDIsRequired)
+	}
+
+	entry, err := s.adminRepo.RepublishOutboxMessage(ctx, request.MessageID)
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+
+	return &grpc.RepublishOutboxMessageResponse{Message: converter.FromOutboxEntryToOutboxMessage(entry)}, nil
+}
+
+func (s OutboxAdminController) StartReplay(ctx context.Context, request *grpc.StartReplayRequest) (*grpc.StartReplayResponse, error) {
+	if request == nil {
+		return nil, toStatusError(ErrRequestIsRequired)
+	}
+
+	// the replay outlives the request, its progress is followed with GetReplay. The snapshots keep the correlation id
+	// of the request
+	checkpoint, err := s.replayComponent.Launch(ctx, converter.ToReplayUsersRequest(request))
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+
+	return &grpc.StartReplayResponse{Replay: converter.FromReplayCheckpointToReplay(checkpoint)}, nil
+}
+
+func (s OutboxAdminController) GetReplay(ctx context.Context, request *grpc.GetReplayRequest) (*grpc.GetReplayResponse, error) {
+	if request == nil {
+		return nil, toStatusError(ErrRequestIsRequired)
+	}
+
+	checkpoint, err := s.replayComponent.Get(ctx, request.ReplayID)
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+
+	return &grpc.GetReplayResponse{Replay: converter.FromReplayCheckpointToReplay(checkpoint)}, nil
+}
@@ -0,0 +1,252 @@
+package api
+
+import (
+	"context"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"testing"
+	"time"
+	"userservice/internal/config"
+	"userservice/internal/domain/model"
+	"userservice/internal/domain/replay"
+	"userservice/internal/infrastructure/messaging"
+	"userservice/internal/infrastructure/outbox"
+	"userservice/internal/infrastructure/serialization"
+	"userservice/internal/mock"
+	"userservice/proto/grpc"
+	"userservice/proto/kafkaschema"
+)
+
+// fixedStatusOutbox only reports a status, the outbox package can not be mocked in the mock package without an import cycle
+type fixedStatusOutbox struct {
+	status outbox.Status
+}
+
+func (f fixedStatusOutbox) CleanUp() {}
+
+func (f fixedStatusOutbox) Run() {}
+
+func (f fixedStatusOutbox) Status() outbox.Status {
+	return f.status
+}
+
+func newTestOutboxAdminController() (*OutboxAdminController, *mock.OutboxAdminRepoMock) {
+	repo := mock.NewOutboxAdminRepoMock(
+		messaging.OutboxEntry{ID: "dead", Topic: "userservice.user.added", Key: "user-1", Value: []byte{1, 2}, State: "dead_lettered", Retries: 10, LastError: "broker down", DeadLetteredAt: time.Now().UTC()},
+		messaging.OutboxEntry{ID: "waiting", Topic: "userservice.user.added", State: "waiting"},
+	)
+	status := outbox.Status{InstanceID: "owner", OwnedPartitions: []int{0, 2}, Purged: 5, Archived: 3, LastArchive: "outbox.jsonl.gz"}
+	return NewOutboxAdminController(repo, fixedStatusOutbox{status: status}, replay.NewReplayComponent(mock.NewReplayRepoMock()), newTestSerializer()), repo
+}
+
+func newTestSerializer() serialization.Serializer {
+	serializer, err := serialization.NewSerializer(config.SerializationConfig{}, nil)
+	if err != nil {
+		panic(err)
+	}
+	return serializer
+}
+
+// addFinishedMessage puts a sent UserAddedMessage of user-2 in the outbox, created age ago
+func addFinishedMessage(t *testing.T, repo *mock.OutboxAdminRepoMock, age time.Duration) messaging.OutboxEntry {
+	event := &kafkaschema.UserAddedMessage{Id: "user-2", FirstName: "Jane"}
+	value, err := proto.Marshal(event)
+	require.NoError(t, err)
+	entry := messaging.OutboxEntry{
+		ID:        "finished",
+		Topic:     "userservice.user.added",
+		Key:       "user-2",
+		Value:     value,
+		Headers:   messaging.NewEventMetadata(context.Background(), "finished", event).Headers(),
+		State:     "finished",
+		Retries:   2,
+		LastError: "broker down",
+		CreatedAt: time.Now().UTC().Add(-age),
+		SentAt:    time.Now().UTC(),
+	}
+	repo.Entries = append(repo.Entries, entry)
+	return entry
+}
+
+func TestOutboxAdminListAndGet(t *testing.T) {
+	controller, _ := newTestOutboxAdminController()
+	ctx := context.Background()
+
+	listResponse, err := controller.ListDeadLetteredMessages(ctx, &grpc.ListDeadLetteredMessagesRequest{})
+	require.NoError(t, err)
+	require.Len(t, listResponse.Messages, 1)
+	require.Equal(t, "dead", listResponse.Messages[0].Id)
+
+	getResponse, err := controller.GetDeadLetteredMessage(ctx, &grpc.GetDeadLetteredMessageRequest{MessageID: "dead"})
+	require.NoError(t, err)
+	require.Equal(t, "broker down", getResponse.Message.LastError)
+	require.Equal(t, int64(10), getResponse.Message.Retries)
+	require.Equal(t, []byte{1, 2}, getResponse.Message.Value)
+	require.NotNil(t, getResponse.Message.DeadLetteredAt)
+	require.Nil(t, getResponse.Message.NextRetry)
+}
+
+func TestOutboxAdminRequeueAndDiscard(t *testing.T) {
+	controller, repo := newTestOutboxAdminController()
+	ctx := context.Background()
+
+	requeueResponse, err := controller.RequeueDeadLetteredMessage(ctx, &grpc.RequeueDeadLetteredMessageRequest{MessageID: "dead"})
+	require.NoError(t, err)
+	require.Equal(t, "waiting", requeueResponse.Message.State)
+	require.Equal(t, int64(0), requeueResponse.Message.Retries)
+
+	// requeued messages are no longer dead lettered
+	_, err = controller.DiscardDeadLetteredMessage(ctx, &grpc.DiscardDeadLetteredMessageRequest{MessageID: "dead"})
+	require.Equal(t, codes.FailedPrecondition, status.Code(err))
+
+	repo.Entries[0].State = "dead_lettered"
+	_, err = controller.DiscardDeadLetteredMessage(ctx, &grpc.DiscardDeadLetteredMessageRequest{MessageID: "dead"})
+	require.NoError(t, err)
+	require.Len(t, repo.Entries, 1)
+}
+
+func TestOutboxAdminErrors(t *testing.T) {
+	controller, _ := newTestOutboxAdminController()
+	ctx := context.Background()
+
+	_, err := controller.GetDeadLetteredMessage(ctx, &grpc.GetDeadLetteredMessageRequest{})
+	require.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	_, err = controller.GetDeadLetteredMessage(ctx, &grpc.GetDeadLetteredMessageRequest{MessageID: "unknown"})
+	require.Equal(t, codes.NotFound, status.Code(err))
+
+	_, err = controller.RequeueDeadLetteredMessage(ctx, &grpc.RequeueDeadLetteredMessageRequest{MessageID: "waiting"})
+	require.Equal(t, codes.FailedPrecondition, status.Code(err))
+
+	_, err = controller.ListDeadLetteredMessages(ctx, nil)
+	require.Equal(t, codes.InvalidArgument, status.Code(err))
+}
+
+func TestOutboxAdminStatus(t *testing.T) {
+	controller, _ := newTestOutboxAdminController()
+
+	response, err := controller.GetOutboxStatus(context.Background(), &grpc.GetOutboxStatusRequest{})
+	require.NoError(t, err)
+	require.Equal(t, "owner", response.Status.InstanceId)
+	require.Equal(t, []int32{0, 2}, response.Status.OwnedPartitions)
+	require.Equal(t, int64(5), response.Status.Purged)
+	require.Equal(t, int64(3), response.Status.Archived)
+	require.Equal(t, "outbox.jsonl.gz", response.Status.LastArchive)
+	require.Nil(t, response.Status.LastPurgeAt)
+	require.Equal(t, map[string]int64{"dead_lettered": 1, "waiting": 1}, response.Status.MessagesByState)
+}
+
+func TestOutboxAdminListOutboxMessages(t *testing.T) {
+	controller, repo := newTestOutboxAdminController()
+	addFinishedMessage(t, repo, time.Hour)
+	ctx := context.Background()
+
+	response, err := controller.ListOutboxMessages(ctx, &grpc.ListOutboxMessagesRequest{})
+	require.NoError(t, err)
+	require.Len(t, response.Messages, 3)
+
+	response, err = controller.ListOutboxMessages(ctx, &grpc.ListOutboxMessagesRequest{State: "finished", Topic: "userservice.user.added", Key: "user-2"})
+	require.NoError(t, err)
+	require.Len(t, response.Messages, 1)
+	require.Equal(t, "finished", response.Messages[0].Id)
+
+	// the other messages were never created, which makes them older than any age
+	response, err = controller.ListOutboxMessages(ctx, &grpc.ListOutboxMessagesRequest{MinAgeSeconds: 60, MaxAgeSeconds: 7200})
+	require.NoError(t, err)
+	require.Len(t, response.Messages, 1)
+	require.Equal(t, "finished", response.Messages[0].Id)
+
+	response, err = controller.ListOutboxMessages(ctx, &grpc.ListOutboxMessagesRequest{State: "finished", MaxAgeSeconds: 60})
+	require.NoError(t, err)
+	require.Empty(t, response.Messages)
+
+	_, err = controller.ListOutboxMessages(ctx, &grpc.ListOutboxMessagesRequest{MinAgeSeconds: -1})
+	require.Equal(t, codes.InvalidArgument, status.Code(err))
+	_, err = controller.ListOutboxMessages(ctx, &grpc.ListOutboxMessagesRequest{MinAgeSeconds: 60, MaxAgeSeconds: 30})
+	require.Equal(t, codes.InvalidArgument, status.Code(err))
+}
+
+func TestOutboxAdminGetOutboxMessageDecodesPayload(t *testing.T) {
+	controller, repo := newTestOutboxAdminController()
+	addFinishedMessage(t, repo, time.Hour)
+	ctx := context.Background()
+
+	response, err := controller.GetOutboxMessage(ctx, &grpc.GetOutboxMessageRequest{MessageID: "finished"})
+	require.NoError(t, err)
+	require.Equal(t, "kafkaschema.UserAddedMessage", response.Message.Headers[messaging.HeaderEventType])
+	require.Empty(t, response.PayloadError)
+	var decoded kafkaschema.UserAddedMessage
+	require.NoError(t, protojson.Unmarshal([]byte(response.Payload), &decoded))
+	require.Equal(t, "Jane", decoded.FirstName)
+
+	// messages without an event type are still shown
+	response, err = controller.GetOutboxMessage(ctx, &grpc.GetOutboxMessageRequest{MessageID: "dead"})
+	require.NoError(t, err)
+	require.Equal(t, "dead", response.Message.Id)
+	require.Empty(t, response.Payload)
+	require.NotEmpty(t, response.PayloadError)
+
+	_, err = controller.GetOutboxMessage(ctx, &grpc.GetOutboxMessageRequest{MessageID: "unknown"})
+	require.Equal(t, codes.NotFound, status.Code(err))
+	_, err = controller.GetOutboxMessage(ctx, &grpc.GetOutboxMessageRequest{})
+	require.Equal(t, codes.InvalidArgument, status.Code(err))
+}
+
+func TestOutboxAdminRetryAndRepublish(t *testing.T) {
+	controller, repo := newTestOutboxAdminController()
+	addFinishedMessage(t, repo, time.Hour)
+	ctx := context.Background()
+
+	retryResponse, err := controller.RetryOutboxMessage(ctx, &grpc.RetryOutboxMessageRequest{MessageID: "waiting"})
+	require.NoError(t, err)
+	require.Equal(t, "waiting", retryResponse.Message.State)
+	require.NotNil(t, retryResponse.Message.NextRetry)
+
+	// only waiting messages are retried, dead lettered ones are requeued instead
+	_, err = controller.RetryOutboxMessage(ctx, &grpc.RetryOutboxMessageRequest{MessageID: "dead"})
+	require.Equal(t, codes.FailedPrecondition, status.Code(err))
+
+	republishResponse, err := controller.RepublishOutboxMessage(ctx, &grpc.RepublishOutboxMessageRequest{MessageID: "finished"})
+	require.NoError(t, err)
+	copied := republishResponse.Message
+	require.NotEqual(t, "finished", copied.Id)
+	require.Equal(t, "waiting", copied.State)
+	require.Equal(t, int64(0), copied.Retries)
+	require.Empty(t, copied.LastError)
+	require.Equal(t, copied.Id, copied.Headers[messaging.HeaderMessageID])
+	require.Equal(t, "finished", copied.Headers[messaging.HeaderRepublishedFrom])
+	original, err := controller.GetOutboxMessage(ctx, &grpc.GetOutboxMessageRequest{MessageID: "finished"})
+	require.NoError(t, err)
+	require.Equal(t, "finished", original.Message.State)
+
+	// the copy is newer than the original now
+	_, err = controller.RepublishOutboxMessage(ctx, &grpc.RepublishOutboxMessageRequest{MessageID: "finished"})
+	require.Equal(t, codes.FailedPrecondition, status.Code(err))
+	_, err = controller.RepublishOutboxMessage(ctx, &grpc.RepublishOutboxMessageRequest{MessageID: "unknown"})
+	require.Equal(t, codes.NotFound, status.Code(err))
+}
+
+func TestOutboxAdminReplay(t *testing.T) {
+	repo := mock.NewReplayRepoMock(model.User{ID: "user-1"}, model.User{ID: "user-2"})
+	controller := NewOutboxAdminController(mock.NewOutboxAdminRepoMock(), fixedStatusOutbox{}, replay.NewReplayComponent(repo), newTestSerializer())
+	ctx := context.Background()
+
+	started, err := controller.StartReplay(ctx, &grpc.StartReplayRequest{ReplayID: "backfill"})
+	require.NoError(t, err)
+	require.Equal(t, "backfill", started.Replay.Id)
+
+	require.Eventually(t, func() bool {
+		response, err := controller.GetReplay(ctx, &grpc.GetReplayRequest{ReplayID: "backfill"})
+		require.NoError(t, err)
+		return response.Replay.FinishedAt != nil && response.Replay.Enqueued == 2
+	}, time.Second, 10*time.Millisecond)
+
+	_, err = controller.GetReplay(ctx, &grpc.GetReplayRequest{ReplayID: "unknown"})
+	require.Equal(t, codes.NotFound, status.Code(err))
+
+	_, err = controller.StartReplay(ctx, &grpc.StartReplayRequest{BatchSize: -1})
+	require.Equal(t, codes.InvalidArgument, status.Code(err))
+}
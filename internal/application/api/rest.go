@@ -0,0 +1,271 @@
+package api
+
+import (
+	"errors"
+	"fmt"
+	"github.com/rs/zerolog/log"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+	"userservice/internal/domain/domainerror"
+	"userservice/internal/domain/model/updateuser"
+	"userservice/internal/domain/user"
+	"userservice/internal/util/converter"
+	"userservice/proto/grpc"
+)
+
+const maxRequestBodyBytes = 1 << 20
+
+const (
+	restReadHeaderTimeout = 5 * time.Second
+	restReadTimeout       = 30 * time.Second
+	restWriteTimeout      = 30 * time.Second
+	restIdleTimeout       = 2 * time.Minute
+)
+
+var errRequestBodyIsInvalid = domainerror.InvalidField("body", "request body is not valid json for the request")
+
+// codeToHTTPStatus follows the mapping documented in https://github.com/googleapis/googleapis/blob/master/google/rpc/code.proto
+var codeToHTTPStatus = map[codes.Code]int{
+	codes.OK:                 http.StatusOK,
+	codes.Canceled:           499,
+	codes.Unknown:            http.StatusInternalServerError,
+	codes.InvalidArgument:    http.StatusBadRequest,
+	codes.DeadlineExceeded:   http.StatusGatewayTimeout,
+	codes.NotFound:           http.StatusNotFound,
+	codes.AlreadyExists:      http.StatusConflict,
+	codes.PermissionDenied:   http.StatusForbidden,
+	codes.Unauthenticated:    http.StatusUnauthorized,
+	codes.ResourceExhausted:  http.StatusTooManyRequests,
+	codes.FailedPrecondition: http.StatusBadRequest,
+	codes.Aborted:            http.StatusConflict,
+	codes.OutOfRange:         http.StatusBadRequest,
+	codes.Unimplemented:      http.StatusNotImplemented,
+	codes.Internal:           http.StatusInternalServerError,
+	codes.Unavailable:        http.StatusServiceUnavailable,
+	codes.DataLoss:           http.StatusInternalServerError,
+}
+
+type UserRestController struct {
+	userComponent user.Component
+}
+
+func NewUserRestController(userComponent user.Component) *UserRestController {
+	return &UserRestController{userComponent: userComponent}
+}
+
+func (c *UserRestController) Handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /v1/users", c.addUser)
+	mux.HandleFunc("GET /v1/users", c.listUsers)
+	mux.HandleFunc("GET /v1/users/{id}", c.getUser)
+	mux.HandleFunc("PATCH /v1/users/{id}", c.updateUser)
+	mux.HandleFunc("DELETE /v1/users/{id}", c.removeUser)
+	return tracingMiddleware(mux)
+}
+
+func httpStatusFromCode(code codes.Code) int {
+	httpStatus, ok := codeToHTTPStatus[code]
+	if !ok {
+		return http.StatusInternalServerError
+	}
+	return httpStatus
+}
+
+func writeMessage(w http.ResponseWriter, httpStatus int, message proto.Message) {
+	data, err := protojson.Marshal(message)
+	if err != nil {
+		log.Error().Err(err).Msg("REST: failed to marshal response")
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(httpStatus)
+	_, _ = w.Write(data)
+}
+
+// writeError sends the same google.rpc.Status the grpc api would have returned, including field violations
+func writeError(w http.ResponseWriter, err error) {
+	st := status.Convert(toStatusError(err))
+	writeMessage(w, httpStatusFromCode(st.Code()), st.Proto())
+}
+
+// readMessage limits the body through w, so the connection is closed once a client sends more than allowed
+func readMessage(w http.ResponseWriter, r *http.Request, message proto.Message) error {
+	data, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxRequestBodyBytes))
+	if err != nil {
+		return errRequestBodyIsInvalid
+	}
+	err = protojson.Unmarshal(data, message)
+	if err != nil {
+		return domainerror.InvalidField("body", err.Error())
+	}
+	return nil
+}
+
+func (c *UserRestController) addUser(w http.ResponseWriter, r *http.Request) {
+	requestUser := &grpc.AddUserRequestUser{}
+	err := readMessage(w, r, requestUser)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	addedUser, err := c.userComponent.AddUser(r.Context(), converter.ToAddUserRequest(&grpc.AddUserRequest{User: requestUser}))
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeMessage(w, http.StatusCreated, &grpc.AddUserResponse{User: converter.FromDomainUserToResponseUser(addedUser)})
+}
+
+func (c *UserRestController) getUser(w http.ResponseWriter, r *http.Request) {
+	foundUser, err := c.userComponent.GetUser(r.Context(), r.PathValue("id"))
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeMessage(w, http.StatusOK, &grpc.GetUserResponse{User: converter.FromDomainUserToResponseUser(foundUser)})
+}
+
+func (c *UserRestController) updateUser(w http.ResponseWriter, r *http.Request) {
+	requestUser := &grpc.UpdateUserRequestUser{}
+	err := readMessage(w, r, requestUser)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	modifiedUser, err := c.userComponent.UpdateUser(r.Context(), r.PathValue("id"), updateuser.Request{
+		FirstName: requestUser.FirstName,
+		LastName:  requestUser.LastName,
+		Nickname:  requestUser.Nickname,
+		Email:     requestUser.Email,
+		Password:  requestUser.Password,
+		Country:   requestUser.Country,
+	})
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeMessage(w, http.StatusOK, &grpc.UpdateUserResponse{User: converter.FromDomainUserToResponseUser(modifiedUser)})
+}
+
+func (c *UserRestController) removeUser(w http.ResponseWriter, r *http.Request) {
+	removedUser, err := c.userComponent.RemoveUser(r.Context(), r.PathValue("id"))
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeMessage(w, http.StatusOK, &grpc.RemoveUserResponse{User: converter.FromDomainUserToResponseUser(removedUser)})
+}
+
+func (c *UserRestController) listUsers(w http.ResponseWriter, r *http.Request) {
+	request, err := parseListUsersQuery(r.URL.Query())
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	userResponse, err := c.userComponent.ListUsers(r.Context(), *converter.ConvertListUsersRequest(request))
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeMessage(w, http.StatusOK, converter.FromListUsersToResponseListUsers(userResponse))
+}
+
+// parseEnum accepts both the proto name (e.g. USER_FIELD_FIRST_NAME) and the number of an enum value, like protojson does
+func parseEnum(query url.Values, field string, descriptor protoreflect.EnumDescriptor) (protoreflect.EnumNumber, bool, error) {
+	raw := query.Get(field)
+	if raw == "" {
+		return 0, false, nil
+	}
+	if value := descriptor.Values().ByName(protoreflect.Name(raw)); value != nil {
+		return value.Number(), true, nil
+	}
+	number, err := strconv.Atoi(raw)
+	if err == nil && descriptor.Values().ByNumber(protoreflect.EnumNumber(number)) != nil {
+		return protoreflect.EnumNumber(number), true, nil
+	}
+	return 0, false, domainerror.InvalidField(field, fmt.Sprintf("%s is not a valid %s", raw, descriptor.Name()))
+}
+
+// parseListUsersQuery reads the list request from the query string, using the dotted protojson field paths, e.g.
+// ?sorting.by=USER_FIELD_FIRST_NAME&sorting.order=ORDERING_DESCENDING&filtering.left=USER_FIELD_COUNTRY&filtering.comparer=COMPARER_EQUAL&filtering.right=DK&paging.limit=10&paging.cursor=...
+func parseListUsersQuery(query url.Values) (*grpc.ListUsersRequest, error) {
+	request := &grpc.ListUsersRequest{}
+
+	sortBy, hasSortBy, err := parseEnum(query, "sorting.by", grpc.UserField(0).Descriptor())
+	if err != nil {
+		return nil, err
+	}
+	order, hasOrder, err := parseEnum(query, "sorting.order", grpc.Ordering(0).Descriptor())
+	if err != nil {
+		return nil, err
+	}
+	if hasSortBy || hasOrder {
+		request.Sorting = &grpc.SortInfo{By: grpc.UserField(sortBy)}
+		if hasOrder {
+			ordering := grpc.Ordering(order)
+			request.Sorting.Order = &ordering
+		}
+	}
+
+	left, hasLeft, err := parseEnum(query, "filtering.left", grpc.UserField(0).Descriptor())
+	if err != nil {
+		return nil, err
+	}
+	comparer, hasComparer, err := parseEnum(query, "filtering.comparer", grpc.Comparer(0).Descriptor())
+	if err != nil {
+		return nil, err
+	}
+	if hasLeft || hasComparer || query.Has("filtering.right") {
+		request.Filtering = &grpc.FilterInfo{
+			Left:     grpc.UserField(left),
+			Comparer: grpc.Comparer(comparer),
+			Right:    query.Get("filtering.right"),
+		}
+	}
+
+	if query.Has("paging.limit") || query.Has("paging.cursor") {
+		request.Paging = &grpc.PageInfo{Cursor: query.Get("paging.cursor")}
+		if rawLimit := query.Get("paging.limit"); rawLimit != "" {
+			limit, err := strconv.ParseInt(rawLimit, 10, 64)
+			if err != nil || limit < 0 {
+				return nil, domainerror.InvalidField("paging.limit", "paging.limit must be a positive number")
+			}
+			request.Paging.Limit = limit
+		}
+	}
+
+	return request, nil
+}
+
+// NewRESTServer has timeouts, so clients that send or read slowly do not hold on to connections forever
+func (c *UserRestController) NewRESTServer(port int) *http.Server {
+	return &http.Server{
+		Addr:              fmt.Sprintf(":%d", port),
+		Handler:           c.Handler(),
+		ReadHeaderTimeout: restReadHeaderTimeout,
+		ReadTimeout:       restReadTimeout,
+		WriteTimeout:      restWriteTimeout,
+		IdleTimeout:       restIdleTimeout,
+	}
+}
+
+// ServeREST serves until server is shut down
+func ServeREST(server *http.Server) {
+	log.Info().Msgf("Serving REST api at %s/v1/users", server.Addr)
+	err := server.ListenAndServe()
+	if err != nil && !errors.Is(err, http.ErrServerClosed) {
+		log.Panic().Msgf("failed to listen on port %s", server.Addr)
+	}
+}
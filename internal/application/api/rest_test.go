@@ -0,0 +1,133 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+	"userservice/internal/domain/user"
+	"userservice/internal/mock"
+	"userservice/proto/grpc"
+)
+
+func newTestRestServer() (*httptest.Server, *mock.UserRepoMock) {
+	repo := mock.NewUserRepoMock()
+	controller := NewUserRestController(user.NewUserComponent(repo))
+	return httptest.NewServer(controller.Handler()), repo
+}
+
+func doRequest(t *testing.T, method string, url string, body string) (*http.Response, map[string]any) {
+	request, err := http.NewRequest(method, url, strings.NewReader(body))
+	require.NoError(t, err)
+	response, err := http.DefaultClient.Do(request)
+	require.NoError(t, err)
+	defer response.Body.Close()
+
+	decoded := map[string]any{}
+	require.NoError(t, json.NewDecoder(response.Body).Decode(&decoded))
+	return response, decoded
+}
+
+func TestRestAddAndGetUser(t *testing.T) {
+	server, repo := newTestRestServer()
+	defer server.Close()
+
+	response, body := doRequest(t, http.MethodPost, server.URL+"/v1/users",
+		`{"firstName":"John","lastName":"Smith","nickname":"js","email":"john@example.com","password":"secret","country":"GB"}`)
+	require.Equal(t, http.StatusCreated, response.StatusCode)
+	require.Len(t, repo.Users, 1)
+
+	addedUser := body["user"].(map[string]any)
+	require.Equal(t, "John", addedUser["firstName"])
+	require.NotContains(t, addedUser, "password")
+
+	response, body = doRequest(t, http.MethodGet, server.URL+"/v1/users/"+repo.Users[0].ID, "")
+	require.Equal(t, http.StatusOK, response.StatusCode)
+	require.Equal(t, repo.Users[0].ID, body["user"].(map[string]any)["id"])
+}
+
+func TestRestErrorStatuses(t *testing.T) {
+	server, _ := newTestRestServer()
+	defer server.Close()
+
+	response, body := doRequest(t, http.MethodGet, server.URL+"/v1/users/"+uuid.NewString(), "")
+	require.Equal(t, http.StatusNotFound, response.StatusCode)
+	require.Equal(t, "user not found", body["message"])
+
+	response, _ = doRequest(t, http.MethodDelete, server.URL+"/v1/users/not-a-uuid", "")
+	require.Equal(t, http.StatusBadRequest, response.StatusCode)
+
+	response, body = doRequest(t, http.MethodPost, server.URL+"/v1/users",
+		`{"firstName":"John","lastName":"Smith","nickname":"js","email":"john@example","password":"secret","country":"GB"}`)
+	require.Equal(t, http.StatusBadRequest, response.StatusCode)
+	details := body["details"].([]any)
+	require.Len(t, details, 1)
+	violation := details[0].(map[string]any)["fieldViolations"].([]any)[0].(map[string]any)
+	require.Equal(t, "email", violation["field"])
+
+	response, _ = doRequest(t, http.MethodPost, server.URL+"/v1/users", `{"unknownField":1}`)
+	require.Equal(t, http.StatusBadRequest, response.StatusCode)
+}
+
+func TestParseListUsersQuery(t *testing.T) {
+	request, err := parseListUsersQuery(map[string][]string{
+		"sorting.by":         {"USER_FIELD_FIRST_NAME"},
+		"sorting.order":      {"2"},
+		"filtering.left":     {"USER_FIELD_COUNTRY"},
+		"filtering.comparer": {"COMPARER_EQUAL"},
+		"filtering.right":    {"DK"},
+		"paging.limit":       {"10"},
+		"paging.cursor":      {"abc:def"},
+	})
+	require.NoError(t, err)
+	require.Equal(t, grpc.UserField_USER_FIELD_FIRST_NAME, request.Sorting.By)
+	require.Equal(t, grpc.Ordering_ORDERING_DESCENDING, *request.Sorting.Order)
+	require.Equal(t, grpc.UserField_USER_FIELD_COUNTRY, request.Filtering.Left)
+	require.Equal(t, grpc.Comparer_COMPARER_EQUAL, request.Filtering.Comparer)
+	require.Equal(t, "DK", request.Filtering.Right)
+	require.Equal(t, int64(10), request.Paging.Limit)
+	require.Equal(t, "abc:def", request.Paging.Cursor)
+
+	request, err = parseListUsersQuery(map[string][]string{})
+	require.NoError(t, err)
+	require.Nil(t, request.Sorting)
+	require.Nil(t, request.Filtering)
+	require.Nil(t, request.Paging)
+
+	_, err = parseListUsersQuery(map[string][]string{"sorting.by": {"USER_FIELD_SHOE_SIZE"}})
+	require.Error(t, err)
+	_, err = parseListUsersQuery(map[string][]string{"paging.limit": {"-1"}})
+	require.Error(t, err)
+}
+
+func TestRestServerShutsDown(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	port := listener.Addr().(*net.TCPAddr).Port
+	require.NoError(t, listener.Close())
+
+	server := NewServer(nil, NewUserRestController(user.NewUserComponent(mock.NewUserRepoMock())), nil, nil, nil)
+	server.RunREST(port)
+	require.NotZero(t, server.restServer.ReadHeaderTimeout)
+
+	url := fmt.Sprintf("http://127.0.0.1:%d/v1/users/%s", port, uuid.NewString())
+	require.Eventually(t, func() bool {
+		response, err := http.Get(url)
+		if err != nil {
+			return false
+		}
+		response.Body.Close()
+		return true
+	}, time.Second, 10*time.Millisecond)
+
+	require.NoError(t, server.ShutdownREST(context.Background()))
+	_, err = http.Get(url)
+	require.Error(t, err)
+}
@@ -4,17 +4,25 @@ import (
 	"context"
 	"google.golang.org/grpc"
 	healthgrpc "google.golang.org/grpc/health/grpc_health_v1"
+	"net/http"
 	grpcproto "userservice/proto/grpc"
 )
 
 type Server struct {
 	userController        *UserController
+	userRestController    *UserRestController
+	outboxAdminController *OutboxAdminController
+	webhookController     *WebhookController
 	healthCheckController *HealthCheckController
+	restServer            *http.Server
 }
 
-func NewServer(usersController *UserController, healthCheckController *HealthCheckController) *Server {
+func NewServer(usersController *UserController, userRestController *UserRestController, outboxAdminController *OutboxAdminController, webhookController *WebhookController, healthCheckController *HealthCheckController) *Server {
 	return &Server{
 		userController:        usersController,
+		userRestController:    userRestController,
+		outboxAdminController: outboxAdminController,
+		webhookController:     webhookController,
 		healthCheckController: healthCheckController,
 	}
 }
@@ -22,6 +30,24 @@ func NewServer(usersController *UserController, healthCheckController *HealthChe
 func (s *Server) RegisterGRPC(grpcServer *grpc.Server) {
 	healthgrpc.RegisterHealthServer(grpcServer, s.healthCheckController)
 	grpcproto.RegisterUserServiceServer(grpcServer, s.userController)
+	grpcproto.RegisterOutboxAdminServiceServer(grpcServer, s.outboxAdminController)
+	grpcproto.RegisterWebhookServiceServer(grpcServer, s.webhookController)
+}
+
+func (s *Server) RunREST(port int) {
+	if port == 0 {
+		return
+	}
+	s.restServer = s.userRestController.NewRESTServer(port)
+	go ServeREST(s.restServer)
+}
+
+// ShutdownREST stops taking REST requests and waits for the ones in flight until ctx is done
+func (s *Server) ShutdownREST(ctx context.Context) error {
+	if s.restServer == nil {
+		return nil
+	}
+	return s.restServer.Shutdown(ctx)
 }
 
 func (s *Server) Run() {
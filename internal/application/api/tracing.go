@@ -0,0 +1,58 @@
+package api
+
+import (
+	"context"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"net/http"
+	"userservice/internal/util/tracing"
+)
+
+func firstMetadataValue(md metadata.MD, key string) string {
+	values := md.Get(key)
+	if len(values) == 0 {
+		return ""
+	}
+	return values[0]
+}
+
+// withRequestMetadata puts the correlation id and trace of the caller in ctx, so the events of the request carry them
+func withRequestMetadata(ctx context.Context) (context.Context, tracing.Metadata) {
+	md, _ := metadata.FromIncomingContext(ctx)
+	requestMetadata := tracing.ForRequest(
+		firstMetadataValue(md, tracing.CorrelationIDHeader),
+		firstMetadataValue(md, tracing.TraceParentHeader),
+	)
+	return tracing.NewContext(ctx, requestMetadata), requestMetadata
+}
+
+// TracingUnaryInterceptor sends the correlation id back to the caller, which is useful when we made it up
+func TracingUnaryInterceptor(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+	ctx, requestMetadata := withRequestMetadata(ctx)
+	_ = grpc.SetHeader(ctx, metadata.Pairs(tracing.CorrelationIDHeader, requestMetadata.CorrelationID))
+	return handler(ctx, req)
+}
+
+type tracedServerStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (t tracedServerStream) Context() context.Context {
+	return t.ctx
+}
+
+func TracingStreamInterceptor(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	ctx, requestMetadata := withRequestMetadata(ss.Context())
+	_ = ss.SetHeader(metadata.Pairs(tracing.CorrelationIDHeader, requestMetadata.CorrelationID))
+	return handler(srv, tracedServerStream{ServerStream: ss, ctx: ctx})
+}
+
+// tracingMiddleware does for the REST api what the interceptors do for grpc
+func tracingMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requestMetadata := tracing.ForRequest(r.Header.Get(tracing.CorrelationIDHeader), r.Header.Get(tracing.TraceParentHeader))
+		w.Header().Set(tracing.CorrelationIDHeader, requestMetadata.CorrelationID)
+		next.ServeHTTP(w, r.WithContext(tracing.NewContext(r.Context(), requestMetadata)))
+	})
+}
@@ -0,0 +1,48 @@
+package api
+
+import (
+	"context"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"userservice/internal/util/tracing"
+)
+
+const callerTraceParent = "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01"
+
+func TestTracingUnaryInterceptor(t *testing.T) {
+	incoming := metadata.New(map[string]string{
+		tracing.CorrelationIDHeader: "correlation",
+		tracing.TraceParentHeader:   callerTraceParent,
+	})
+	ctx := metadata.NewIncomingContext(context.Background(), incoming)
+
+	var seen tracing.Metadata
+	_, err := TracingUnaryInterceptor(ctx, nil, &grpc.UnaryServerInfo{}, func(ctx context.Context, req any) (any, error) {
+		seen, _ = tracing.FromContext(ctx)
+		return nil, nil
+	})
+	require.NoError(t, err)
+	require.Equal(t, "correlation", seen.CorrelationID)
+	// our span continues the trace of the caller
+	require.True(t, strings.HasPrefix(seen.TraceParent, "00-4bf92f3577b34da6a3ce929d0e0e4736-"))
+	require.NotEqual(t, callerTraceParent, seen.TraceParent)
+}
+
+func TestTracingMiddleware(t *testing.T) {
+	var seen tracing.Metadata
+	handler := tracingMiddleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		seen, _ = tracing.FromContext(r.Context())
+	}))
+
+	recorder := httptest.NewRecorder()
+	handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/v1/users", nil))
+
+	require.NotEmpty(t, seen.CorrelationID)
+	require.True(t, tracing.ValidTraceParent(seen.TraceParent))
+	require.Equal(t, seen.CorrelationID, recorder.Header().Get(tracing.CorrelationIDHeader))
+}
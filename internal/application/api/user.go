@@ -2,15 +2,16 @@ package api
 
 import (
 	"context"
-	"errors"
+	"userservice/internal/domain/domainerror"
 	"userservice/internal/domain/model/updateuser"
 	"userservice/internal/domain/user"
 	"userservice/internal/util/converter"
 	"userservice/proto/grpc"
 )
 
-var ErrRequestIsRequired = errors.New("request is required")
-var ErrModifiedUserRequestIsRequired = errors.New("user to be modified is required")
+var ErrRequestIsRequired = domainerror.InvalidArgument("request is required")
+var ErrAddedUserRequestIsRequired = domainerror.InvalidField("user", "user to be added is required")
+var ErrModifiedUserRequestIsRequired = domainerror.InvalidField("user", "user to be modified is required")
 
 type UserController struct {
 	grpc.UserServiceServer
@@ -23,12 +24,16 @@ func NewUserController(userComponent user.Component) *UserController {
 
 func (s UserController) AddUser(ctx context.Context, request *grpc.AddUserRequest) (*grpc.AddUserResponse, error) {
 	if request == nil {
-		return nil, ErrRequestIsRequired
+		return nil, toStatusError(ErrRequestIsRequired)
+	}
+
+	if request.User == nil {
+		return nil, toStatusError(ErrAddedUserRequestIsRequired)
 	}
 
 	addedUser, err := s.userComponent.AddUser(ctx, converter.ToAddUserRequest(request))
 	if err != nil {
-		return nil, err
+		return nil, toStatusError(err)
 	}
 	responseUser := converter.FromDomainUserToResponseUser(addedUser)
 	return &grpc.AddUserResponse{User: responseUser}, nil
@@ -36,12 +41,12 @@ func (s UserController) AddUser(ctx context.Context, request *grpc.AddUserReques
 
 func (s UserController) RemoveUser(ctx context.Context, request *grpc.RemoveUserRequest) (*grpc.RemoveUserResponse, error) {
 	if request == nil {
-		return nil, ErrRequestIsRequired
+		return nil, toStatusError(ErrRequestIsRequired)
 	}
 
 	removedUser, err := s.userComponent.RemoveUser(ctx, request.UserID)
 	if err != nil {
-		return nil, err
+		return nil, toStatusError(err)
 	}
 
 	return &grpc.RemoveUserResponse{User: converter.FromDomainUserToResponseUser(removedUser)}, nil
@@ -49,11 +54,11 @@ func (s UserController) RemoveUser(ctx context.Context, request *grpc.RemoveUser
 
 func (s UserController) UpdateUser(ctx context.Context, request *grpc.UpdateUserRequest) (*grpc.UpdateUserResponse, error) {
 	if request == nil {
-		return nil, ErrRequestIsRequired
+		return nil, toStatusError(ErrRequestIsRequired)
 	}
 
 	if request.User == nil {
-		return nil, ErrModifiedUserRequestIsRequired
+		return nil, toStatusError(ErrModifiedUserRequestIsRequired)
 	}
 
 	modifiedUser, err := s.userComponent.UpdateUser(ctx, request.UserID, updateuser.Request{
@@ -65,7 +70,7 @@ func (s UserController) UpdateUser(ctx context.Context, request *grpc.UpdateUser
 		Country:   request.User.Country,
 	})
 	if err != nil {
-		return nil, err
+		return nil, toStatusError(err)
 	}
 	responseUser := converter.FromDomainUserToResponseUser(modifiedUser)
 	return &grpc.UpdateUserResponse{User: responseUser}, nil
@@ -74,13 +79,39 @@ func (s UserController) UpdateUser(ctx context.Context, request *grpc.UpdateUser
 func (s UserController) ListUsers(ctx context.Context, request *grpc.ListUsersRequest) (*grpc.ListUsersResponse, error) {
 	input := converter.ConvertListUsersRequest(request)
 	if input == nil {
-		return nil, ErrRequestIsRequired
+		return nil, toStatusError(ErrRequestIsRequired)
 	}
 
 	userResponse, err := s.userComponent.ListUsers(ctx, *input)
 	if err != nil {
-		return nil, err
+		return nil, toStatusError(err)
 	}
 
 	return converter.FromListUsersToResponseListUsers(userResponse), nil
 }
+
+func (s UserController) GetUser(ctx context.Context, request *grpc.GetUserRequest) (*grpc.GetUserResponse, error) {
+	if request == nil {
+		return nil, toStatusError(ErrRequestIsRequired)
+	}
+
+	foundUser, err := s.userComponent.GetUser(ctx, request.UserID)
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+
+	return &grpc.GetUserResponse{User: converter.FromDomainUserToResponseUser(foundUser)}, nil
+}
+
+func (s UserController) BatchGetUsers(ctx context.Context, request *grpc.BatchGetUsersRequest) (*grpc.BatchGetUsersResponse, error) {
+	if request == nil {
+		return nil, toStatusError(ErrRequestIsRequired)
+	}
+
+	results, err := s.userComponent.BatchGetUsers(ctx, request.UserIDs)
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+
+	return converter.FromBatchGetUsersToResponse(results), nil
+}
@@ -0,0 +1,70 @@
+package api
+
+import (
+	"context"
+	"github.com/rs/zerolog/log"
+	"userservice/internal/config"
+	"userservice/internal/domain/domainerror"
+	"userservice/internal/domain/user"
+	"userservice/internal/infrastructure/consumer"
+	"userservice/internal/infrastructure/serialization"
+	"userservice/proto/kafkaschema"
+)
+
+// UserCommandController runs the user commands other services send over kafka
+type UserCommandController struct {
+	userComponent user.Component
+	serializer    serialization.Serializer
+}
+
+func NewUserCommandController(userComponent user.Component, serializer serialization.Serializer) *UserCommandController {
+	return &UserCommandController{userComponent: userComponent, serializer: serializer}
+}
+
+// Register adds a handler for every command topic in the config
+func (s UserCommandController) Register(registry *consumer.Registry, commandsConfig config.CommandsConfig) {
+	registry.Register(commandsConfig.DeleteUserTopicName, s.DeleteUser(commandsConfig.DeleteUserTopicName))
+	registry.Register(commandsConfig.SuspendUserTopicName, s.SuspendUser(commandsConfig.SuspendUserTopicName))
+}
+
+func (s UserCommandController) DeleteUser(topic string) consumer.Handler {
+	return func(value []byte) (consumer.Command, error) {
+		var command kafkaschema.DeleteUserCommand
+		err := s.serializer.Deserialize(topic, value, &command)
+		if err != nil {
+			return consumer.Command{}, err
+		}
+
+		return consumer.Command{
+			ID: command.CommandId,
+			Execute: func(ctx context.Context) error {
+				log.Info().Msgf("UserCommands: deleting user %s for %s, reason: %s", command.UserId, command.RequestedBy, command.Reason)
+				_, err := s.userComponent.RemoveUser(ctx, command.UserId)
+				if domainerror.KindOf(err) == domainerror.KindNotFound {
+					// the user is gone either way, which is what was asked for
+					return nil
+				}
+				return err
+			},
+		}, nil
+	}
+}
+
+func (s UserCommandController) SuspendUser(topic string) consumer.Handler {
+	return func(value []byte) (consumer.Command, error) {
+		var command kafkaschema.SuspendUserCommand
+		err := s.serializer.Deserialize(topic, value, &command)
+		if err != nil {
+			return consumer.Command{}, err
+		}
+
+		return consumer.Command{
+			ID: command.CommandId,
+			Execute: func(ctx context.Context) error {
+				log.Info().Msgf("UserCommands: suspending user %s for %s, reason: %s", command.UserId, command.RequestedBy, command.Reason)
+				_, err := s.userComponent.SuspendUser(ctx, command.UserId, command.Reason)
+				return err
+			},
+		}, nil
+	}
+}
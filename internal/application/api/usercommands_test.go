@@ -0,0 +1,78 @@
+package api
+
+import (
+	"context"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+	"testing"
+	"userservice/internal/config"
+	"userservice/internal/domain/domainerror"
+	"userservice/internal/domain/model"
+	"userservice/internal/domain/user"
+	"userservice/internal/infrastructure/consumer"
+	"userservice/internal/infrastructure/serialization"
+	"userservice/internal/mock"
+	"userservice/proto/kafkaschema"
+)
+
+var testCommandsConfig = config.CommandsConfig{
+	DeleteUserTopicName:  "commands.delete_user",
+	SuspendUserTopicName: "commands.suspend_user",
+}
+
+func newTestUserCommands(t *testing.T, users ...model.User) (*consumer.Registry, *mock.UserRepoMock) {
+	serializer, err := serialization.NewSerializer(config.SerializationConfig{DefaultFormat: "protobuf"}, nil)
+	require.NoError(t, err)
+	repo := mock.NewUserRepoMock()
+	repo.Users = users
+	registry := consumer.NewRegistry()
+	NewUserCommandController(user.NewUserComponent(repo), serializer).Register(registry, testCommandsConfig)
+	return registry, repo
+}
+
+func decodeCommand(t *testing.T, registry *consumer.Registry, topic string, command proto.Message) consumer.Command {
+	value, err := proto.Marshal(command)
+	require.NoError(t, err)
+	handler, ok := registry.Handler(topic)
+	require.True(t, ok)
+	decoded, err := handler(value)
+	require.NoError(t, err)
+	return decoded
+}
+
+func TestUserCommandsDeleteUser(t *testing.T) {
+	userID := uuid.New().String()
+	registry, repo := newTestUserCommands(t, model.User{ID: userID})
+	ctx := context.Background()
+
+	command := decodeCommand(t, registry, testCommandsConfig.DeleteUserTopicName, &kafkaschema.DeleteUserCommand{CommandId: "delete-1", UserId: userID})
+	require.Equal(t, "delete-1", command.ID)
+	require.NoError(t, command.Execute(ctx))
+	require.Empty(t, repo.Users)
+
+	// deleting a user that is already gone is what was asked for
+	require.NoError(t, command.Execute(ctx))
+}
+
+func TestUserCommandsSuspendUser(t *testing.T) {
+	userID := uuid.New().String()
+	registry, repo := newTestUserCommands(t, model.User{ID: userID})
+	ctx := context.Background()
+
+	command := decodeCommand(t, registry, testCommandsConfig.SuspendUserTopicName, &kafkaschema.SuspendUserCommand{CommandId: "suspend-1", UserId: userID, Reason: "fraud"})
+	require.NoError(t, command.Execute(ctx))
+	require.True(t, repo.Users[0].Suspended)
+
+	unknown := decodeCommand(t, registry, testCommandsConfig.SuspendUserTopicName, &kafkaschema.SuspendUserCommand{CommandId: "suspend-2", UserId: uuid.New().String()})
+	require.Equal(t, domainerror.KindNotFound, domainerror.KindOf(unknown.Execute(ctx)))
+}
+
+func TestUserCommandsRejectUndecodableCommands(t *testing.T) {
+	registry, _ := newTestUserCommands(t)
+	handler, ok := registry.Handler(testCommandsConfig.DeleteUserTopicName)
+	require.True(t, ok)
+
+	_, err := handler([]byte{0xff, 0xff})
+	require.Error(t, err)
+}
@@ -0,0 +1,73 @@
+package api
+
+import (
+	"context"
+	"userservice/internal/domain/webhook"
+	"userservice/internal/util/converter"
+	"userservice/proto/grpc"
+)
+
+type WebhookController struct {
+	grpc.WebhookServiceServer
+	webhookComponent webhook.Component
+}
+
+func NewWebhookController(webhookComponent webhook.Component) *WebhookController {
+	return &WebhookController{webhookComponent: webhookComponent}
+}
+
+func (s WebhookController) CreateWebhookSubscription(ctx context.Context, request *grpc.CreateWebhookSubscriptionRequest) (*grpc.CreateWebhookSubscriptionResponse, error) {
+	if request == nil {
+		return nil, toStatusError(ErrRequestIsRequired)
+	}
+
+	subscription, err := s.webhookComponent.Subscribe(ctx, converter.FromCreateWebhookSubscriptionRequestToModel(request))
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+
+	return &grpc.CreateWebhookSubscriptionResponse{
+		Subscription: converter.FromWebhookSubscriptionToResponse(subscription),
+		Secret:       subscription.Secret,
+	}, nil
+}
+
+func (s WebhookController) ListWebhookSubscriptions(ctx context.Context, request *grpc.ListWebhookSubscriptionsRequest) (*grpc.ListWebhookSubscriptionsResponse, error) {
+	if request == nil {
+		return nil, toStatusError(ErrRequestIsRequired)
+	}
+
+	subscriptions, err := s.webhookComponent.ListSubscriptions(ctx)
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+
+	return converter.FromWebhookSubscriptionsToListResponse(subscriptions), nil
+}
+
+func (s WebhookController) DeleteWebhookSubscription(ctx context.Context, request *grpc.DeleteWebhookSubscriptionRequest) (*grpc.DeleteWebhookSubscriptionResponse, error) {
+	if request == nil {
+		return nil, toStatusError(ErrRequestIsRequired)
+	}
+
+	subscription, err := s.webhookComponent.Unsubscribe(ctx, request.SubscriptionID)
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+
+	return &grpc.DeleteWebhookSubscriptionResponse{Subscription: converter.FromWebhookSubscriptionToResponse(subscription)}, nil
+}
+
+func (s WebhookController) ListWebhookDeliveries(ctx context.Context, request *grpc.ListWebhookDeliveriesRequest) (*grpc.ListWebhookDeliveriesResponse, error) {
+	if request == nil {
+		return nil, toStatusError(ErrRequestIsRequired)
+	}
+
+	limit := request.GetPaging().GetLimit()
+	deliveries, nextCursor, err := s.webhookComponent.ListDeliveries(ctx, request.SubscriptionID, limit, request.GetPaging().GetCursor())
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+
+	return converter.FromWebhookDeliveriesToListResponse(deliveries, limit, nextCursor), nil
+}
@@ -10,18 +10,43 @@ import (
 	"go.mongodb.org/mongo-driver/mongo/options"
 	"google.golang.org/grpc"
 	"net"
+	"os"
+	"os/signal"
+	"syscall"
 	"time"
 	"userservice/internal/application/api"
 	"userservice/internal/config"
+	"userservice/internal/domain/replay"
 	"userservice/internal/domain/user"
+	webhookdomain "userservice/internal/domain/webhook"
+	"userservice/internal/infrastructure/cloudevents"
+	"userservice/internal/infrastructure/consumer"
 	"userservice/internal/infrastructure/health"
+	"userservice/internal/infrastructure/kafkaclient"
+	"userservice/internal/infrastructure/metrics"
 	appdb "userservice/internal/infrastructure/mongodb"
 	"userservice/internal/infrastructure/outbox"
+	"userservice/internal/infrastructure/publisher"
+	"userservice/internal/infrastructure/serialization"
+	"userservice/internal/infrastructure/webhook"
+)
+
+// restShutdownTimeout is how long the REST requests in flight get to finish when the service stops
+const restShutdownTimeout = 10 * time.Second
+
+const (
+	publisherTypeKafka  = "kafka"
+	publisherTypeMemory = "memory"
+	publisherTypeJSONL  = "jsonl"
 )
 
 type App struct {
 	kafkaOutboxService outbox.Outbox
+	webhookDispatcher  webhook.Dispatcher
+	commandConsumer    consumer.Consumer
+	replayComponent    replay.Component
 	server             *api.Server
+	metrics            *metrics.Metrics
 	config             config.AppConfig
 }
 
@@ -35,35 +60,75 @@ func NewApp(config config.AppConfig) *App {
 
 func buildApp(config config.AppConfig) (*App, error) {
 	ctx := context.Background()
-	mongoDBConn, err := constructDBConnection(ctx, config.Database)
+	appMetrics := metrics.NewMetrics()
+	mongoDBConn, err := constructDBConnection(ctx, config.Database, appMetrics)
 	if err != nil {
 		log.Panic().Err(err).Msg("failed constructing Database connection")
 	}
 
 	// Dependencies
-	dbRepo := appdb.NewMongoDBConnection(mongoDBConn, config.Database, config.Kafka)
-	kafkaProducer, err := createKafkaProducer()
+	serializer, err := createSerializer(config.Kafka.Serialization)
 	if err != nil {
-		return nil, errors.Wrap(err, "failed creating kafka producer")
+		return nil, errors.Wrap(err, "failed creating event serializer")
+	}
+	envelope, err := cloudevents.NewEnvelope(config.Kafka.CloudEvents, serializer)
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid cloud events config")
+	}
+	dbRepo := appdb.NewMongoDBConnection(mongoDBConn, config.Database, config.Kafka, config.Webhook, serializer, envelope)
+	appMetrics.RegisterOutboxDepth(dbRepo)
+
+	// Health Check
+	healthCheckController := api.NewHealthCheckController(config.HealthChecker, appMetrics)
+	healthCheckController.RegisterHealthCheckable(ctx, health.NewMongoDBHealthCheckable(mongoDBConn), health.CriticalityCritical)
+	// a stalled outbox does not stop the service from taking requests, the events are delivered once it catches up
+	healthCheckController.RegisterHealthCheckable(ctx, health.NewOutboxLagHealthCheckable(dbRepo, config.HealthChecker.OutboxLag), health.CriticalityNonCritical)
+
+	// Topics
+	err = checkTopics(ctx, config, healthCheckController)
+	if err != nil {
+		return nil, err
 	}
 
 	// Outbox
-	kafkaOutboxService := outbox.NewKafkaOutbox(kafkaProducer, dbRepo, config.Kafka.Outbox)
+	eventPublisher, err := createPublisher(ctx, config, healthCheckController)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed creating event publisher")
+	}
+	kafkaOutboxService := outbox.NewKafkaOutbox(eventPublisher, dbRepo, dbRepo, dbRepo, appMetrics, config.Kafka.Outbox)
+	replayComponent := replay.NewReplayComponent(dbRepo)
+	outboxAdminController := api.NewOutboxAdminController(dbRepo, kafkaOutboxService, replayComponent, serializer)
 
 	// User
 	usersComponent := user.NewUserComponent(dbRepo)
 	userController := api.NewUserController(usersComponent)
+	userRestController := api.NewUserRestController(usersComponent)
 
-	// Health Check
-	healthCheckController := api.NewHealthCheckController(config.HealthChecker)
-	healthCheckController.RegisterHealthCheckable(ctx, health.NewMongoDBHealthCheckable(mongoDBConn))
-	healthCheckController.RegisterHealthCheckable(ctx, health.NewKafkaHealthCheckable(kafkaProducer))
+	// Webhooks
+	webhookController := api.NewWebhookController(webhookdomain.NewWebhookComponent(dbRepo))
+	var webhookDispatcher webhook.Dispatcher
+	if config.Webhook.Enabled {
+		webhookDispatcher = webhook.NewDispatcher(dbRepo, config.Webhook)
+	}
+
+	// Commands
+	var commandConsumer consumer.Consumer
+	if config.Kafka.Commands.Enabled {
+		commandConsumer, err = createCommandConsumer(config.Kafka, usersComponent, dbRepo, eventPublisher, serializer)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed creating command consumer")
+		}
+	}
 
-	server := api.NewServer(userController, healthCheckController)
+	server := api.NewServer(userController, userRestController, outboxAdminController, webhookController, healthCheckController)
 
 	return &App{
 		kafkaOutboxService: kafkaOutboxService,
+		webhookDispatcher:  webhookDispatcher,
+		commandConsumer:    commandConsumer,
+		replayComponent:    replayComponent,
 		server:             server,
+		metrics:            appMetrics,
 		config:             config,
 	}, nil
 }
@@ -76,14 +141,49 @@ func (a *App) Run() error {
 	}
 
 	// app server
-	s := grpc.NewServer()
+	s := grpc.NewServer(
+		grpc.ChainUnaryInterceptor(api.MetricsUnaryInterceptor(a.metrics), api.TracingUnaryInterceptor),
+		grpc.ChainStreamInterceptor(api.MetricsStreamInterceptor(a.metrics), api.TracingStreamInterceptor),
+	)
 	a.server.RegisterGRPC(s)
 	a.server.Run()
+	a.server.RunREST(a.config.Server.RestListeningPort)
 
 	// kafka outbox
 	go a.kafkaOutboxService.Run()
+	if a.webhookDispatcher != nil {
+		go a.webhookDispatcher.Run()
+	}
+	consumerStopped := make(chan struct{})
+	if a.commandConsumer != nil {
+		go func() {
+			defer close(consumerStopped)
+			a.commandConsumer.Run()
+		}()
+	} else {
+		close(consumerStopped)
+	}
+	// replays stopped by the last shutdown carry on where their checkpoint is
+	err = a.replayComponent.ResumeUnfinished(context.Background())
+	if err != nil {
+		log.Err(err).Msg("failed resuming unfinished replays")
+	}
+
+	// serving until the process is asked to stop
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	go func() {
+		<-ctx.Done()
+		log.Info().Msg("shutting down")
+		shutdownContext, cancel := context.WithTimeout(context.Background(), restShutdownTimeout)
+		defer cancel()
+		err := a.server.ShutdownREST(shutdownContext)
+		if err != nil {
+			log.Warn().Err(err).Msg("failed to finish the REST requests in flight")
+		}
+		s.GracefulStop()
+	}()
 
-	// serving
 	log.Info().Msgf("Server listening at %v", lis.Addr())
 	err = s.Serve(lis)
 
@@ -91,10 +191,26 @@ func (a *App) Run() error {
 		log.Panic().Msgf("failed to serve")
 	}
 
+	a.cleanUp(consumerStopped)
 	return nil
 }
 
-func constructDBConnection(ctx context.Context, config config.DatabaseConfig) (*mongo.Client, error) {
+// cleanUp stops the background work. The consumer is waited for, so it leaves its group and a new instance gets the
+// partitions right away, and it is done dead lettering before the outbox closes the publisher they share. Replays are
+// stopped at their last checkpoint and resumed by the next instance
+func (a *App) cleanUp(consumerStopped <-chan struct{}) {
+	a.replayComponent.CleanUp()
+	if a.commandConsumer != nil {
+		a.commandConsumer.CleanUp()
+	}
+	if a.webhookDispatcher != nil {
+		a.webhookDispatcher.CleanUp()
+	}
+	<-consumerStopped
+	a.kafkaOutboxService.CleanUp()
+}
+
+func constructDBConnection(ctx context.Context, config config.DatabaseConfig, appMetrics *metrics.Metrics) (*mongo.Client, error) {
 
 	timeoutCtx, cancel := context.WithTimeout(ctx, 5*time.Second)
 	defer cancel()
@@ -102,7 +218,7 @@ func constructDBConnection(ctx context.Context, config config.DatabaseConfig) (*
 	log.Info().Msg("constructing Database connection")
 
 	serverAPI := options.ServerAPI(options.ServerAPIVersion1)
-	opts := options.Client().ApplyURI(config.ConnectionString).SetServerAPIOptions(serverAPI)
+	opts := options.Client().ApplyURI(config.ConnectionString).SetServerAPIOptions(serverAPI).SetMonitor(appMetrics.MongoCommandMonitor())
 
 	client, err := mongo.Connect(timeoutCtx, opts)
 	if err != nil {
@@ -118,13 +234,100 @@ func constructDBConnection(ctx context.Context, config config.DatabaseConfig) (*
 	return client, nil
 }
 
-func createKafkaProducer() (*confkafka.Producer, error) {
-	p, err := confkafka.NewProducer(&confkafka.ConfigMap{
-		"bootstrap.servers": "0.0.0.0:29092",
-	})
+// bootstrapServers falls back to the health checker brokers, for configs written before the producer had its own
+func bootstrapServers(appConfig config.AppConfig) string {
+	if appConfig.Kafka.Producer.BootstrapServers != "" {
+		return appConfig.Kafka.Producer.BootstrapServers
+	}
+	return appConfig.HealthChecker.BootstrapServer
+}
+
+func createKafkaProducer(appConfig config.AppConfig) (*confkafka.Producer, error) {
+	producerConfig := appConfig.Kafka.Producer
+	producerConfig.BootstrapServers = bootstrapServers(appConfig)
+	if !kafkaclient.IdempotenceEnabled(producerConfig) {
+		log.Warn().Msg("idempotent production is turned off, retried messages may be written twice or out of order")
+	}
+	return kafkaclient.NewProducer(producerConfig, appConfig.Kafka.Security)
+}
+
+// createPublisher builds the publisher chosen in the config, kafka is only health checked when events go there
+func createPublisher(ctx context.Context, appConfig config.AppConfig, healthCheckController *api.HealthCheckController) (outbox.Publisher, error) {
+	publisherConfig := appConfig.Kafka.Publisher
+	switch publisherConfig.Type {
+	case "", publisherTypeKafka:
+		kafkaProducer, err := createKafkaProducer(appConfig)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed creating kafka producer")
+		}
+		healthCheckController.RegisterHealthCheckable(ctx, health.NewKafkaHealthCheckable(kafkaProducer), health.CriticalityNonCritical)
+		return publisher.NewKafkaPublisher(kafkaProducer), nil
+	case publisherTypeMemory:
+		return publisher.NewMemoryPublisher(), nil
+	case publisherTypeJSONL:
+		return publisher.NewJSONLPublisher(publisherConfig.Path)
+	}
+	return nil, fmt.Errorf("unknown publisher type %s", publisherConfig.Type)
+}
+
+// createCommandConsumer dead letters failed commands through the event publisher
+func createCommandConsumer(kafkaConfig config.KafkaConfig, usersComponent user.Component, repo consumer.ProcessedCommandRepo, deadLetters outbox.Publisher, serializer serialization.Serializer) (consumer.Consumer, error) {
+	commandsConfig := kafkaConfig.Commands
+	source, err := consumer.NewKafkaSource(commandsConfig.BootstrapServers, commandsConfig.GroupID, kafkaConfig.Security)
 	if err != nil {
 		return nil, err
 	}
+	registry := consumer.NewRegistry()
+	api.NewUserCommandController(usersComponent, serializer).Register(registry, commandsConfig)
+	return consumer.NewConsumer(source, registry, repo, deadLetters, serializer, commandsConfig), nil
+}
 
-	return p, nil
+// checkTopics verifies or creates the topics before anything is produced. With onMismatch unhealthy the topics are
+// checked again on every health check tick, so the service recovers once they were fixed
+func checkTopics(ctx context.Context, appConfig config.AppConfig, healthCheckController *api.HealthCheckController) error {
+	provisioning := appConfig.Kafka.Provisioning
+	err := validateProvisioning(provisioning)
+	if err != nil {
+		return err
+	}
+	publisherType := appConfig.Kafka.Publisher.Type
+	if provisioning.Mode == "" || provisioning.Mode == provisioningModeOff || (publisherType != "" && publisherType != publisherTypeKafka) {
+		return nil
+	}
+
+	topics := producedTopics(appConfig.Kafka)
+	// every check has an admin client of its own, so none is left open between the health check ticks
+	check := func(ctx context.Context) error {
+		admin, err := kafkaclient.NewTopicAdmin(bootstrapServers(appConfig), appConfig.Kafka.Security)
+		if err != nil {
+			return errors.Wrap(err, "failed creating kafka admin client")
+		}
+		defer admin.Close()
+		return provisionTopics(ctx, admin, provisioning, topics)
+	}
+
+	if provisioning.OnMismatch == onMismatchUnhealthy {
+		// like kafka itself the topics only degrade the service, the outbox holds on to the events meanwhile
+		healthCheckController.RegisterHealthCheckable(ctx, health.NewKafkaTopicsHealthCheckable(check), health.CriticalityNonCritical)
+		return nil
+	}
+
+	err = check(ctx)
+	if err != nil {
+		return errors.Wrap(err, "kafka topics are not ready")
+	}
+	log.Info().Msgf("verified %d kafka topics", len(topics))
+	return nil
+}
+
+func createSerializer(serializationConfig config.SerializationConfig) (serialization.Serializer, error) {
+	var registry serialization.SchemaRegistry
+	if serializationConfig.SchemaRegistryPath != "" {
+		fileRegistry, err := serialization.NewFileSchemaRegistry(serializationConfig.SchemaRegistryPath)
+		if err != nil {
+			return nil, err
+		}
+		registry = fileRegistry
+	}
+	return serialization.NewSerializer(serializationConfig, registry)
 }
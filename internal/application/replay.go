@@ -0,0 +1,40 @@
+package application
+
+import (
+	"context"
+	"github.com/pkg/errors"
+	"userservice/internal/config"
+	"userservice/internal/domain/model/replayusers"
+	"userservice/internal/domain/replay"
+	"userservice/internal/infrastructure/cloudevents"
+	"userservice/internal/infrastructure/metrics"
+	appdb "userservice/internal/infrastructure/mongodb"
+)
+
+// RunReplay enqueues the snapshots of a replay without starting the service, the running service sends them
+func RunReplay(ctx context.Context, config config.AppConfig, request replayusers.Request) (replayusers.Checkpoint, error) {
+	// nothing scrapes a replay, its metrics are dropped
+	mongoDBConn, err := constructDBConnection(ctx, config.Database, metrics.NewMetrics())
+	if err != nil {
+		return replayusers.Checkpoint{}, errors.Wrap(err, "failed constructing Database connection")
+	}
+	defer mongoDBConn.Disconnect(context.Background())
+
+	serializer, err := createSerializer(config.Kafka.Serialization)
+	if err != nil {
+		return replayusers.Checkpoint{}, errors.Wrap(err, "failed creating event serializer")
+	}
+	envelope, err := cloudevents.NewEnvelope(config.Kafka.CloudEvents, serializer)
+	if err != nil {
+		return replayusers.Checkpoint{}, errors.Wrap(err, "invalid cloud events config")
+	}
+	dbRepo := appdb.NewMongoDBConnection(mongoDBConn, config.Database, config.Kafka, config.Webhook, serializer, envelope)
+
+	replayComponent := replay.NewReplayComponent(dbRepo)
+	checkpoint, err := replayComponent.Start(ctx, request)
+	if err != nil {
+		return replayusers.Checkpoint{}, err
+	}
+	request.ID = checkpoint.ID
+	return replayComponent.Run(ctx, request)
+}
@@ -0,0 +1,133 @@
+package application
+
+import (
+	"context"
+	"fmt"
+	"github.com/rs/zerolog/log"
+	"strings"
+	"time"
+	"userservice/internal/config"
+	"userservice/internal/infrastructure/kafkaclient"
+)
+
+const (
+	provisioningModeOff    = "off"
+	provisioningModeVerify = "verify"
+	provisioningModeCreate = "create"
+	onMismatchFail         = "fail"
+	onMismatchUnhealthy    = "unhealthy"
+
+	defaultProvisioningTimeoutSeconds = 30
+)
+
+// topicMismatchError lists everything that is wrong with the topics, so one restart is enough to see all of it
+type topicMismatchError struct {
+	mismatches []string
+}
+
+func (t *topicMismatchError) Error() string {
+	return "kafka topics do not match the config: " + strings.Join(t.mismatches, "; ")
+}
+
+// producedTopics are the topics the service writes to, the command topics belong to the services sending commands
+func producedTopics(kafkaConfig config.KafkaConfig) []kafkaclient.Topic {
+	names := []string{
+		kafkaConfig.Topics.UserAddedTopicName,
+		kafkaConfig.Topics.UserRemovedTopicName,
+		kafkaConfig.Topics.UserUpdatedTopicName,
+	}
+	if kafkaConfig.Commands.Enabled {
+		names = append(names, kafkaConfig.Commands.DeadLetterTopicName)
+	}
+
+	var topics []kafkaclient.Topic
+	for _, name := range names {
+		if name == "" {
+			continue
+		}
+		settings, ok := kafkaConfig.Provisioning.Overrides[name]
+		if !ok {
+			settings = kafkaConfig.Provisioning.Defaults
+		}
+		topics = append(topics, kafkaclient.Topic{Name: name, Settings: settings})
+	}
+	return topics
+}
+
+func validateProvisioning(provisioning config.TopicProvisioning) error {
+	switch provisioning.Mode {
+	case "", provisioningModeOff, provisioningModeVerify, provisioningModeCreate:
+	default:
+		return fmt.Errorf("unknown topic provisioning mode %s", provisioning.Mode)
+	}
+	switch provisioning.OnMismatch {
+	case "", onMismatchFail, onMismatchUnhealthy:
+	default:
+		return fmt.Errorf("unknown topic mismatch handling %s", provisioning.OnMismatch)
+	}
+	return nil
+}
+
+func compareTopic(name string, want config.TopicSettings, have config.TopicSettings) []string {
+	var mismatches []string
+	if want.Partitions > 0 && want.Partitions != have.Partitions {
+		mismatches = append(mismatches, fmt.Sprintf("%s has %d partitions instead of %d", name, have.Partitions, want.Partitions))
+	}
+	if want.ReplicationFactor > 0 && want.ReplicationFactor != have.ReplicationFactor {
+		mismatches = append(mismatches, fmt.Sprintf("%s has replication factor %d instead of %d", name, have.ReplicationFactor, want.ReplicationFactor))
+	}
+	if want.RetentionMillis != 0 && want.RetentionMillis != have.RetentionMillis {
+		mismatches = append(mismatches, fmt.Sprintf("%s has retention %dms instead of %dms", name, have.RetentionMillis, want.RetentionMillis))
+	}
+	return mismatches
+}
+
+// provisionTopics compares the topics with the config and creates the missing ones in create mode. Existing topics are
+// never changed, adding partitions would move keys to other partitions and break the order of their events
+func provisionTopics(ctx context.Context, admin kafkaclient.TopicAdmin, provisioning config.TopicProvisioning, topics []kafkaclient.Topic) error {
+	timeoutSeconds := provisioning.TimeoutSeconds
+	if timeoutSeconds <= 0 {
+		timeoutSeconds = defaultProvisioningTimeoutSeconds
+	}
+	ctx, cancel := context.WithTimeout(ctx, time.Duration(timeoutSeconds)*time.Second)
+	defer cancel()
+
+	names := make([]string, 0, len(topics))
+	for _, topic := range topics {
+		names = append(names, topic.Name)
+	}
+	existing, err := admin.DescribeTopics(ctx, names)
+	if err != nil {
+		return fmt.Errorf("failed to describe topics: %w", err)
+	}
+
+	var missing []kafkaclient.Topic
+	var mismatches []string
+	for _, topic := range topics {
+		have, ok := existing[topic.Name]
+		if !ok {
+			missing = append(missing, topic)
+			continue
+		}
+		mismatches = append(mismatches, compareTopic(topic.Name, topic.Settings, have)...)
+	}
+
+	if len(missing) > 0 && provisioning.Mode == provisioningModeCreate {
+		err = admin.CreateTopics(ctx, missing)
+		if err != nil {
+			return fmt.Errorf("failed to create topics: %w", err)
+		}
+		for _, topic := range missing {
+			log.Info().Msgf("created kafka topic %s", topic.Name)
+		}
+	} else {
+		for _, topic := range missing {
+			mismatches = append(mismatches, fmt.Sprintf("%s does not exist", topic.Name))
+		}
+	}
+
+	if len(mismatches) > 0 {
+		return &topicMismatchError{mismatches: mismatches}
+	}
+	return nil
+}
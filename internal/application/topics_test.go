@@ -0,0 +1,125 @@
+package application
+
+import (
+	"context"
+	"github.com/stretchr/testify/require"
+	"testing"
+	"userservice/internal/config"
+	"userservice/internal/infrastructure/kafkaclient"
+)
+
+// testTopicAdmin keeps the topics of a cluster in memory
+type testTopicAdmin struct {
+	topics  map[string]config.TopicSettings
+	created []kafkaclient.Topic
+}
+
+func (t *testTopicAdmin) DescribeTopics(ctx context.Context, names []string) (map[string]config.TopicSettings, error) {
+	described := map[string]config.TopicSettings{}
+	for _, name := range names {
+		if settings, ok := t.topics[name]; ok {
+			described[name] = settings
+		}
+	}
+	return described, nil
+}
+
+func (t *testTopicAdmin) CreateTopics(ctx context.Context, topics []kafkaclient.Topic) error {
+	for _, topic := range topics {
+		t.topics[topic.Name] = topic.Settings
+	}
+	t.created = append(t.created, topics...)
+	return nil
+}
+
+func (t *testTopicAdmin) Close() {}
+
+var testKafkaConfig = config.KafkaConfig{
+	Topics: config.KafkaTopicsConfig{
+		UserAddedTopicName:   "user.added",
+		UserRemovedTopicName: "user.removed",
+		UserUpdatedTopicName: "user.updated",
+	},
+	Commands: config.CommandsConfig{Enabled: true, DeadLetterTopicName: "commands.dead_letter"},
+	Provisioning: config.TopicProvisioning{
+		Defaults:  config.TopicSettings{Partitions: 6, ReplicationFactor: 3, RetentionMillis: 604800000},
+		Overrides: map[string]config.TopicSettings{"commands.dead_letter": {Partitions: 1, ReplicationFactor: 3, RetentionMillis: -1}},
+	},
+}
+
+func TestProducedTopics(t *testing.T) {
+	topics := producedTopics(testKafkaConfig)
+
+	require.Len(t, topics, 4)
+	require.Equal(t, "user.added", topics[0].Name)
+	require.Equal(t, 6, topics[0].Settings.Partitions)
+	require.Equal(t, "commands.dead_letter", topics[3].Name)
+	require.Equal(t, int64(-1), topics[3].Settings.RetentionMillis)
+
+	withoutCommands := testKafkaConfig
+	withoutCommands.Commands.Enabled = false
+	require.Len(t, producedTopics(withoutCommands), 3)
+}
+
+func TestProvisionTopicsCreatesMissingTopics(t *testing.T) {
+	admin := &testTopicAdmin{topics: map[string]config.TopicSettings{
+		"user.added": {Partitions: 6, ReplicationFactor: 3, RetentionMillis: 604800000},
+	}}
+	provisioning := testKafkaConfig.Provisioning
+	provisioning.Mode = provisioningModeCreate
+
+	err := provisionTopics(context.Background(), admin, provisioning, producedTopics(testKafkaConfig))
+	require.NoError(t, err)
+	require.Len(t, admin.created, 3)
+	require.Equal(t, 1, admin.topics["commands.dead_letter"].Partitions)
+
+	// everything exists now
+	err = provisionTopics(context.Background(), admin, provisioning, producedTopics(testKafkaConfig))
+	require.NoError(t, err)
+	require.Len(t, admin.created, 3)
+}
+
+func TestProvisionTopicsReportsMismatches(t *testing.T) {
+	admin := &testTopicAdmin{topics: map[string]config.TopicSettings{
+		"user.added":           {Partitions: 3, ReplicationFactor: 1, RetentionMillis: 604800000},
+		"user.removed":         {Partitions: 6, ReplicationFactor: 3, RetentionMillis: 86400000},
+		"user.updated":         {Partitions: 6, ReplicationFactor: 3, RetentionMillis: 604800000},
+		"commands.dead_letter": {Partitions: 1, ReplicationFactor: 3, RetentionMillis: -1},
+	}}
+	provisioning := testKafkaConfig.Provisioning
+	provisioning.Mode = provisioningModeCreate
+
+	err := provisionTopics(context.Background(), admin, provisioning, producedTopics(testKafkaConfig))
+	var mismatch *topicMismatchError
+	require.ErrorAs(t, err, &mismatch)
+	require.Equal(t, []string{
+		"user.added has 3 partitions instead of 6",
+		"user.added has replication factor 1 instead of 3",
+		"user.removed has retention 86400000ms instead of 604800000ms",
+	}, mismatch.mismatches)
+	// existing topics are never changed
+	require.Empty(t, admin.created)
+}
+
+func TestProvisionTopicsOnlyVerifies(t *testing.T) {
+	admin := &testTopicAdmin{topics: map[string]config.TopicSettings{}}
+	kafkaConfig := testKafkaConfig
+	kafkaConfig.Provisioning = config.TopicProvisioning{Mode: provisioningModeVerify}
+	provisioning := kafkaConfig.Provisioning
+
+	err := provisionTopics(context.Background(), admin, provisioning, producedTopics(kafkaConfig))
+	require.ErrorContains(t, err, "user.added does not exist")
+	require.Empty(t, admin.created)
+
+	// settings that are not configured are not verified
+	admin.topics = map[string]config.TopicSettings{"user.added": {Partitions: 12}, "user.removed": {}, "user.updated": {}, "commands.dead_letter": {}}
+	err = provisionTopics(context.Background(), admin, provisioning, producedTopics(kafkaConfig))
+	require.NoError(t, err)
+}
+
+func TestValidateProvisioning(t *testing.T) {
+	require.NoError(t, validateProvisioning(config.TopicProvisioning{}))
+	require.NoError(t, validateProvisioning(config.TopicProvisioning{Mode: provisioningModeCreate, OnMismatch: onMismatchUnhealthy}))
+	require.Error(t, validateProvisioning(config.TopicProvisioning{Mode: "sometimes"}))
+	require.Error(t, validateProvisioning(config.TopicProvisioning{Mode: provisioningModeVerify, OnMismatch: "ignore"}))
+}
@@ -11,43 +11,235 @@ type AppConfig struct {
 	Database      DatabaseConfig      `json:"database"`
 	Kafka         KafkaConfig         `json:"kafka"`
 	HealthChecker HealthCheckerConfig `json:"healthChecker"`
+	Webhook       WebhookConfig       `json:"webhook"`
 }
 type ServerConfig struct {
 	ListeningPort int `json:"listeningPort"`
+	// RestListeningPort serves the HTTP/JSON api next to grpc, 0 disables it
+	RestListeningPort int `json:"restListeningPort"`
 }
 type DatabaseConfig struct {
 	ConnectionString          string `json:"connectionString"`
 	DatabaseName              string `envconfig:"DATABASE_NAME" json:"databaseName"`
 	UserCollectionName        string `json:"userCollectionName"`
 	KafkaOutboxCollectionName string `json:"kafkaOutboxCollectionName"`
-	InitialRetryDelaySeconds  int64  `json:"initialRetryDelaySeconds"`
-	UserIdName                string `json:"userIdName"`
-	ListUserDefaultLimit      int64  `json:"listUserDefaultLimit"`
-	ListUserMaxLimit          int64  `json:"listUserMaxLimit"`
+	// KafkaOutboxLeaseCollectionName holds the replicas running the outbox and the partitions each of them owns
+	KafkaOutboxLeaseCollectionName string `json:"kafkaOutboxLeaseCollectionName"`
+	// WebhookSubscriptionCollectionName holds the registered webhook endpoints
+	WebhookSubscriptionCollectionName string `json:"webhookSubscriptionCollectionName"`
+	// WebhookDeliveryCollectionName is the delivery log, one document per event and subscription
+	WebhookDeliveryCollectionName string `json:"webhookDeliveryCollectionName"`
+	// ProcessedCommandCollectionName remembers the ids of the commands that were consumed, so they only run once
+	ProcessedCommandCollectionName string `json:"processedCommandCollectionName"`
+	InitialRetryDelaySeconds       int64  `json:"initialRetryDelaySeconds"`
+	UserIdName                     string `json:"userIdName"`
+	ListUserDefaultLimit           int64  `json:"listUserDefaultLimit"`
+	ListUserMaxLimit               int64  `json:"listUserMaxLimit"`
 }
 
 type KafkaTopicsConfig struct {
 	UserAddedTopicName   string `json:"userAddedTopicName"`
 	UserRemovedTopicName string `json:"userRemovedTopicName"`
+	UserUpdatedTopicName string `json:"userUpdatedTopicName"`
 }
 
 type KafkaConfig struct {
-	Topics KafkaTopicsConfig `json:"topics"`
-	Outbox OutboxConfig      `json:"outbox"`
+	// Security is used by every kafka client of the service
+	Security      KafkaSecurityConfig `json:"security"`
+	Producer      ProducerConfig      `json:"producer"`
+	Topics        KafkaTopicsConfig   `json:"topics"`
+	Outbox        OutboxConfig        `json:"outbox"`
+	Serialization SerializationConfig `json:"serialization"`
+	Publisher     PublisherConfig     `json:"publisher"`
+	Commands      CommandsConfig      `json:"commands"`
+	Provisioning  TopicProvisioning   `json:"provisioning"`
+	CloudEvents   CloudEventsConfig   `json:"cloudEvents"`
+}
+
+// CloudEventsConfig wraps the events of some topics in CloudEvents 1.0, https://cloudevents.io
+type CloudEventsConfig struct {
+	// Source identifies the service in the events, a URI reference like /userservice or urn:example:userservice
+	Source string `json:"source"`
+	// TypePrefix is put in front of the event kind, com.example.userservice gives com.example.userservice.UserAdded.
+	// The type is just the event kind when empty
+	TypePrefix string `json:"typePrefix,omitempty"`
+	// Topics maps topic names to binary, with the attributes in ce_ headers, or structured, with the whole event as
+	// JSON in the value. Topics that are not listed are published without envelope
+	Topics map[string]string `json:"topics,omitempty"`
+}
+
+// TopicProvisioning checks the topics we produce to at startup
+type TopicProvisioning struct {
+	// Mode is off, verify or create. verify compares the existing topics with the settings below, create also creates
+	// the missing ones. Defaults to off
+	Mode string `json:"mode"`
+	// OnMismatch is fail, which stops the service at startup, or unhealthy, which starts anyway and reports the
+	// KafkaTopics health check as not serving until the topics match. Defaults to fail
+	OnMismatch string `json:"onMismatch"`
+	// TimeoutSeconds bounds every call to the brokers, defaults to 30
+	TimeoutSeconds int64 `json:"timeoutSeconds"`
+	// Defaults apply to every topic, Overrides to single topics by name
+	Defaults  TopicSettings            `json:"defaults"`
+	Overrides map[string]TopicSettings `json:"overrides,omitempty"`
+}
+
+// TopicSettings that are 0 are not verified and left to the broker defaults when a topic is created
+type TopicSettings struct {
+	Partitions        int `json:"partitions"`
+	ReplicationFactor int `json:"replicationFactor"`
+	// RetentionMillis is -1 to keep messages forever
+	RetentionMillis int64 `json:"retentionMillis"`
+}
+
+// CommandsConfig is for the commands other services send us, failed commands are put on the dead letter topic through
+// the publisher
+type CommandsConfig struct {
+	Enabled          bool   `json:"enabled"`
+	BootstrapServers string `json:"bootstrapServers"`
+	GroupID          string `json:"groupID"`
+	// DeleteUserTopicName and SuspendUserTopicName are not consumed when empty
+	DeleteUserTopicName  string `json:"deleteUserTopicName"`
+	SuspendUserTopicName string `json:"suspendUserTopicName"`
+	DeadLetterTopicName  string `json:"deadLetterTopicName"`
+	// MaxAttempts is how often a command that failed for a temporary reason is run before it is dead lettered,
+	// defaults to 5
+	MaxAttempts int `json:"maxAttempts"`
+	// RetryBackoffMillis is the wait before the second attempt, it grows linearly with every attempt. Defaults to 500
+	RetryBackoffMillis int64 `json:"retryBackoffMillis"`
+}
+
+type KafkaSecurityConfig struct {
+	// Protocol is one of plaintext, ssl, sasl_plaintext or sasl_ssl, defaults to plaintext
+	Protocol string `json:"protocol"`
+	// SASLMechanism is one of PLAIN, SCRAM-SHA-256 or SCRAM-SHA-512, required by the sasl protocols
+	SASLMechanism string `json:"saslMechanism,omitempty"`
+	SASLUsername  string `envconfig:"KAFKA_SASL_USERNAME" json:"saslUsername,omitempty"`
+	SASLPassword  string `envconfig:"KAFKA_SASL_PASSWORD" json:"saslPassword,omitempty"`
+	// CALocation verifies the brokers, the system certificates are used when empty
+	CALocation string `json:"caLocation,omitempty"`
+	// CertificateLocation and KeyLocation authenticate the service with a client certificate
+	CertificateLocation string `json:"certificateLocation,omitempty"`
+	KeyLocation         string `json:"keyLocation,omitempty"`
+	KeyPassword         string `envconfig:"KAFKA_SSL_KEY_PASSWORD" json:"keyPassword,omitempty"`
+}
+
+type ProducerConfig struct {
+	// BootstrapServers defaults to healthChecker.bootstrapServer, for configs written before it was added
+	BootstrapServers string `envconfig:"KAFKA_BOOTSTRAP_SERVERS" json:"bootstrapServers"`
+	// Acks is one of all, 1 or 0, defaults to all
+	Acks string `json:"acks"`
+	// Idempotence makes retries within the producer never write a message twice or out of order, defaults to true and
+	// requires acks all
+	Idempotence *bool `json:"idempotence,omitempty"`
+	// CompressionType is one of none, gzip, snappy, lz4 or zstd, defaults to none
+	CompressionType string `json:"compressionType"`
+	// LingerMillis is how long messages are held back to fill a batch, the librdkafka default is used when 0
+	LingerMillis int `json:"lingerMillis"`
+	// BatchSizeBytes caps the size of a batch, the librdkafka default is used when 0
+	BatchSizeBytes int `json:"batchSizeBytes"`
+	// Properties are passed to librdkafka as they are, for settings that have no field of their own. They are checked
+	// at startup and may not repeat the settings above
+	Properties map[string]string `json:"properties,omitempty"`
+}
+
+type PublisherConfig struct {
+	// Type is one of kafka, memory or jsonl, defaults to kafka
+	Type string `json:"type"`
+	// Path is the file the jsonl publisher appends to, stdout when empty or "-"
+	Path string `json:"path,omitempty"`
+}
+
+type SerializationConfig struct {
+	// DefaultFormat is one of protobuf, protojson or confluent-protobuf
+	DefaultFormat string `json:"defaultFormat"`
+	// TopicFormats overrides DefaultFormat for single topics
+	TopicFormats map[string]string `json:"topicFormats,omitempty"`
+	// SchemaRegistryPath points at the file based schema registry, required by confluent-protobuf
+	SchemaRegistryPath string `json:"schemaRegistryPath,omitempty"`
 }
 
 type OutboxConfig struct {
 	SleepIntervalSeconds int64 `json:"producerSleepIntervalSeconds"`
 	BaseRetryTimeSeconds int64 `json:"baseRetryTimeSeconds"`
 	MaxRetryTimeSeconds  int64 `json:"maxRetryTimeSeconds"`
+	// BatchSize is the number of messages claimed from the outbox at once
+	BatchSize int `json:"batchSize"`
+	// Concurrency is the number of workers handing claimed messages to the producer
+	Concurrency int `json:"concurrency"`
+	// MaxInFlight caps how many produced messages may wait for a delivery report at the same time
+	MaxInFlight int `json:"maxInFlight"`
+	// MaxAttempts is the number of failed deliveries after which a message is dead lettered, defaults to 10
+	MaxAttempts int `json:"maxAttempts"`
+	// InstanceID identifies this replica as owner of the messages it claimed, defaults to the hostname and a random suffix
+	InstanceID string `envconfig:"OUTBOX_INSTANCE_ID" json:"instanceID,omitempty"`
+	// LeaseDurationSeconds is how long a claimed message belongs to this instance, it has to be longer than the wait
+	// for a delivery report, defaults to 600
+	LeaseDurationSeconds int64 `json:"leaseDurationSeconds"`
+	// ReaperIntervalSeconds is how often messages with an expired lease are given back to the outbox, defaults to 30
+	ReaperIntervalSeconds int64 `json:"reaperIntervalSeconds"`
+	// Partitions is the number of partitions the outbox is split in between replicas, by message key. Messages keep
+	// the partition they were stored with, so it should only be changed with an empty outbox. Defaults to 16
+	Partitions int `json:"partitions"`
+	// CoordinationIntervalSeconds is how often replicas announce themselves and rebalance partitions, a replica that
+	// missed three intervals is considered dead. Defaults to 5
+	CoordinationIntervalSeconds int64 `json:"coordinationIntervalSeconds"`
+	// ChangeStream sends new messages as soon as they are stored, by watching the outbox collection. Requires mongodb
+	// to run as a replica set, polling every producerSleepIntervalSeconds is kept for retries and missed changes
+	ChangeStream bool `json:"changeStream"`
+	// Retention removes finished messages, it is disabled when no retention is set
+	Retention OutboxRetentionConfig `json:"retention"`
+}
+
+type OutboxRetentionConfig struct {
+	// FinishedRetentionSeconds is how long finished messages are kept after they were sent, 0 keeps them forever
+	FinishedRetentionSeconds int64 `json:"finishedRetentionSeconds"`
+	// PurgeIntervalSeconds is how often expired messages are purged, defaults to 3600
+	PurgeIntervalSeconds int64 `json:"purgeIntervalSeconds"`
+	// PurgeBatchSize is the number of messages archived and deleted at once, defaults to 500
+	PurgeBatchSize int `json:"purgeBatchSize"`
+	// ArchiveDirectory receives a gzip compressed JSONL file per purge, purged messages are not archived when empty
+	ArchiveDirectory string `json:"archiveDirectory,omitempty"`
+}
+
+type WebhookConfig struct {
+	// Enabled records a delivery for every matching subscription when an event is put in the outbox, and sends them
+	Enabled bool `json:"enabled"`
+	// PollIntervalSeconds is how often due deliveries are looked for, defaults to 1
+	PollIntervalSeconds int64 `json:"pollIntervalSeconds"`
+	// BatchSize is the number of deliveries claimed at once, defaults to 20
+	BatchSize int `json:"batchSize"`
+	// Concurrency is the number of deliveries sent at the same time, defaults to 4
+	Concurrency int `json:"concurrency"`
+	// RequestTimeoutSeconds bounds a single request to an endpoint, defaults to 10
+	RequestTimeoutSeconds int64 `json:"requestTimeoutSeconds"`
+	// LeaseDurationSeconds is how long a claimed delivery belongs to this instance before another one may retry it,
+	// defaults to 60
+	LeaseDurationSeconds int64 `json:"leaseDurationSeconds"`
+	BaseRetryTimeSeconds int64 `json:"baseRetryTimeSeconds"`
+	MaxRetryTimeSeconds  int64 `json:"maxRetryTimeSeconds"`
+	// MaxAttempts is the number of failed attempts after which a delivery is given up, defaults to 10
+	MaxAttempts int `json:"maxAttempts"`
 }
 
 type HealthCheckerConfig struct {
-	HealthTopicName                  string `json:"healthTopicName"`
-	BootstrapServer                  string `json:"bootstrapServer"`
-	TickerIntervalSeconds            int64  `json:"tickerIntervalSeconds"`
-	HealthCheckTickerIntervalSeconds int64  `json:"healthCheckTickerIntervalSeconds"`
-	OrdinaryHealthCheckListeningPort int    `json:"ordinaryHealthCheckListeningPort"`
+	HealthTopicName string `json:"healthTopicName"`
+	BootstrapServer string `json:"bootstrapServer"`
+	// TickerIntervalSeconds is no longer used, reports are taken in as soon as the checks are done
+	TickerIntervalSeconds            int64 `json:"tickerIntervalSeconds"`
+	HealthCheckTickerIntervalSeconds int64 `json:"healthCheckTickerIntervalSeconds"`
+	OrdinaryHealthCheckListeningPort int   `json:"ordinaryHealthCheckListeningPort"`
+	// HistorySize is how many status changes /healthz shows, 100 when not set
+	HistorySize int `json:"historySize,omitempty"`
+	// OutboxLag makes the OutboxLag health check fail when the outbox falls behind
+	OutboxLag OutboxLagThresholds `json:"outboxLag"`
+}
+
+// OutboxLagThresholds are the limits of the OutboxLag health check, 0 leaves a limit out
+type OutboxLagThresholds struct {
+	// MaxOldestWaitingAgeSeconds is how long ago the oldest waiting message may have been created
+	MaxOldestWaitingAgeSeconds int64 `json:"maxOldestWaitingAgeSeconds"`
+	MaxProcessing              int64 `json:"maxProcessing"`
+	MaxDeadLettered            int64 `json:"maxDeadLettered"`
 }
 
 func ReadConfig(path string) (AppConfig, error) {
@@ -0,0 +1,87 @@
+package domainerror
+
+import "errors"
+
+// Kind classifies a domain error independently of the transport that ends up reporting it
+type Kind int
+
+const (
+	KindInternal Kind = iota
+	KindInvalidArgument
+	KindNotFound
+	KindAlreadyExists
+	KindFailedPrecondition
+)
+
+func (k Kind) String() string {
+	switch k {
+	case KindInvalidArgument:
+		return "invalid argument"
+	case KindNotFound:
+		return "not found"
+	case KindAlreadyExists:
+		return "already exists"
+	case KindFailedPrecondition:
+		return "failed precondition"
+	}
+	return "internal"
+}
+
+// FieldViolation describes why a single field of a request is invalid
+type FieldViolation struct {
+	Field       string
+	Description string
+}
+
+type Error struct {
+	Kind       Kind
+	Message    string
+	Violations []FieldViolation
+}
+
+func (e *Error) Error() string {
+	return e.Message
+}
+
+func InvalidArgument(message string, violations ...FieldViolation) *Error {
+	return &Error{Kind: KindInvalidArgument, Message: message, Violations: violations}
+}
+
+// InvalidField is a shorthand for an invalid argument caused by exactly one field
+func InvalidField(field string, description string) *Error {
+	return InvalidArgument(description, FieldViolation{Field: field, Description: description})
+}
+
+func NotFound(message string) *Error {
+	return &Error{Kind: KindNotFound, Message: message}
+}
+
+func AlreadyExists(message string) *Error {
+	return &Error{Kind: KindAlreadyExists, Message: message}
+}
+
+func FailedPrecondition(message string) *Error {
+	return &Error{Kind: KindFailedPrecondition, Message: message}
+}
+
+func Internal(message string) *Error {
+	return &Error{Kind: KindInternal, Message: message}
+}
+
+// As finds the first domain error in the chain of err
+func As(err error) (*Error, bool) {
+	var domainErr *Error
+	if errors.As(err, &domainErr) {
+		return domainErr, true
+	}
+	return nil, false
+}
+
+// KindOf returns the kind of the domain error in err, anything that is not a domain error is internal
+func KindOf(err error) Kind {
+	domainErr, ok := As(err)
+	if !ok {
+		return KindInternal
+	}
+	return domainErr.Kind
+}
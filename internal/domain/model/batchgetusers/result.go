@@ -0,0 +1,9 @@
+package batchgetusers
+
+import "userservice/internal/domain/model"
+
+// Result of looking up a single requested id, User is nil when no user exists with the id
+type Result struct {
+	ID   string      `json:"id"`
+	User *model.User `json:"user,omitempty"`
+}
@@ -0,0 +1,36 @@
+package replayusers
+
+import (
+	"time"
+	"userservice/internal/domain/model/listusers"
+)
+
+type Request struct {
+	// ID names the replay, starting a replay with the ID of an unfinished one resumes it from its checkpoint
+	ID string `json:"id"`
+	// Filtering limits the replay to a subset of the users, a resumed replay keeps the filter it was started with
+	Filtering *listusers.FilterInfo `json:"filtering,omitempty"`
+	// BatchSize is the number of users enqueued, and checkpointed, at once
+	BatchSize int64 `json:"batchSize"`
+	// UsersPerSecond throttles the replay, so the outbox and the consumers can keep up
+	UsersPerSecond int64 `json:"usersPerSecond"`
+}
+
+// Checkpoint is the stored progress of a replay
+type Checkpoint struct {
+	ID        string                `json:"id"`
+	Filtering *listusers.FilterInfo `json:"filtering,omitempty"`
+	// BatchSize and UsersPerSecond are those of the request that started the replay, a resumed replay keeps them
+	BatchSize      int64 `json:"batchSize"`
+	UsersPerSecond int64 `json:"usersPerSecond"`
+	// Cursor is the keyset pagination cursor of the last enqueued user
+	Cursor     string    `json:"cursor"`
+	Enqueued   int64     `json:"enqueued"`
+	StartedAt  time.Time `json:"startedAt"`
+	UpdatedAt  time.Time `json:"updatedAt"`
+	FinishedAt time.Time `json:"finishedAt"`
+}
+
+func (c Checkpoint) Finished() bool {
+	return !c.FinishedAt.IsZero()
+}
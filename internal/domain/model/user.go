@@ -14,4 +14,7 @@ type User struct {
 	Country   string    `json:"country"`
 	CreatedAt time.Time `json:"created_at"`
 	UpdatedAt time.Time `json:"updated_at"`
+	// Suspended users are kept, but flagged for other services to lock out
+	Suspended   bool      `json:"suspended"`
+	SuspendedAt time.Time `json:"suspended_at,omitempty"`
 }
@@ -0,0 +1,63 @@
+package model
+
+import "time"
+
+// event types partners can subscribe to, named after the kafka schemas
+const (
+	EventTypeUserAdded   = "UserAdded"
+	EventTypeUserRemoved = "UserRemoved"
+	EventTypeUserUpdated = "UserUpdated"
+)
+
+var EventTypes = []string{EventTypeUserAdded, EventTypeUserRemoved, EventTypeUserUpdated}
+
+type WebhookSubscription struct {
+	ID  string
+	URL string
+	// EventTypes filters the events sent to the endpoint, all events are sent when empty
+	EventTypes []string
+	// Secret signs the payloads, it is only handed out when the subscription is created
+	Secret    string
+	CreatedAt time.Time
+}
+
+// Wants tells whether an event of eventType should be delivered to the subscription
+func (s WebhookSubscription) Wants(eventType string) bool {
+	if len(s.EventTypes) == 0 {
+		return true
+	}
+	for _, wanted := range s.EventTypes {
+		if wanted == eventType {
+			return true
+		}
+	}
+	return false
+}
+
+// WebhookDelivery is one event sent to one subscription, together with every attempt made
+type WebhookDelivery struct {
+	ID             string
+	SubscriptionID string
+	URL            string
+	EventID        string
+	EventType      string
+	Payload        []byte
+	State          string
+	CreatedAt      time.Time
+	NextRetry      time.Time
+	DeliveredAt    time.Time
+	LastError      string
+	Attempts       []WebhookAttempt
+	// LeaseToken identifies the claim of a processing delivery, only its holder may record the outcome until
+	// LeaseExpiresAt
+	LeaseToken     string
+	LeaseExpiresAt time.Time
+}
+
+type WebhookAttempt struct {
+	AttemptedAt time.Time
+	// StatusCode is 0 when no response was received
+	StatusCode int
+	Error      string
+	Duration   time.Duration
+}
@@ -0,0 +1,240 @@
+package replay
+
+import (
+	"context"
+	"errors"
+	"github.com/google/uuid"
+	"github.com/rs/zerolog/log"
+	"sync"
+	"time"
+	"userservice/internal/domain/domainerror"
+	"userservice/internal/domain/model/listusers"
+	"userservice/internal/domain/model/replayusers"
+)
+
+var (
+	ErrReplayIDIsRequired    = domainerror.InvalidField("replay_id", "replay id is required")
+	ErrInvalidReplayFilter   = domainerror.InvalidField("filtering", "filter needs a field and a comparer")
+	ErrBatchSizeIsNotValid   = domainerror.InvalidField("batch_size", "batch size has to be between 0 and 1000")
+	ErrUsersPerSecondInvalid = domainerror.InvalidField("users_per_second", "users per second can not be negative")
+	ErrReplayAlreadyRunning  = domainerror.FailedPrecondition("replay is already running")
+)
+
+const (
+	defaultBatchSize      = 100
+	maxBatchSize          = 1000
+	defaultUsersPerSecond = 200
+)
+
+type component struct {
+	repo Repo
+	// running holds the ids of the replays this instance is running, so a replay is not started twice
+	running sync.Map
+	// wait is time.Sleep that stops early when ctx is cancelled, replaced in tests
+	wait func(ctx context.Context, d time.Duration) error
+	// stopped is done once CleanUp was called, it stops the launched replays
+	stopped  context.Context
+	stop     context.CancelFunc
+	launched sync.WaitGroup
+}
+
+type Component interface {
+	// Start creates the checkpoint of a new replay, or returns the one of the replay that is resumed
+	Start(ctx context.Context, request replayusers.Request) (replayusers.Checkpoint, error)
+	// Run enqueues the users of a started replay, throttled, until all are enqueued or ctx is cancelled
+	Run(ctx context.Context, request replayusers.Request) (replayusers.Checkpoint, error)
+	// Launch starts the replay and runs it in the background until it is finished or CleanUp is called, the replay
+	// keeps the values of ctx. ErrReplayAlreadyRunning is returned right away when this instance runs it already
+	Launch(ctx context.Context, request replayusers.Request) (replayusers.Checkpoint, error)
+	// ResumeUnfinished launches the replays that were stopped before they were finished, like by a restart
+	ResumeUnfinished(ctx context.Context) error
+	Get(ctx context.Context, id string) (replayusers.Checkpoint, error)
+	// CleanUp stops the launched replays and waits for them, they are resumed by ResumeUnfinished
+	CleanUp()
+}
+
+func NewReplayComponent(repo Repo) Component {
+	stopped, stop := context.WithCancel(context.Background())
+	return &component{
+		repo:    repo,
+		wait:    waitFor,
+		stopped: stopped,
+		stop:    stop,
+	}
+}
+
+func waitFor(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// withDefaults validates the request, a missing id is generated
+func withDefaults(request replayusers.Request) (replayusers.Request, error) {
+	if request.ID == "" {
+		request.ID = uuid.NewString()
+	}
+	if request.Filtering != nil && (request.Filtering.Left == listusers.UserFieldUnspecified || request.Filtering.Comparer == listusers.ComparerUnspecified) {
+		return replayusers.Request{}, ErrInvalidReplayFilter
+	}
+	if request.BatchSize < 0 || request.BatchSize > maxBatchSize {
+		return replayusers.Request{}, ErrBatchSizeIsNotValid
+	}
+	if request.BatchSize == 0 {
+		request.BatchSize = defaultBatchSize
+	}
+	if request.UsersPerSecond < 0 {
+		return replayusers.Request{}, ErrUsersPerSecondInvalid
+	}
+	if request.UsersPerSecond == 0 {
+		request.UsersPerSecond = defaultUsersPerSecond
+	}
+	return request, nil
+}
+
+func (c *component) Start(ctx context.Context, request replayusers.Request) (replayusers.Checkpoint, error) {
+	request, err := withDefaults(request)
+	if err != nil {
+		return replayusers.Checkpoint{}, err
+	}
+
+	checkpoint, err := c.repo.GetReplayCheckpoint(ctx, request.ID)
+	if err == nil {
+		log.Info().Msgf("ReplayComponent: resuming replay %s after %d users", checkpoint.ID, checkpoint.Enqueued)
+		return checkpoint, nil
+	}
+	if domainerror.KindOf(err) != domainerror.KindNotFound {
+		return replayusers.Checkpoint{}, err
+	}
+
+	log.Info().Msgf("ReplayComponent: starting replay %s", request.ID)
+	now := time.Now().UTC()
+	return c.repo.CreateReplayCheckpoint(ctx, replayusers.Checkpoint{
+		ID:             request.ID,
+		Filtering:      request.Filtering,
+		BatchSize:      request.BatchSize,
+		UsersPerSecond: request.UsersPerSecond,
+		StartedAt:      now,
+		UpdatedAt:      now,
+	})
+}
+
+func (c *component) Run(ctx context.Context, request replayusers.Request) (replayusers.Checkpoint, error) {
+	if request.ID == "" {
+		return replayusers.Checkpoint{}, ErrReplayIDIsRequired
+	}
+	request, err := withDefaults(request)
+	if err != nil {
+		return replayusers.Checkpoint{}, err
+	}
+	if _, running := c.running.LoadOrStore(request.ID, struct{}{}); running {
+		return replayusers.Checkpoint{}, ErrReplayAlreadyRunning
+	}
+	defer c.running.Delete(request.ID)
+	return c.run(ctx, request)
+}
+
+// run must only be called for a valid request of a replay that is marked as running
+func (c *component) run(ctx context.Context, request replayusers.Request) (replayusers.Checkpoint, error) {
+	checkpoint, err := c.repo.GetReplayCheckpoint(ctx, request.ID)
+	if err != nil {
+		return replayusers.Checkpoint{}, err
+	}
+
+	for !checkpoint.Finished() {
+		batchStarted := time.Now()
+		enqueuedBefore := checkpoint.Enqueued
+		checkpoint, err = c.repo.EnqueueReplayBatch(ctx, checkpoint, request.BatchSize)
+		if err != nil {
+			log.Err(err).Msgf("ReplayComponent: replay %s stopped after %d users", request.ID, checkpoint.Enqueued)
+			return checkpoint, err
+		}
+		log.Info().Msgf("ReplayComponent: replay %s enqueued %d users", request.ID, checkpoint.Enqueued)
+
+		// spread the users evenly, a batch may only take as long as its users are allowed to
+		allowed := time.Duration(checkpoint.Enqueued-enqueuedBefore) * time.Second / time.Duration(request.UsersPerSecond)
+		if remaining := allowed - time.Since(batchStarted); remaining > 0 && !checkpoint.Finished() {
+			err = c.wait(ctx, remaining)
+			if err != nil {
+				return checkpoint, err
+			}
+		}
+	}
+
+	log.Info().Msgf("ReplayComponent: replay %s finished after %d users", request.ID, checkpoint.Enqueued)
+	return checkpoint, nil
+}
+
+func (c *component) Launch(ctx context.Context, request replayusers.Request) (replayusers.Checkpoint, error) {
+	request, err := withDefaults(request)
+	if err != nil {
+		return replayusers.Checkpoint{}, err
+	}
+	checkpoint, err := c.Start(ctx, request)
+	if err != nil {
+		return replayusers.Checkpoint{}, err
+	}
+	if checkpoint.Finished() {
+		return checkpoint, nil
+	}
+	return checkpoint, c.launch(ctx, request)
+}
+
+func (c *component) ResumeUnfinished(ctx context.Context) error {
+	checkpoints, err := c.repo.ListUnfinishedReplayCheckpoints(ctx)
+	if err != nil {
+		return err
+	}
+	for _, checkpoint := range checkpoints {
+		// replays stored before the checkpoint had these are resumed with the defaults
+		request, err := withDefaults(replayusers.Request{ID: checkpoint.ID, BatchSize: checkpoint.BatchSize, UsersPerSecond: checkpoint.UsersPerSecond})
+		if err != nil {
+			log.Err(err).Msgf("ReplayComponent: can not resume replay %s", checkpoint.ID)
+			continue
+		}
+		log.Info().Msgf("ReplayComponent: resuming replay %s after %d users", checkpoint.ID, checkpoint.Enqueued)
+		err = c.launch(ctx, request)
+		if err != nil && !errors.Is(err, ErrReplayAlreadyRunning) {
+			return err
+		}
+	}
+	return nil
+}
+
+// launch runs the valid request in the background. Another replica that runs the same replay is noticed by the repo,
+// the checkpoint is only moved by one of them
+func (c *component) launch(ctx context.Context, request replayusers.Request) error {
+	if _, running := c.running.LoadOrStore(request.ID, struct{}{}); running {
+		return ErrReplayAlreadyRunning
+	}
+	runContext, cancel := context.WithCancel(context.WithoutCancel(ctx))
+	stopWithComponent := context.AfterFunc(c.stopped, cancel)
+
+	c.launched.Add(1)
+	go func() {
+		defer c.launched.Done()
+		defer cancel()
+		defer stopWithComponent()
+		defer c.running.Delete(request.ID)
+		// run logs why the replay stopped, the checkpoint tells how far it got
+		_, _ = c.run(runContext, request)
+	}()
+	return nil
+}
+
+func (c *component) CleanUp() {
+	c.stop()
+	c.launched.Wait()
+}
+
+func (c *component) Get(ctx context.Context, id string) (replayusers.Checkpoint, error) {
+	if id == "" {
+		return replayusers.Checkpoint{}, ErrReplayIDIsRequired
+	}
+	return c.repo.GetReplayCheckpoint(ctx, id)
+}
@@ -0,0 +1,216 @@
+package replay
+
+import (
+	"context"
+	"fmt"
+	"github.com/stretchr/testify/require"
+	"testing"
+	"time"
+	"userservice/internal/domain/model"
+	"userservice/internal/domain/model/listusers"
+	"userservice/internal/domain/model/replayusers"
+	"userservice/internal/mock"
+)
+
+func testUsers(count int) []model.User {
+	users := make([]model.User, 0, count)
+	for i := range count {
+		country := "DK"
+		if i%2 == 1 {
+			country = "GB"
+		}
+		users = append(users, model.User{ID: fmt.Sprintf("user-%02d", i), Country: country})
+	}
+	return users
+}
+
+// newTestComponent records the throttling waits instead of sleeping
+func newTestComponent(repo Repo) (*component, *[]time.Duration) {
+	var waits []time.Duration
+	c := NewReplayComponent(repo).(*component)
+	c.wait = func(ctx context.Context, d time.Duration) error {
+		waits = append(waits, d)
+		return nil
+	}
+	return c, &waits
+}
+
+func enqueuedIDs(repo *mock.ReplayRepoMock) []string {
+	var ids []string
+	for _, user := range repo.Enqueued {
+		ids = append(ids, user.ID)
+	}
+	return ids
+}
+
+func TestReplayAllUsers(t *testing.T) {
+	repo := mock.NewReplayRepoMock(testUsers(25)...)
+	c, waits := newTestComponent(repo)
+	ctx := context.Background()
+
+	request := replayusers.Request{ID: "backfill", BatchSize: 10, UsersPerSecond: 5}
+	checkpoint, err := c.Start(ctx, request)
+	require.NoError(t, err)
+	require.Equal(t, "backfill", checkpoint.ID)
+	require.False(t, checkpoint.Finished())
+
+	checkpoint, err = c.Run(ctx, request)
+	require.NoError(t, err)
+	require.True(t, checkpoint.Finished())
+	require.EqualValues(t, 25, checkpoint.Enqueued)
+	require.Len(t, repo.Enqueued, 25)
+	require.Equal(t, "user-00", repo.Enqueued[0].ID)
+	require.Equal(t, "user-24", repo.Enqueued[24].ID)
+
+	// two full batches of 10 users at 5 users per second, nothing to wait for after the last batch
+	require.Len(t, *waits, 2)
+	for _, wait := range *waits {
+		require.InDelta(t, 2*time.Second, wait, float64(100*time.Millisecond))
+	}
+
+	// running a finished replay again enqueues nothing
+	_, err = c.Start(ctx, request)
+	require.NoError(t, err)
+	_, err = c.Run(ctx, request)
+	require.NoError(t, err)
+	require.Len(t, repo.Enqueued, 25)
+}
+
+func TestReplayFilteredUsers(t *testing.T) {
+	repo := mock.NewReplayRepoMock(testUsers(10)...)
+	c, _ := newTestComponent(repo)
+	ctx := context.Background()
+
+	request := replayusers.Request{
+		ID:        "danes",
+		Filtering: &listusers.FilterInfo{Left: listusers.UserFieldCountry, Comparer: listusers.ComparerEqual, Right: "DK"},
+		BatchSize: 2,
+	}
+	_, err := c.Start(ctx, request)
+	require.NoError(t, err)
+
+	// the filter of the started replay is used, not the one given when resuming
+	request.Filtering = nil
+	checkpoint, err := c.Run(ctx, request)
+	require.NoError(t, err)
+	require.EqualValues(t, 5, checkpoint.Enqueued)
+	require.Equal(t, []string{"user-00", "user-02", "user-04", "user-06", "user-08"}, enqueuedIDs(repo))
+}
+
+func TestReplayResumesFromCheckpoint(t *testing.T) {
+	repo := mock.NewReplayRepoMock(testUsers(10)...)
+	repo.FailAfter = 4
+	c, _ := newTestComponent(repo)
+	ctx := context.Background()
+
+	request := replayusers.Request{ID: "flaky", BatchSize: 3}
+	_, err := c.Start(ctx, request)
+	require.NoError(t, err)
+	_, err = c.Run(ctx, request)
+	require.Error(t, err)
+	require.Len(t, repo.Enqueued, 3, "only the first batch made it")
+
+	repo.FailAfter = 0
+	checkpoint, err := c.Start(ctx, request)
+	require.NoError(t, err)
+	require.EqualValues(t, 3, checkpoint.Enqueued)
+
+	checkpoint, err = c.Run(ctx, request)
+	require.NoError(t, err)
+	require.EqualValues(t, 10, checkpoint.Enqueued)
+	require.Len(t, repo.Enqueued, 10, "no user is enqueued twice")
+	require.Equal(t, "user-03", repo.Enqueued[3].ID)
+}
+
+func TestReplayValidation(t *testing.T) {
+	c, _ := newTestComponent(mock.NewReplayRepoMock())
+	ctx := context.Background()
+
+	tests := []struct {
+		request replayusers.Request
+		err     error
+	}{
+		{replayusers.Request{Filtering: &listusers.FilterInfo{Left: listusers.UserFieldCountry}}, ErrInvalidReplayFilter},
+		{replayusers.Request{BatchSize: -1}, ErrBatchSizeIsNotValid},
+		{replayusers.Request{BatchSize: maxBatchSize + 1}, ErrBatchSizeIsNotValid},
+		{replayusers.Request{UsersPerSecond: -1}, ErrUsersPerSecondInvalid},
+	}
+	for _, test := range tests {
+		_, err := c.Start(ctx, test.request)
+		require.ErrorIs(t, err, test.err)
+	}
+
+	checkpoint, err := c.Start(ctx, replayusers.Request{})
+	require.NoError(t, err)
+	require.NotEmpty(t, checkpoint.ID, "an id is generated")
+
+	_, err = c.Run(ctx, replayusers.Request{})
+	require.ErrorIs(t, err, ErrReplayIDIsRequired)
+}
+
+func TestReplayIsNotRunTwice(t *testing.T) {
+	repo := mock.NewReplayRepoMock(testUsers(3)...)
+	c := NewReplayComponent(repo).(*component)
+	ctx := context.Background()
+
+	request := replayusers.Request{ID: "once", BatchSize: 1, UsersPerSecond: 1}
+	_, err := c.Start(ctx, request)
+	require.NoError(t, err)
+
+	waiting := make(chan struct{})
+	release := make(chan struct{})
+	c.wait = func(ctx context.Context, d time.Duration) error {
+		close(waiting)
+		<-release
+		return context.Canceled
+	}
+	done := make(chan error)
+	go func() {
+		_, err := c.Run(ctx, request)
+		done <- err
+	}()
+
+	<-waiting
+	_, err = c.Run(ctx, request)
+	require.ErrorIs(t, err, ErrReplayAlreadyRunning)
+	close(release)
+	require.ErrorIs(t, <-done, context.Canceled)
+}
+
+func TestLaunchedReplayIsStoppedAndResumed(t *testing.T) {
+	repo := mock.NewReplayRepoMock(testUsers(3)...)
+	c := NewReplayComponent(repo).(*component)
+	ctx := context.Background()
+
+	request := replayusers.Request{ID: "launched", BatchSize: 1, UsersPerSecond: 1}
+	checkpoint, err := c.Launch(ctx, request)
+	require.NoError(t, err)
+	require.False(t, checkpoint.Finished())
+	_, err = c.Launch(ctx, request)
+	require.ErrorIs(t, err, ErrReplayAlreadyRunning)
+
+	require.Eventually(t, func() bool {
+		checkpoint, err = c.Get(ctx, request.ID)
+		return err == nil && checkpoint.Enqueued == 1
+	}, time.Second, 10*time.Millisecond)
+	// stopped while it waits for the next batch
+	c.CleanUp()
+	checkpoint, err = c.Get(ctx, request.ID)
+	require.NoError(t, err)
+	require.False(t, checkpoint.Finished())
+
+	restarted, waits := newTestComponent(repo)
+	require.NoError(t, restarted.ResumeUnfinished(ctx))
+	require.Eventually(t, func() bool {
+		checkpoint, err = restarted.Get(ctx, request.ID)
+		return err == nil && checkpoint.Finished()
+	}, time.Second, 10*time.Millisecond)
+	restarted.CleanUp()
+	require.EqualValues(t, 3, checkpoint.Enqueued)
+	require.Equal(t, []string{"user-00", "user-01", "user-02"}, enqueuedIDs(repo))
+	// the resumed replay keeps the batch size and rate it was started with
+	require.Len(t, *waits, 2)
+	for _, wait := range *waits {
+		require.InDelta(t, time.Second, wait, float64(100*time.Millisecond))
+	}
+}
@@ -0,0 +1,17 @@
+package replay
+
+import (
+	"context"
+	"userservice/internal/domain/model/replayusers"
+)
+
+type Repo interface {
+	// GetReplayCheckpoint returns a not found domain error for unknown replays
+	GetReplayCheckpoint(ctx context.Context, id string) (replayusers.Checkpoint, error)
+	CreateReplayCheckpoint(ctx context.Context, checkpoint replayusers.Checkpoint) (replayusers.Checkpoint, error)
+	// ListUnfinishedReplayCheckpoints returns the checkpoints of the replays that have users left to enqueue
+	ListUnfinishedReplayCheckpoints(ctx context.Context) ([]replayusers.Checkpoint, error)
+	// EnqueueReplayBatch puts snapshots of the next limit users after the checkpoint in the outbox and moves the
+	// checkpoint past them, both or neither happen. It fails when the checkpoint was moved by someone else
+	EnqueueReplayBatch(ctx context.Context, checkpoint replayusers.Checkpoint, limit int64) (replayusers.Checkpoint, error)
+}
@@ -2,24 +2,37 @@ package user
 
 import (
 	"context"
-	"errors"
 	"github.com/google/uuid"
 	"github.com/rs/zerolog/log"
+	"userservice/internal/domain/domainerror"
 	"userservice/internal/domain/model"
 	"userservice/internal/domain/model/adduser"
+	"userservice/internal/domain/model/batchgetusers"
 	"userservice/internal/domain/model/listusers"
 	"userservice/internal/domain/model/updateuser"
 	"userservice/internal/util/validation"
 )
 
 var (
-	ErrRequestedCountryIsNotValid      = errors.New("request country is not valid")
-	ErrRequestedEmailIsNotValid        = errors.New("request email is not valid")
-	ErrRequestedUserIDIsNotUUID        = errors.New("requested user id is not uuid")
-	errUnableToUpdateUserInternalError = errors.New("unable to update user: internal error")
-	errUnableToRemoveUserInternalError = errors.New("unable to remove user: internal error")
+	ErrRequestedCountryIsNotValid       = domainerror.InvalidField("country", "request country is not valid")
+	ErrRequestedEmailIsNotValid         = domainerror.InvalidField("email", "request email is not valid")
+	ErrRequestedUserIDIsNotUUID         = domainerror.InvalidField("user_id", "requested user id is not uuid")
+	ErrRequestedUserIDsIsEmpty          = domainerror.InvalidField("user_ids", "requested user ids is empty")
+	ErrTooManyRequestedUserIDs          = domainerror.InvalidField("user_ids", "too many requested user ids")
+	errFirstNameIsRequired              = domainerror.InvalidField("first_name", "first name is required")
+	errLastNameIsRequired               = domainerror.InvalidField("last_name", "last name is required")
+	errNicknameIsRequired               = domainerror.InvalidField("nickname", "nickname is required")
+	errEmailIsRequired                  = domainerror.InvalidField("email", "email is required")
+	errPasswordIsRequired               = domainerror.InvalidField("password", "password is required")
+	errCountryIsRequired                = domainerror.InvalidField("country", "country is required")
+	errUnableToUpdateUserInternalError  = domainerror.Internal("unable to update user: internal error")
+	errUnableToRemoveUserInternalError  = domainerror.Internal("unable to remove user: internal error")
+	errUnableToSuspendUserInternalError = domainerror.Internal("unable to suspend user: internal error")
 )
 
+// maxBatchGetUsers caps how many users can be looked up in a single BatchGetUsers call
+const maxBatchGetUsers = 100
+
 type component struct {
 	repo Repo
 }
@@ -28,7 +41,10 @@ type Component interface {
 	AddUser(ctx context.Context, requestUser adduser.Request) (model.User, error)
 	RemoveUser(ctx context.Context, userID string) (model.User, error)
 	UpdateUser(ctx context.Context, userID string, user updateuser.Request) (model.User, error)
+	SuspendUser(ctx context.Context, userID string, reason string) (model.User, error)
 	ListUsers(ctx context.Context, request listusers.Request) (listusers.Response, error)
+	GetUser(ctx context.Context, userID string) (model.User, error)
+	BatchGetUsers(ctx context.Context, userIDs []string) ([]batchgetusers.Result, error)
 }
 
 func NewUserComponent(conn Repo) Component {
@@ -37,6 +53,14 @@ func NewUserComponent(conn Repo) Component {
 	}
 }
 
+// keepDomainError passes errors the repo already classified on to the caller, anything else is replaced by fallback
+func keepDomainError(err error, fallback error) error {
+	if _, ok := domainerror.As(err); ok {
+		return err
+	}
+	return fallback
+}
+
 func isValidUUID(idString string) bool {
 	_, err := uuid.Parse(idString)
 	return err == nil
@@ -55,23 +79,23 @@ func (c *component) AddUser(ctx context.Context, requestUser adduser.Request) (m
 		return model.User{}, ErrRequestedEmailIsNotValid
 	}
 	if requestUser.FirstName == "" {
-		return model.User{}, errors.New("first name is required")
+		return model.User{}, errFirstNameIsRequired
 	}
 	if requestUser.LastName == "" {
-		return model.User{}, errors.New("last name is required")
+		return model.User{}, errLastNameIsRequired
 	}
 	if requestUser.Nickname == "" {
-		return model.User{}, errors.New("nickname is required")
+		return model.User{}, errNicknameIsRequired
 	}
 	if requestUser.Email == "" {
-		return model.User{}, errors.New("email is required")
+		return model.User{}, errEmailIsRequired
 	}
 
 	if requestUser.Password == "" {
-		return model.User{}, errors.New("password is required")
+		return model.User{}, errPasswordIsRequired
 	}
 	if requestUser.Country == "" {
-		return model.User{}, errors.New("country is required")
+		return model.User{}, errCountryIsRequired
 	}
 
 	newUser := model.User{
@@ -102,7 +126,7 @@ func (c *component) RemoveUser(ctx context.Context, userID string) (model.User,
 	removedUser, err := c.repo.RemoveUser(ctx, userID)
 	if err != nil {
 		log.Err(err).Msgf("UserComponent: failed to remove user %s", userID)
-		return model.User{}, errUnableToRemoveUserInternalError
+		return model.User{}, keepDomainError(err, errUnableToRemoveUserInternalError)
 	}
 
 	return removedUser, nil
@@ -118,12 +142,28 @@ func (c *component) UpdateUser(ctx context.Context, userID string, user updateus
 	modifiedUser, err := c.repo.UpdateUser(ctx, userID, user)
 	if err != nil {
 		log.Err(err).Msgf("UserComponent: failed to update user %s", userID)
-		return model.User{}, errUnableToUpdateUserInternalError
+		return model.User{}, keepDomainError(err, errUnableToUpdateUserInternalError)
 	}
 
 	return modifiedUser, nil
 }
 
+func (c *component) SuspendUser(ctx context.Context, userID string, reason string) (model.User, error) {
+	log.Info().Msgf("UserComponent: suspending user %s", userID)
+
+	if !isValidUUID(userID) {
+		return model.User{}, ErrRequestedUserIDIsNotUUID
+	}
+
+	suspendedUser, err := c.repo.SuspendUser(ctx, userID, reason)
+	if err != nil {
+		log.Err(err).Msgf("UserComponent: failed to suspend user %s", userID)
+		return model.User{}, keepDomainError(err, errUnableToSuspendUserInternalError)
+	}
+
+	return suspendedUser, nil
+}
+
 func (c *component) ListUsers(ctx context.Context, request listusers.Request) (listusers.Response, error) {
 
 	users, err := c.repo.ListUsers(ctx, request)
@@ -133,3 +173,49 @@ func (c *component) ListUsers(ctx context.Context, request listusers.Request) (l
 
 	return users, nil
 }
+
+func (c *component) GetUser(ctx context.Context, userID string) (model.User, error) {
+
+	if !isValidUUID(userID) {
+		return model.User{}, ErrRequestedUserIDIsNotUUID
+	}
+
+	return c.repo.GetUser(ctx, userID)
+}
+
+func (c *component) BatchGetUsers(ctx context.Context, userIDs []string) ([]batchgetusers.Result, error) {
+
+	if len(userIDs) == 0 {
+		return nil, ErrRequestedUserIDsIsEmpty
+	}
+	if len(userIDs) > maxBatchGetUsers {
+		return nil, ErrTooManyRequestedUserIDs
+	}
+	for _, userID := range userIDs {
+		if !isValidUUID(userID) {
+			return nil, ErrRequestedUserIDIsNotUUID
+		}
+	}
+
+	foundUsers, err := c.repo.GetUsers(ctx, userIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	usersByID := make(map[string]model.User, len(foundUsers))
+	for _, foundUser := range foundUsers {
+		usersByID[foundUser.ID] = foundUser
+	}
+
+	// keep the order of the requested ids, duplicates get the same user
+	results := make([]batchgetusers.Result, 0, len(userIDs))
+	for _, userID := range userIDs {
+		result := batchgetusers.Result{ID: userID}
+		if foundUser, ok := usersByID[userID]; ok {
+			result.User = &foundUser
+		}
+		results = append(results, result)
+	}
+
+	return results, nil
+}
@@ -5,6 +5,7 @@ import (
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/require"
 	"testing"
+	"userservice/internal/domain/domainerror"
 	"userservice/internal/domain/model"
 	"userservice/internal/domain/model/adduser"
 	"userservice/internal/domain/model/listusers"
@@ -53,7 +54,7 @@ func TestFailAddUserWithBadCountryCode(t *testing.T) {
 	u := getSuccessfulUserRequest()
 	u.Country = "DENMARK"
 	_, err := c.AddUser(context.Background(), u)
-	require.Error(t, err)
+	require.ErrorIs(t, err, ErrRequestedCountryIsNotValid)
 }
 func TestFailAddUserWithBadEmail(t *testing.T) {
 	mockUserRepo := mock.NewUserRepoMock()
@@ -127,6 +128,30 @@ func TestSuccessRemoveUser(t *testing.T) {
 	// TODO: check outbox
 }
 
+func TestSuspendUser(t *testing.T) {
+	mockUserRepo := mock.NewUserRepoMock()
+	c := NewUserComponent(mockUserRepo)
+
+	userToSuspend, err := c.AddUser(context.Background(), getSuccessfulUserRequest())
+	require.NoError(t, err)
+
+	suspendedUser, err := c.SuspendUser(context.Background(), userToSuspend.ID, "fraud")
+	require.NoError(t, err)
+	require.True(t, suspendedUser.Suspended)
+	require.False(t, suspendedUser.SuspendedAt.IsZero())
+
+	// suspending again keeps the first suspension
+	suspendedAgain, err := c.SuspendUser(context.Background(), userToSuspend.ID, "fraud")
+	require.NoError(t, err)
+	require.Equal(t, suspendedUser.SuspendedAt, suspendedAgain.SuspendedAt)
+
+	_, err = c.SuspendUser(context.Background(), "not a uuid", "fraud")
+	require.ErrorIs(t, err, ErrRequestedUserIDIsNotUUID)
+
+	_, err = c.SuspendUser(context.Background(), uuid.NewString(), "fraud")
+	require.Equal(t, domainerror.KindNotFound, domainerror.KindOf(err))
+}
+
 func TestFailNoUserWithID(t *testing.T) {
 	mockUserRepo := mock.NewUserRepoMock()
 	c := NewUserComponent(mockUserRepo)
@@ -142,6 +167,7 @@ func TestFailNoUserWithID(t *testing.T) {
 
 	_, err = c.RemoveUser(context.Background(), invalidID.String())
 	require.Error(t, err)
+	require.Equal(t, domainerror.KindNotFound, domainerror.KindOf(err))
 
 }
 
@@ -181,3 +207,71 @@ func TestSuccessListUsers(t *testing.T) {
 	})
 	require.NoError(t, err)
 }
+
+// / GETTING USERS
+// ///////////////
+
+func TestSuccessGetUser(t *testing.T) {
+	mockUserRepo := mock.NewUserRepoMock()
+	c := NewUserComponent(mockUserRepo)
+
+	addedUser, err := c.AddUser(context.Background(), getSuccessfulUserRequest())
+	require.NoError(t, err)
+
+	foundUser, err := c.GetUser(context.Background(), addedUser.ID)
+	require.NoError(t, err)
+	require.Equal(t, addedUser.ID, foundUser.ID)
+	require.Equal(t, addedUser.Nickname, foundUser.Nickname)
+}
+
+func TestFailGetUserWithBadID(t *testing.T) {
+	mockUserRepo := mock.NewUserRepoMock()
+	c := NewUserComponent(mockUserRepo)
+
+	_, err := c.GetUser(context.Background(), "not-a-uuid")
+	require.ErrorIs(t, err, ErrRequestedUserIDIsNotUUID)
+}
+
+func TestSuccessBatchGetUsersKeepsOrder(t *testing.T) {
+	mockUserRepo := mock.NewUserRepoMock()
+	c := NewUserComponent(mockUserRepo)
+
+	firstUser, err := c.AddUser(context.Background(), getSuccessfulUserRequest())
+	require.NoError(t, err)
+	secondUser, err := c.AddUser(context.Background(), getSuccessfulUserRequest())
+	require.NoError(t, err)
+	missingID := uuid.New().String()
+
+	results, err := c.BatchGetUsers(context.Background(), []string{secondUser.ID, missingID, firstUser.ID})
+	require.NoError(t, err)
+	require.Len(t, results, 3)
+
+	require.Equal(t, secondUser.ID, results[0].ID)
+	require.NotNil(t, results[0].User)
+	require.Equal(t, secondUser.ID, results[0].User.ID)
+
+	require.Equal(t, missingID, results[1].ID)
+	require.Nil(t, results[1].User)
+
+	require.Equal(t, firstUser.ID, results[2].ID)
+	require.NotNil(t, results[2].User)
+	require.Equal(t, firstUser.ID, results[2].User.ID)
+}
+
+func TestFailBatchGetUsers(t *testing.T) {
+	mockUserRepo := mock.NewUserRepoMock()
+	c := NewUserComponent(mockUserRepo)
+
+	_, err := c.BatchGetUsers(context.Background(), []string{})
+	require.ErrorIs(t, err, ErrRequestedUserIDsIsEmpty)
+
+	_, err = c.BatchGetUsers(context.Background(), []string{uuid.New().String(), "not-a-uuid"})
+	require.ErrorIs(t, err, ErrRequestedUserIDIsNotUUID)
+
+	tooMany := make([]string, maxBatchGetUsers+1)
+	for i := range tooMany {
+		tooMany[i] = uuid.New().String()
+	}
+	_, err = c.BatchGetUsers(context.Background(), tooMany)
+	require.ErrorIs(t, err, ErrTooManyRequestedUserIDs)
+}
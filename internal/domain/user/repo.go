@@ -11,6 +11,8 @@ type Repo interface {
 	AddUser(ctx context.Context, user model.User) (model.User, error)
 	RemoveUser(ctx context.Context, id string) (model.User, error)
 	UpdateUser(ctx context.Context, userID string, updateUser updateuser.Request) (model.User, error)
+	SuspendUser(ctx context.Context, userID string, reason string) (model.User, error)
 	GetUser(ctx context.Context, id string) (model.User, error)
+	GetUsers(ctx context.Context, ids []string) ([]model.User, error)
 	ListUsers(ctx context.Context, listRequest listusers.Request) (listusers.Response, error)
 }
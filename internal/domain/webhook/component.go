@@ -0,0 +1,112 @@
+package webhook
+
+import (
+	"context"
+	"github.com/google/uuid"
+	"github.com/rs/zerolog/log"
+	"net/url"
+	"slices"
+	"time"
+	"userservice/internal/domain/domainerror"
+	"userservice/internal/domain/model"
+	"userservice/internal/util/crypto"
+)
+
+var (
+	ErrURLIsRequired           = domainerror.InvalidField("url", "url is required")
+	ErrURLIsNotValid           = domainerror.InvalidField("url", "url has to be an absolute http or https url")
+	ErrUnknownEventType        = domainerror.InvalidField("event_types", "unknown event type")
+	ErrSubscriptionIDIsNotUUID = domainerror.InvalidField("subscription_id", "subscription id is not uuid")
+	errUnableToListDeliveries  = domainerror.Internal("unable to list webhook deliveries: internal error")
+)
+
+type component struct {
+	repo Repo
+}
+
+type Component interface {
+	Subscribe(ctx context.Context, request model.WebhookSubscription) (model.WebhookSubscription, error)
+	ListSubscriptions(ctx context.Context) ([]model.WebhookSubscription, error)
+	Unsubscribe(ctx context.Context, subscriptionID string) (model.WebhookSubscription, error)
+	ListDeliveries(ctx context.Context, subscriptionID string, limit int64, cursor string) ([]model.WebhookDelivery, string, error)
+}
+
+func NewWebhookComponent(repo Repo) Component {
+	return &component{
+		repo: repo,
+	}
+}
+
+func isValidEndpoint(endpoint string) bool {
+	parsed, err := url.Parse(endpoint)
+	if err != nil {
+		return false
+	}
+	return (parsed.Scheme == "http" || parsed.Scheme == "https") && parsed.Host != ""
+}
+
+func (c *component) Subscribe(ctx context.Context, request model.WebhookSubscription) (model.WebhookSubscription, error) {
+
+	log.Info().Msgf("WebhookComponent: subscribing %s", request.URL)
+
+	if request.URL == "" {
+		return model.WebhookSubscription{}, ErrURLIsRequired
+	}
+	if !isValidEndpoint(request.URL) {
+		return model.WebhookSubscription{}, ErrURLIsNotValid
+	}
+	var eventTypes []string
+	for _, eventType := range request.EventTypes {
+		if !slices.Contains(model.EventTypes, eventType) {
+			return model.WebhookSubscription{}, ErrUnknownEventType
+		}
+		if !slices.Contains(eventTypes, eventType) {
+			eventTypes = append(eventTypes, eventType)
+		}
+	}
+
+	secret := request.Secret
+	if secret == "" {
+		secret = crypto.GenerateWebhookSecret()
+	}
+
+	return c.repo.AddWebhookSubscription(ctx, model.WebhookSubscription{
+		ID:         uuid.NewString(),
+		URL:        request.URL,
+		EventTypes: eventTypes,
+		Secret:     secret,
+		CreatedAt:  time.Now().UTC(),
+	})
+}
+
+func (c *component) ListSubscriptions(ctx context.Context) ([]model.WebhookSubscription, error) {
+	return c.repo.ListWebhookSubscriptions(ctx)
+}
+
+func (c *component) Unsubscribe(ctx context.Context, subscriptionID string) (model.WebhookSubscription, error) {
+
+	log.Info().Msgf("WebhookComponent: unsubscribing %s", subscriptionID)
+
+	if _, err := uuid.Parse(subscriptionID); err != nil {
+		return model.WebhookSubscription{}, ErrSubscriptionIDIsNotUUID
+	}
+
+	return c.repo.RemoveWebhookSubscription(ctx, subscriptionID)
+}
+
+func (c *component) ListDeliveries(ctx context.Context, subscriptionID string, limit int64, cursor string) ([]model.WebhookDelivery, string, error) {
+
+	if _, err := uuid.Parse(subscriptionID); err != nil {
+		return nil, "", ErrSubscriptionIDIsNotUUID
+	}
+
+	deliveries, nextCursor, err := c.repo.ListWebhookDeliveries(ctx, subscriptionID, limit, cursor)
+	if err != nil {
+		if _, ok := domainerror.As(err); ok {
+			return nil, "", err
+		}
+		log.Err(err).Msgf("WebhookComponent: failed to list deliveries of %s", subscriptionID)
+		return nil, "", errUnableToListDeliveries
+	}
+	return deliveries, nextCursor, nil
+}
@@ -0,0 +1,67 @@
+package webhook
+
+import (
+	"context"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"testing"
+	"userservice/internal/domain/model"
+	"userservice/internal/mock"
+)
+
+func TestSubscribe(t *testing.T) {
+	repo := mock.NewWebhookRepoMock()
+	c := NewWebhookComponent(repo)
+
+	subscription, err := c.Subscribe(context.Background(), model.WebhookSubscription{
+		URL:        "https://partner.example.com/hooks",
+		EventTypes: []string{model.EventTypeUserAdded, model.EventTypeUserAdded, model.EventTypeUserRemoved},
+	})
+	require.NoError(t, err)
+
+	_, err = uuid.Parse(subscription.ID)
+	require.NoError(t, err)
+	require.NotEmpty(t, subscription.Secret, "a secret is generated when none is given")
+	require.Equal(t, []string{model.EventTypeUserAdded, model.EventTypeUserRemoved}, subscription.EventTypes)
+	require.Len(t, repo.Subscriptions, 1)
+
+	subscription, err = c.Subscribe(context.Background(), model.WebhookSubscription{URL: "http://localhost:9000", Secret: "mine"})
+	require.NoError(t, err)
+	require.Equal(t, "mine", subscription.Secret)
+	require.True(t, subscription.Wants(model.EventTypeUserUpdated))
+}
+
+func TestSubscribeValidation(t *testing.T) {
+	c := NewWebhookComponent(mock.NewWebhookRepoMock())
+
+	tests := []struct {
+		request model.WebhookSubscription
+		err     error
+	}{
+		{model.WebhookSubscription{}, ErrURLIsRequired},
+		{model.WebhookSubscription{URL: "partner.example.com/hooks"}, ErrURLIsNotValid},
+		{model.WebhookSubscription{URL: "ftp://partner.example.com"}, ErrURLIsNotValid},
+		{model.WebhookSubscription{URL: "https://partner.example.com", EventTypes: []string{"UserDeleted"}}, ErrUnknownEventType},
+	}
+	for _, test := range tests {
+		_, err := c.Subscribe(context.Background(), test.request)
+		require.ErrorIs(t, err, test.err, test.request.URL)
+	}
+}
+
+func TestUnsubscribe(t *testing.T) {
+	repo := mock.NewWebhookRepoMock()
+	c := NewWebhookComponent(repo)
+
+	_, err := c.Unsubscribe(context.Background(), "not a uuid")
+	require.ErrorIs(t, err, ErrSubscriptionIDIsNotUUID)
+
+	subscription, err := c.Subscribe(context.Background(), model.WebhookSubscription{URL: "https://partner.example.com"})
+	require.NoError(t, err)
+	_, err = c.Unsubscribe(context.Background(), subscription.ID)
+	require.NoError(t, err)
+	require.Empty(t, repo.Subscriptions)
+
+	_, err = c.Unsubscribe(context.Background(), subscription.ID)
+	require.Error(t, err)
+}
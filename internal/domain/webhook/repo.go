@@ -0,0 +1,13 @@
+package webhook
+
+import (
+	"context"
+	"userservice/internal/domain/model"
+)
+
+type Repo interface {
+	AddWebhookSubscription(ctx context.Context, subscription model.WebhookSubscription) (model.WebhookSubscription, error)
+	ListWebhookSubscriptions(ctx context.Context) ([]model.WebhookSubscription, error)
+	RemoveWebhookSubscription(ctx context.Context, id string) (model.WebhookSubscription, error)
+	ListWebhookDeliveries(ctx context.Context, subscriptionID string, limit int64, cursor string) ([]model.WebhookDelivery, string, error)
+}
@@ -0,0 +1,191 @@
+package cloudevents
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+	"userservice/internal/config"
+	"userservice/internal/infrastructure/messaging"
+	"userservice/internal/infrastructure/serialization"
+)
+
+const SpecVersion = "1.0"
+
+type Mode string
+
+const (
+	ModeBinary     Mode = "binary"
+	ModeStructured Mode = "structured"
+)
+
+// kafka protocol binding, https://github.com/cloudevents/spec/blob/v1.0.2/cloudevents/bindings/kafka-protocol-binding.md
+const (
+	HeaderPrefix          = "ce_"
+	HeaderContentType     = "content-type"
+	StructuredContentType = "application/cloudevents+json; charset=UTF-8"
+)
+
+var contentTypes = map[serialization.Format]string{
+	serialization.FormatProtobuf:  "application/protobuf",
+	serialization.FormatProtoJSON: "application/json",
+	// the schema id in front of the protobuf message is not part of any registered media type
+	serialization.FormatConfluentProtobuf: "application/vnd.confluent.protobuf",
+}
+
+var errSourceIsRequired = errors.New("cloud events require a source")
+var errNoData = errors.New("structured cloud event has no data")
+
+// Event holds the attributes we set, the data is the value of the kafka message
+type Event struct {
+	ID      string
+	Type    string
+	Subject string
+	Time    time.Time
+}
+
+// structuredEvent is the JSON event format, https://github.com/cloudevents/spec/blob/v1.0.2/cloudevents/formats/json-format.md
+type structuredEvent struct {
+	SpecVersion     string          `json:"specversion"`
+	ID              string          `json:"id"`
+	Source          string          `json:"source"`
+	Type            string          `json:"type"`
+	Subject         string          `json:"subject,omitempty"`
+	Time            string          `json:"time,omitempty"`
+	DataContentType string          `json:"datacontenttype,omitempty"`
+	Data            json.RawMessage `json:"data,omitempty"`
+	DataBase64      []byte          `json:"data_base64,omitempty"`
+}
+
+// Envelope wraps the messages of the topics configured for it, a nil Envelope leaves all messages as they are
+type Envelope struct {
+	source     string
+	typePrefix string
+	topics     map[string]Mode
+	serializer serialization.Serializer
+}
+
+func NewEnvelope(cloudEventsConfig config.CloudEventsConfig, serializer serialization.Serializer) (*Envelope, error) {
+	topics := map[string]Mode{}
+	for topic, rawMode := range cloudEventsConfig.Topics {
+		mode := Mode(rawMode)
+		if mode != ModeBinary && mode != ModeStructured {
+			return nil, fmt.Errorf("unknown cloud events mode %s for topic %s", rawMode, topic)
+		}
+		topics[topic] = mode
+	}
+	if len(topics) == 0 {
+		return nil, nil
+	}
+
+	if cloudEventsConfig.Source == "" {
+		return nil, errSourceIsRequired
+	}
+	_, err := url.Parse(cloudEventsConfig.Source)
+	if err != nil {
+		return nil, fmt.Errorf("cloud events source is not a URI reference: %w", err)
+	}
+	return &Envelope{
+		source:     cloudEventsConfig.Source,
+		typePrefix: cloudEventsConfig.TypePrefix,
+		topics:     topics,
+		serializer: serializer,
+	}, nil
+}
+
+func (e *Envelope) eventType(kind string) string {
+	if e.typePrefix == "" {
+		return kind
+	}
+	return e.typePrefix + "." + kind
+}
+
+// Wrap turns msg into a CloudEvent when its topic is configured for it. kind is the event kind, like UserAdded
+func (e *Envelope) Wrap(msg *messaging.KafkaInternalMessage, kind string, subject string, occurredAt time.Time) error {
+	if e == nil {
+		return nil
+	}
+	mode, ok := e.topics[msg.TopicID]
+	if !ok {
+		return nil
+	}
+
+	event := Event{ID: msg.ID, Type: e.eventType(kind), Subject: subject, Time: occurredAt}
+	contentType := contentTypes[e.serializer.FormatOf(msg.TopicID)]
+	if mode == ModeBinary {
+		e.wrapBinary(msg, event, contentType)
+		return nil
+	}
+	return e.wrapStructured(msg, event, contentType)
+}
+
+func (e *Envelope) wrapBinary(msg *messaging.KafkaInternalMessage, event Event, contentType string) {
+	attributes := []messaging.Header{
+		{Key: HeaderPrefix + "specversion", Value: SpecVersion},
+		{Key: HeaderPrefix + "id", Value: event.ID},
+		{Key: HeaderPrefix + "source", Value: e.source},
+		{Key: HeaderPrefix + "type", Value: event.Type},
+	}
+	if event.Subject != "" {
+		attributes = append(attributes, messaging.Header{Key: HeaderPrefix + "subject", Value: event.Subject})
+	}
+	if !event.Time.IsZero() {
+		attributes = append(attributes, messaging.Header{Key: HeaderPrefix + "time", Value: event.Time.UTC().Format(time.RFC3339Nano)})
+	}
+	// datacontenttype has no ce_ header of its own in the kafka binding
+	if contentType != "" {
+		attributes = append(attributes, messaging.Header{Key: HeaderContentType, Value: contentType})
+	}
+	msg.Headers = append(msg.Headers, attributes...)
+}
+
+func (e *Envelope) wrapStructured(msg *messaging.KafkaInternalMessage, event Event, contentType string) error {
+	structured := structuredEvent{
+		SpecVersion:     SpecVersion,
+		ID:              event.ID,
+		Source:          e.source,
+		Type:            event.Type,
+		Subject:         event.Subject,
+		DataContentType: contentType,
+	}
+	if !event.Time.IsZero() {
+		structured.Time = event.Time.UTC().Format(time.RFC3339Nano)
+	}
+	// JSON data is embedded as it is, everything else is base64 encoded
+	if contentType == contentTypes[serialization.FormatProtoJSON] && json.Valid(msg.Value) {
+		structured.Data = msg.Value
+	} else {
+		structured.DataBase64 = msg.Value
+	}
+
+	value, err := json.Marshal(structured)
+	if err != nil {
+		return err
+	}
+	msg.Value = value
+	msg.Headers = append(msg.Headers, messaging.Header{Key: HeaderContentType, Value: StructuredContentType})
+	return nil
+}
+
+// Unwrap returns the event in the value of a message, which is the value itself unless it is a structured cloud event
+func Unwrap(value []byte, headers []messaging.Header) ([]byte, error) {
+	contentType, _ := messaging.HeaderValue(headers, HeaderContentType)
+	if !strings.HasPrefix(contentType, "application/cloudevents+json") {
+		return value, nil
+	}
+
+	var structured structuredEvent
+	err := json.Unmarshal(value, &structured)
+	if err != nil {
+		return nil, err
+	}
+	if structured.Data != nil {
+		return structured.Data, nil
+	}
+	if structured.DataBase64 != nil {
+		return structured.DataBase64, nil
+	}
+	return nil, errNoData
+}
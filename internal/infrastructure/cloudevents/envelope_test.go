@@ -0,0 +1,264 @@
+package cloudevents
+
+import (
+	"encoding/json"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+	"userservice/internal/config"
+	"userservice/internal/infrastructure/messaging"
+	"userservice/internal/infrastructure/serialization"
+	"userservice/proto/kafkaschema"
+)
+
+const (
+	binaryTopic     = "userservice.user.added"
+	structuredTopic = "userservice.user.updated"
+	plainTopic      = "userservice.user.removed"
+)
+
+var occurredAt = time.Date(2024, 5, 1, 12, 30, 0, 0, time.UTC)
+
+// attributeName is the naming rule for context attributes of the spec
+var attributeName = regexp.MustCompile(`^[a-z0-9]+$`)
+
+// decodedEvent is a CloudEvent read back from a kafka message the way a consumer following the kafka protocol binding
+// would
+type decodedEvent struct {
+	attributes map[string]string
+	data       []byte
+}
+
+// decodeBinary reads an event in binary mode and checks the rules of the kafka protocol binding along the way
+func decodeBinary(t *testing.T, msg messaging.KafkaInternalMessage) decodedEvent {
+	event := decodedEvent{attributes: map[string]string{}, data: msg.Value}
+	for _, header := range msg.Headers {
+		if header.Key == HeaderContentType {
+			event.attributes["datacontenttype"] = header.Value
+			continue
+		}
+		if !strings.HasPrefix(header.Key, HeaderPrefix) {
+			continue
+		}
+		name := strings.TrimPrefix(header.Key, HeaderPrefix)
+		require.Regexp(t, attributeName, name, "attribute header %s", header.Key)
+		_, duplicate := event.attributes[name]
+		require.False(t, duplicate, "attribute %s set twice", name)
+		event.attributes[name] = header.Value
+	}
+	requireValidAttributes(t, event.attributes)
+	return event
+}
+
+// decodeStructured reads an event in structured mode and checks the rules of the JSON event format along the way
+func decodeStructured(t *testing.T, msg messaging.KafkaInternalMessage) decodedEvent {
+	contentType, ok := headerValue(msg, HeaderContentType)
+	require.True(t, ok)
+	require.True(t, strings.HasPrefix(contentType, "application/cloudevents+json"))
+
+	var raw map[string]json.RawMessage
+	require.NoError(t, json.Unmarshal(msg.Value, &raw))
+
+	event := decodedEvent{attributes: map[string]string{}}
+	data, hasData := raw["data"]
+	dataBase64, hasDataBase64 := raw["data_base64"]
+	require.False(t, hasData && hasDataBase64, "data and data_base64 are mutually exclusive")
+	if hasData {
+		event.data = data
+	}
+	if hasDataBase64 {
+		require.NoError(t, json.Unmarshal(dataBase64, &event.data))
+	}
+
+	for name, value := range raw {
+		if name == "data" || name == "data_base64" {
+			continue
+		}
+		require.Regexp(t, attributeName, name)
+		var attribute string
+		require.NoError(t, json.Unmarshal(value, &attribute), "attribute %s is not a string", name)
+		event.attributes[name] = attribute
+	}
+	requireValidAttributes(t, event.attributes)
+	return event
+}
+
+func requireValidAttributes(t *testing.T, attributes map[string]string) {
+	require.Equal(t, SpecVersion, attributes["specversion"])
+	for _, required := range []string{"id", "source", "type"} {
+		require.NotEmpty(t, attributes[required], "required attribute %s", required)
+	}
+	if eventTime, ok := attributes["time"]; ok {
+		_, err := time.Parse(time.RFC3339, eventTime)
+		require.NoError(t, err)
+	}
+}
+
+func headerValue(msg messaging.KafkaInternalMessage, key string) (string, bool) {
+	for _, header := range msg.Headers {
+		if header.Key == key {
+			return header.Value, true
+		}
+	}
+	return "", false
+}
+
+func newTestEnvelope(t *testing.T, format serialization.Format) (*Envelope, serialization.Serializer) {
+	serializer, err := serialization.NewSerializer(config.SerializationConfig{DefaultFormat: string(format)}, nil)
+	require.NoError(t, err)
+	envelope, err := NewEnvelope(config.CloudEventsConfig{
+		Source:     "/userservice",
+		TypePrefix: "com.example.userservice",
+		Topics: map[string]string{
+			binaryTopic:     string(ModeBinary),
+			structuredTopic: string(ModeStructured),
+		},
+	}, serializer)
+	require.NoError(t, err)
+	require.NotNil(t, envelope)
+	return envelope, serializer
+}
+
+func newTestMessage(t *testing.T, serializer serialization.Serializer, topic string) (*messaging.KafkaInternalMessage, proto.Message) {
+	event := &kafkaschema.UserAddedMessage{Id: "user-id", FirstName: "John"}
+	value, err := serializer.Serialize(topic, event)
+	require.NoError(t, err)
+	msg := messaging.NewInternalMessage(topic, []byte("user-id"), value)
+	msg.Headers = []messaging.Header{{Key: messaging.HeaderEventType, Value: "UserAdded"}}
+	return msg, event
+}
+
+func TestBinaryModeSetsAttributeHeaders(t *testing.T) {
+	envelope, serializer := newTestEnvelope(t, serialization.FormatProtobuf)
+	msg, event := newTestMessage(t, serializer, binaryTopic)
+	value := msg.Value
+
+	require.NoError(t, envelope.Wrap(msg, "UserAdded", "user-id", occurredAt))
+
+	decoded := decodeBinary(t, *msg)
+	require.Equal(t, msg.ID, decoded.attributes["id"])
+	require.Equal(t, "/userservice", decoded.attributes["source"])
+	require.Equal(t, "com.example.userservice.UserAdded", decoded.attributes["type"])
+	require.Equal(t, "user-id", decoded.attributes["subject"])
+	require.Equal(t, "application/protobuf", decoded.attributes["datacontenttype"])
+	eventTime, err := time.Parse(time.RFC3339, decoded.attributes["time"])
+	require.NoError(t, err)
+	require.True(t, occurredAt.Equal(eventTime))
+
+	// the value stays the event, the existing headers are kept
+	require.Equal(t, value, decoded.data)
+	var roundTripped kafkaschema.UserAddedMessage
+	require.NoError(t, proto.Unmarshal(decoded.data, &roundTripped))
+	require.True(t, proto.Equal(event, &roundTripped))
+	eventType, ok := headerValue(*msg, messaging.HeaderEventType)
+	require.True(t, ok)
+	require.Equal(t, "UserAdded", eventType)
+}
+
+func TestStructuredModeEmbedsJSONData(t *testing.T) {
+	envelope, serializer := newTestEnvelope(t, serialization.FormatProtoJSON)
+	msg, event := newTestMessage(t, serializer, structuredTopic)
+
+	require.NoError(t, envelope.Wrap(msg, "UserUpdated", "user-id", occurredAt))
+
+	decoded := decodeStructured(t, *msg)
+	require.Equal(t, msg.ID, decoded.attributes["id"])
+	require.Equal(t, "com.example.userservice.UserUpdated", decoded.attributes["type"])
+	require.Equal(t, "application/json", decoded.attributes["datacontenttype"])
+
+	// JSON data is a JSON value in the event rather than a string
+	require.True(t, json.Valid(decoded.data))
+	var roundTripped kafkaschema.UserAddedMessage
+	require.NoError(t, protojson.Unmarshal(decoded.data, &roundTripped))
+	require.True(t, proto.Equal(event, &roundTripped))
+}
+
+func TestStructuredModeBase64EncodesBinaryData(t *testing.T) {
+	envelope, serializer := newTestEnvelope(t, serialization.FormatProtobuf)
+	msg, event := newTestMessage(t, serializer, structuredTopic)
+	value := msg.Value
+
+	require.NoError(t, envelope.Wrap(msg, "UserUpdated", "user-id", occurredAt))
+
+	decoded := decodeStructured(t, *msg)
+	require.Equal(t, "application/protobuf", decoded.attributes["datacontenttype"])
+	require.Equal(t, value, decoded.data)
+	var roundTripped kafkaschema.UserAddedMessage
+	require.NoError(t, proto.Unmarshal(decoded.data, &roundTripped))
+	require.True(t, proto.Equal(event, &roundTripped))
+}
+
+func TestUnconfiguredTopicsAreLeftAlone(t *testing.T) {
+	envelope, serializer := newTestEnvelope(t, serialization.FormatProtobuf)
+	msg, _ := newTestMessage(t, serializer, plainTopic)
+	original := *msg
+	original.Headers = append([]messaging.Header(nil), msg.Headers...)
+
+	require.NoError(t, envelope.Wrap(msg, "UserRemoved", "user-id", occurredAt))
+	require.Equal(t, original, *msg)
+
+	// no topics configured gives no envelope at all
+	noEnvelope, err := NewEnvelope(config.CloudEventsConfig{Source: "/userservice"}, serializer)
+	require.NoError(t, err)
+	require.Nil(t, noEnvelope)
+	require.NoError(t, noEnvelope.Wrap(msg, "UserRemoved", "user-id", occurredAt))
+	require.Equal(t, original, *msg)
+}
+
+func TestTypeIsKindWithoutPrefix(t *testing.T) {
+	serializer, err := serialization.NewSerializer(config.SerializationConfig{}, nil)
+	require.NoError(t, err)
+	envelope, err := NewEnvelope(config.CloudEventsConfig{
+		Source: "urn:example:userservice",
+		Topics: map[string]string{binaryTopic: string(ModeBinary)},
+	}, serializer)
+	require.NoError(t, err)
+	msg, _ := newTestMessage(t, serializer, binaryTopic)
+
+	require.NoError(t, envelope.Wrap(msg, "UserAdded", "", time.Time{}))
+
+	decoded := decodeBinary(t, *msg)
+	require.Equal(t, "UserAdded", decoded.attributes["type"])
+	require.NotContains(t, decoded.attributes, "subject")
+	require.NotContains(t, decoded.attributes, "time")
+}
+
+func TestInvalidConfigIsRejected(t *testing.T) {
+	serializer, err := serialization.NewSerializer(config.SerializationConfig{}, nil)
+	require.NoError(t, err)
+
+	testCases := []struct {
+		name   string
+		config config.CloudEventsConfig
+	}{
+		{"unknown mode", config.CloudEventsConfig{Source: "/userservice", Topics: map[string]string{binaryTopic: "batched"}}},
+		{"missing source", config.CloudEventsConfig{Topics: map[string]string{binaryTopic: string(ModeBinary)}}},
+		{"source is no uri", config.CloudEventsConfig{Source: "http://[::1", Topics: map[string]string{binaryTopic: string(ModeBinary)}}},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			_, err := NewEnvelope(testCase.config, serializer)
+			require.Error(t, err)
+		})
+	}
+}
+
+func TestUnwrapReturnsTheEvent(t *testing.T) {
+	for _, format := range []serialization.Format{serialization.FormatProtobuf, serialization.FormatProtoJSON} {
+		envelope, serializer := newTestEnvelope(t, format)
+		for _, topic := range []string{binaryTopic, structuredTopic, plainTopic} {
+			msg, event := newTestMessage(t, serializer, topic)
+			require.NoError(t, envelope.Wrap(msg, "UserAdded", "user-id", occurredAt))
+
+			data, err := Unwrap(msg.Value, msg.Headers)
+			require.NoError(t, err)
+			var unwrapped kafkaschema.UserAddedMessage
+			require.NoError(t, serializer.Deserialize(topic, data, &unwrapped))
+			require.True(t, proto.Equal(event, &unwrapped), "%s in %s", topic, format)
+		}
+	}
+}
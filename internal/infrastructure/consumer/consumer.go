@@ -0,0 +1,264 @@
+package consumer
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/rs/zerolog/log"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"sync"
+	"time"
+	"userservice/internal/config"
+	"userservice/internal/domain/domainerror"
+	"userservice/internal/infrastructure/messaging"
+	"userservice/internal/infrastructure/outbox"
+	"userservice/internal/infrastructure/serialization"
+	"userservice/internal/util/tracing"
+	"userservice/proto/kafkaschema"
+)
+
+const (
+	defaultMaxAttempts        = 5
+	defaultRetryBackoffMillis = 500
+	// maxBackoff caps the wait between two attempts at a message that can neither be run nor dead lettered
+	maxBackoff  = 30 * time.Second
+	pollTimeout = 500 * time.Millisecond
+	// deadLetterTimeout is how long we wait for the dead letter topic to confirm a message
+	deadLetterTimeout = 30 * time.Second
+)
+
+var (
+	errNoHandler                = errors.New("no handler registered for topic")
+	errCommandIDIsRequired      = errors.New("command id is required")
+	errDeadLetterTopicIsMissing = errors.New("no dead letter topic configured")
+	errDeadLetterTimedOut       = errors.New("timed out waiting for the dead letter topic")
+)
+
+type Consumer interface {
+	CleanUp()
+	Run()
+}
+
+type consumer struct {
+	source          Source
+	registry        *Registry
+	repo            ProcessedCommandRepo
+	deadLetters     outbox.Publisher
+	serializer      serialization.Serializer
+	config          config.CommandsConfig
+	shutdownLock    sync.Mutex
+	shutdownChannel chan struct{}
+	hasBeenShutDown bool
+}
+
+// NewConsumer runs the commands of registry that are read from source. Offsets are committed once a command ran, was
+// already run before or was put on the dead letter topic, so nothing is lost when the service stops in between
+func NewConsumer(source Source, registry *Registry, repo ProcessedCommandRepo, deadLetters outbox.Publisher, serializer serialization.Serializer, config config.CommandsConfig) Consumer {
+	if config.MaxAttempts <= 0 {
+		config.MaxAttempts = defaultMaxAttempts
+	}
+	if config.RetryBackoffMillis <= 0 {
+		config.RetryBackoffMillis = defaultRetryBackoffMillis
+	}
+	return &consumer{
+		source:          source,
+		registry:        registry,
+		repo:            repo,
+		deadLetters:     deadLetters,
+		serializer:      serializer,
+		config:          config,
+		shutdownChannel: make(chan struct{}),
+	}
+}
+
+func (c *consumer) CleanUp() {
+	c.shutdownLock.Lock()
+	defer c.shutdownLock.Unlock()
+	if c.hasBeenShutDown {
+		return
+	}
+	close(c.shutdownChannel)
+	c.hasBeenShutDown = true
+}
+
+func (c *consumer) Run() {
+	ctx := context.Background()
+	topics := c.registry.Topics()
+	err := c.source.Subscribe(topics)
+	if err != nil {
+		log.Error().Err(err).Msgf("Commands: failed to subscribe to %v", topics)
+		return
+	}
+	defer func() {
+		err := c.source.Close()
+		if err != nil {
+			log.Warn().Err(err).Msg("Commands: failed to close consumer")
+		}
+	}()
+
+	log.Info().Msgf("Commands: consuming %v", topics)
+	readErrors := 0
+	for {
+		select {
+		case <-c.shutdownChannel:
+			log.Info().Msg("Commands: stopped consumer")
+			return
+		default:
+		}
+
+		msg, err := c.source.ReadMessage(pollTimeout)
+		if err != nil {
+			// backing off, so a broker that stays down is not polled in a busy loop
+			readErrors++
+			log.Warn().Err(err).Msg("Commands: failed to read message")
+			if !c.wait(c.backoff(readErrors)) {
+				log.Info().Msg("Commands: stopped consumer")
+				return
+			}
+			continue
+		}
+		readErrors = 0
+		if msg == nil {
+			continue
+		}
+		c.process(ctx, *msg)
+	}
+}
+
+// process handles msg until it is done with it, only then the offset is committed. Messages that can not even be
+// dead lettered block their partition, as skipping them would lose the command
+func (c *consumer) process(ctx context.Context, msg messaging.InboundMessage) {
+	for attempt := 1; ; attempt++ {
+		err := c.handle(ctx, msg)
+		if err == nil {
+			break
+		}
+		log.Error().Err(err).Msgf("Commands: failed to handle message %s/%d/%d", msg.Topic, msg.Partition, msg.Offset)
+		if !c.wait(c.backoff(attempt)) {
+			// not committed, so the message is read again after a restart
+			return
+		}
+	}
+
+	err := c.source.CommitMessage(msg)
+	if err != nil {
+		// reading the message again is harmless, the command is recognised by its id
+		log.Warn().Err(err).Msgf("Commands: failed to commit message %s/%d/%d", msg.Topic, msg.Partition, msg.Offset)
+	}
+}
+
+// handle returns an error when the message has to be handled again
+func (c *consumer) handle(ctx context.Context, msg messaging.InboundMessage) error {
+	handler, ok := c.registry.Handler(msg.Topic)
+	if !ok {
+		return c.deadLetter(ctx, msg, "", errNoHandler, 0)
+	}
+	command, err := handler(msg.Value)
+	if err == nil && command.ID == "" {
+		err = errCommandIDIsRequired
+	}
+	if err != nil {
+		return c.deadLetter(ctx, msg, command.ID, fmt.Errorf("failed to decode command: %w", err), 0)
+	}
+
+	processed, err := c.repo.IsCommandProcessed(ctx, command.ID)
+	if err != nil {
+		return err
+	}
+	if processed {
+		log.Info().Msgf("Commands: skipping command %s, it was already processed", command.ID)
+		return nil
+	}
+
+	// the events of the command are correlated to it
+	ctx = tracing.NewContext(ctx, tracing.ForRequest(command.ID, ""))
+	ctx = NewProcessingContext(ctx, Processing{CommandID: command.ID, Topic: msg.Topic})
+	attempts, err := c.execute(ctx, command)
+	if errors.Is(err, ErrCommandAlreadyProcessed) {
+		log.Info().Msgf("Commands: skipping command %s, it was processed meanwhile", command.ID)
+		return nil
+	}
+	if err != nil {
+		return c.deadLetter(ctx, msg, command.ID, err, attempts)
+	}
+	log.Info().Msgf("Commands: processed command %s from %s", command.ID, msg.Topic)
+	// usually marked already with the change of the command, this marks the commands that changed nothing
+	return c.repo.MarkCommandProcessed(ctx, command.ID, msg.Topic)
+}
+
+// execute runs the command until it succeeds, fails permanently or runs out of attempts
+func (c *consumer) execute(ctx context.Context, command Command) (int, error) {
+	var err error
+	for attempt := 1; attempt <= c.config.MaxAttempts; attempt++ {
+		err = command.Execute(ctx)
+		if err == nil || isPermanent(err) || errors.Is(err, ErrCommandAlreadyProcessed) {
+			return attempt, err
+		}
+		log.Warn().Err(err).Msgf("Commands: attempt %d at command %s failed", attempt, command.ID)
+		if attempt < c.config.MaxAttempts && !c.wait(c.backoff(attempt)) {
+			return attempt, err
+		}
+	}
+	return c.config.MaxAttempts, err
+}
+
+// isPermanent errors are caused by the command itself, running it again gives the same result
+func isPermanent(err error) bool {
+	switch domainerror.KindOf(err) {
+	case domainerror.KindInvalidArgument, domainerror.KindNotFound, domainerror.KindFailedPrecondition, domainerror.KindAlreadyExists:
+		return true
+	}
+	return false
+}
+
+func (c *consumer) deadLetter(ctx context.Context, msg messaging.InboundMessage, commandID string, cause error, attempts int) error {
+	if c.config.DeadLetterTopicName == "" {
+		return errDeadLetterTopicIsMissing
+	}
+	log.Warn().Err(cause).Msgf("Commands: dead lettering message %s/%d/%d", msg.Topic, msg.Partition, msg.Offset)
+
+	deadLetter := &kafkaschema.DeadLetteredCommand{
+		Topic:     msg.Topic,
+		Partition: msg.Partition,
+		Offset:    msg.Offset,
+		Key:       msg.Key,
+		Value:     msg.Value,
+		CommandId: commandID,
+		Error:     cause.Error(),
+		Attempts:  int32(attempts),
+		FailedAt:  timestamppb.Now(),
+	}
+	value, err := c.serializer.Serialize(c.config.DeadLetterTopicName, deadLetter)
+	if err != nil {
+		return err
+	}
+	kafkaMessage := messaging.NewInternalMessage(c.config.DeadLetterTopicName, msg.Key, value)
+	kafkaMessage.Headers = messaging.NewEventMetadata(ctx, kafkaMessage.ID, deadLetter).Headers()
+
+	report, err := c.deadLetters.Publish(*kafkaMessage)
+	if err != nil {
+		return err
+	}
+	select {
+	case err = <-report:
+		return err
+	case <-time.After(deadLetterTimeout):
+		return errDeadLetterTimedOut
+	}
+}
+
+func (c *consumer) backoff(attempt int) time.Duration {
+	return min(time.Duration(c.config.RetryBackoffMillis)*time.Millisecond*time.Duration(attempt), maxBackoff)
+}
+
+// wait returns false when the consumer was shut down while waiting
+func (c *consumer) wait(duration time.Duration) bool {
+	timer := time.NewTimer(duration)
+	defer timer.Stop()
+	select {
+	case <-c.shutdownChannel:
+		return false
+	case <-timer.C:
+		return true
+	}
+}
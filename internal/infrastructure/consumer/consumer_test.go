@@ -0,0 +1,293 @@
+package consumer
+
+import (
+	"context"
+	"errors"
+	"github.com/stretchr/testify/require"
+	"sync"
+	"testing"
+	"time"
+	"userservice/internal/config"
+	"userservice/internal/domain/domainerror"
+	"userservice/internal/infrastructure/messaging"
+	"userservice/internal/infrastructure/publisher"
+	"userservice/internal/infrastructure/serialization"
+	"userservice/internal/mock"
+	"userservice/proto/kafkaschema"
+)
+
+const (
+	testTopic       = "commands"
+	deadLetterTopic = "commands.dead_letter"
+)
+
+var errTemporary = errors.New("database is down")
+
+// testSource hands out messages once and remembers the committed ones
+type testSource struct {
+	lock      sync.Mutex
+	messages  []messaging.InboundMessage
+	committed []messaging.InboundMessage
+	closed    bool
+	readErr   error
+	reads     int
+}
+
+func (s *testSource) Subscribe(topics []string) error {
+	return nil
+}
+
+func (s *testSource) ReadMessage(timeout time.Duration) (*messaging.InboundMessage, error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.reads++
+	if s.readErr != nil {
+		return nil, s.readErr
+	}
+	if len(s.messages) == 0 {
+		return nil, nil
+	}
+	msg := s.messages[0]
+	s.messages = s.messages[1:]
+	return &msg, nil
+}
+
+func (s *testSource) CommitMessage(msg messaging.InboundMessage) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.committed = append(s.committed, msg)
+	return nil
+}
+
+func (s *testSource) Close() error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.closed = true
+	return nil
+}
+
+func (s *testSource) committedOffsets() []int64 {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	var offsets []int64
+	for _, msg := range s.committed {
+		offsets = append(offsets, msg.Offset)
+	}
+	return offsets
+}
+
+// testCommands runs the command with the id in the message value, failing with the errors queued for it
+type testCommands struct {
+	lock     sync.Mutex
+	failures map[string][]error
+	executed map[string]int
+	// processing is what the repo would mark the command with
+	processing map[string]Processing
+}
+
+func (t *testCommands) handler(value []byte) (Command, error) {
+	id := string(value)
+	if id == "undecodable" {
+		return Command{}, errors.New("bad command")
+	}
+	return Command{ID: id, Execute: func(ctx context.Context) error {
+		t.lock.Lock()
+		defer t.lock.Unlock()
+		t.executed[id]++
+		t.processing[id], _ = ProcessingFromContext(ctx)
+		if failures := t.failures[id]; len(failures) > 0 {
+			t.failures[id] = failures[1:]
+			return failures[0]
+		}
+		return nil
+	}}, nil
+}
+
+func (t *testCommands) executions(id string) int {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	return t.executed[id]
+}
+
+func setUp(t *testing.T, processedIDs ...string) (*consumer, *testSource, *testCommands, *mock.ProcessedCommandRepoMock, *publisher.MemoryPublisher) {
+	serializer, err := serialization.NewSerializer(config.SerializationConfig{DefaultFormat: "protobuf"}, nil)
+	require.NoError(t, err)
+
+	commands := &testCommands{failures: map[string][]error{}, executed: map[string]int{}, processing: map[string]Processing{}}
+	registry := NewRegistry()
+	registry.Register(testTopic, commands.handler)
+	source := &testSource{}
+	repo := mock.NewProcessedCommandRepoMock(processedIDs...)
+	deadLetters := publisher.NewMemoryPublisher()
+
+	c := NewConsumer(source, registry, repo, deadLetters, serializer, config.CommandsConfig{
+		DeadLetterTopicName: deadLetterTopic,
+		MaxAttempts:         3,
+		RetryBackoffMillis:  1,
+	}).(*consumer)
+	return c, source, commands, repo, deadLetters
+}
+
+func message(offset int64, value string) messaging.InboundMessage {
+	return messaging.InboundMessage{Topic: testTopic, Offset: offset, Key: []byte("key"), Value: []byte(value)}
+}
+
+func deadLettered(t *testing.T, deadLetters *publisher.MemoryPublisher) []*kafkaschema.DeadLetteredCommand {
+	serializer, err := serialization.NewSerializer(config.SerializationConfig{DefaultFormat: "protobuf"}, nil)
+	require.NoError(t, err)
+	var result []*kafkaschema.DeadLetteredCommand
+	for _, msg := range deadLetters.Published() {
+		require.Equal(t, deadLetterTopic, msg.TopicID)
+		var parsed kafkaschema.DeadLetteredCommand
+		require.NoError(t, serializer.Deserialize(msg.TopicID, msg.Value, &parsed))
+		result = append(result, &parsed)
+	}
+	return result
+}
+
+func TestConsumerRunsCommandsOnce(t *testing.T) {
+	c, source, commands, repo, deadLetters := setUp(t, "old")
+	ctx := context.Background()
+
+	c.process(ctx, message(1, "first"))
+	c.process(ctx, message(2, "first"))
+	c.process(ctx, message(3, "old"))
+
+	require.Equal(t, 1, commands.executions("first"))
+	require.Equal(t, 0, commands.executions("old"))
+	require.Equal(t, testTopic, repo.Processed["first"])
+	require.Equal(t, []int64{1, 2, 3}, source.committedOffsets())
+	require.Empty(t, deadLetters.Published())
+}
+
+func TestConsumerSkipsCommandsProcessedMeanwhile(t *testing.T) {
+	c, source, commands, repo, deadLetters := setUp(t)
+	commands.failures["raced"] = []error{ErrCommandAlreadyProcessed}
+
+	c.process(context.Background(), message(1, "raced"))
+
+	require.Equal(t, 1, commands.executions("raced"))
+	require.Equal(t, Processing{CommandID: "raced", Topic: testTopic}, commands.processing["raced"])
+	// the other run marked it with its change
+	require.NotContains(t, repo.Processed, "raced")
+	require.Equal(t, []int64{1}, source.committedOffsets())
+	require.Empty(t, deadLetters.Published())
+}
+
+func TestConsumerRetriesTemporaryFailures(t *testing.T) {
+	c, source, commands, repo, deadLetters := setUp(t)
+	commands.failures["flaky"] = []error{errTemporary, errTemporary}
+
+	c.process(context.Background(), message(1, "flaky"))
+
+	require.Equal(t, 3, commands.executions("flaky"))
+	require.Contains(t, repo.Processed, "flaky")
+	require.Equal(t, []int64{1}, source.committedOffsets())
+	require.Empty(t, deadLetters.Published())
+}
+
+func TestConsumerDeadLettersFailedCommands(t *testing.T) {
+	c, source, commands, repo, deadLetters := setUp(t)
+	commands.failures["broken"] = []error{errTemporary, errTemporary, errTemporary}
+	commands.failures["invalid"] = []error{domainerror.InvalidField("user_id", "not a uuid")}
+	ctx := context.Background()
+
+	c.process(ctx, message(1, "broken"))
+	c.process(ctx, message(2, "invalid"))
+	c.process(ctx, message(3, "undecodable"))
+
+	// permanent failures are not retried
+	require.Equal(t, 3, commands.executions("broken"))
+	require.Equal(t, 1, commands.executions("invalid"))
+	require.Empty(t, repo.Processed)
+	require.Equal(t, []int64{1, 2, 3}, source.committedOffsets())
+
+	dead := deadLettered(t, deadLetters)
+	require.Len(t, dead, 3)
+	require.Equal(t, "broken", dead[0].CommandId)
+	require.Equal(t, int32(3), dead[0].Attempts)
+	require.Equal(t, errTemporary.Error(), dead[0].Error)
+	require.Equal(t, []byte("broken"), dead[0].Value)
+	require.Equal(t, testTopic, dead[0].Topic)
+	require.Equal(t, int64(1), dead[0].Offset)
+	require.Equal(t, int32(1), dead[1].Attempts)
+	require.Equal(t, int32(0), dead[2].Attempts)
+	require.Contains(t, dead[2].Error, "failed to decode command")
+	require.Equal(t, []byte("key"), deadLetters.Published()[0].Key)
+}
+
+func TestConsumerDoesNotCommitUntilDeadLettered(t *testing.T) {
+	c, source, commands, _, deadLetters := setUp(t)
+	commands.failures["broken"] = []error{domainerror.NotFound("user not found")}
+	deadLetters.FailDelivery(errTemporary)
+	for range 100 {
+		commands.failures["broken"] = append(commands.failures["broken"], domainerror.NotFound("user not found"))
+	}
+
+	done := make(chan struct{})
+	go func() {
+		c.process(context.Background(), message(1, "broken"))
+		close(done)
+	}()
+
+	require.Never(t, func() bool { return len(source.committedOffsets()) > 0 }, 50*time.Millisecond, 5*time.Millisecond)
+	deadLetters.FailDelivery(nil)
+	require.Eventually(t, func() bool { return len(source.committedOffsets()) == 1 }, time.Second, 5*time.Millisecond)
+	<-done
+	require.Len(t, deadLettered(t, deadLetters), 1)
+}
+
+func TestConsumerStopsWithoutCommitting(t *testing.T) {
+	c, source, _, repo, _ := setUp(t)
+	repo.SetErr(errTemporary)
+	source.messages = []messaging.InboundMessage{message(1, "first")}
+
+	stopped := make(chan struct{})
+	go func() {
+		c.Run()
+		close(stopped)
+	}()
+
+	require.Eventually(t, func() bool {
+		source.lock.Lock()
+		defer source.lock.Unlock()
+		return len(source.messages) == 0
+	}, time.Second, 5*time.Millisecond)
+	c.CleanUp()
+	<-stopped
+
+	require.Empty(t, source.committedOffsets())
+	require.True(t, source.closed)
+}
+
+func TestConsumerBacksOffAfterReadErrors(t *testing.T) {
+	c, source, _, _, _ := setUp(t)
+	source.readErr = errors.New("broker is down")
+
+	stopped := make(chan struct{})
+	go func() {
+		c.Run()
+		close(stopped)
+	}()
+
+	// the backoff grows by a millisecond per failed read, a busy loop would read thousands of times meanwhile
+	time.Sleep(100 * time.Millisecond)
+	c.CleanUp()
+	<-stopped
+
+	source.lock.Lock()
+	defer source.lock.Unlock()
+	require.Less(t, source.reads, 50)
+	require.True(t, source.closed)
+}
+
+func TestRegistrySkipsTopicsWithoutName(t *testing.T) {
+	registry := NewRegistry()
+	registry.Register("b", nil)
+	registry.Register("", nil)
+	registry.Register("a", nil)
+
+	require.Equal(t, []string{"a", "b"}, registry.Topics())
+	_, ok := registry.Handler("")
+	require.False(t, ok)
+}
@@ -0,0 +1,63 @@
+package consumer
+
+import (
+	"errors"
+	confkafka "github.com/confluentinc/confluent-kafka-go/v2/kafka"
+	"time"
+	"userservice/internal/config"
+	"userservice/internal/infrastructure/kafkaclient"
+	"userservice/internal/infrastructure/messaging"
+)
+
+type KafkaSource struct {
+	consumer *confkafka.Consumer
+}
+
+// NewKafkaSource creates a consumer that never commits on its own, a command is only done once it was handled
+func NewKafkaSource(bootstrapServers string, groupID string, security config.KafkaSecurityConfig) (*KafkaSource, error) {
+	configMap, err := kafkaclient.ConsumerConfigMap(bootstrapServers, groupID, security)
+	if err != nil {
+		return nil, err
+	}
+	consumer, err := confkafka.NewConsumer(configMap)
+	if err != nil {
+		return nil, err
+	}
+	return &KafkaSource{consumer: consumer}, nil
+}
+
+func (k *KafkaSource) Subscribe(topics []string) error {
+	return k.consumer.SubscribeTopics(topics, nil)
+}
+
+func (k *KafkaSource) ReadMessage(timeout time.Duration) (*messaging.InboundMessage, error) {
+	msg, err := k.consumer.ReadMessage(timeout)
+	var kafkaErr confkafka.Error
+	if errors.As(err, &kafkaErr) && kafkaErr.IsTimeout() {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &messaging.InboundMessage{
+		Topic:     *msg.TopicPartition.Topic,
+		Partition: msg.TopicPartition.Partition,
+		Offset:    int64(msg.TopicPartition.Offset),
+		Key:       msg.Key,
+		Value:     msg.Value,
+	}, nil
+}
+
+// CommitMessage commits the offset after msg, so the group continues with the next message
+func (k *KafkaSource) CommitMessage(msg messaging.InboundMessage) error {
+	_, err := k.consumer.CommitOffsets([]confkafka.TopicPartition{{
+		Topic:     &msg.Topic,
+		Partition: msg.Partition,
+		Offset:    confkafka.Offset(msg.Offset + 1),
+	}})
+	return err
+}
+
+func (k *KafkaSource) Close() error {
+	return k.consumer.Close()
+}
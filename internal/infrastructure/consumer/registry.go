@@ -0,0 +1,48 @@
+package consumer
+
+import (
+	"context"
+	"sort"
+)
+
+// Command is a decoded command, ID makes sure a command that is read twice only runs once. Execute makes its change in
+// at most one transaction of a repo, which marks the command as processed with it. A command that changes nothing in
+// a transaction, like deleting a user that is already gone, is marked afterwards and has to be safe to run again
+type Command struct {
+	ID      string
+	Execute func(ctx context.Context) error
+}
+
+// Handler decodes the value of a message read from the topic it was registered for
+type Handler func(value []byte) (Command, error)
+
+type Registry struct {
+	handlers map[string]Handler
+}
+
+func NewRegistry() *Registry {
+	return &Registry{handlers: map[string]Handler{}}
+}
+
+// Register routes messages of topic to handler, topics without a name are skipped so a command can be turned off
+// by leaving its topic out of the config
+func (r *Registry) Register(topic string, handler Handler) {
+	if topic == "" {
+		return
+	}
+	r.handlers[topic] = handler
+}
+
+func (r *Registry) Handler(topic string) (Handler, bool) {
+	handler, ok := r.handlers[topic]
+	return handler, ok
+}
+
+func (r *Registry) Topics() []string {
+	topics := make([]string, 0, len(r.handlers))
+	for topic := range r.handlers {
+		topics = append(topics, topic)
+	}
+	sort.Strings(topics)
+	return topics
+}
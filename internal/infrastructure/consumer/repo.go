@@ -0,0 +1,34 @@
+package consumer
+
+import (
+	"context"
+	"errors"
+)
+
+// ErrCommandAlreadyProcessed is returned by a repo that did not make the change of a command, because the command was
+// marked as processed meanwhile
+var ErrCommandAlreadyProcessed = errors.New("command was already processed")
+
+type ProcessedCommandRepo interface {
+	IsCommandProcessed(ctx context.Context, commandID string) (bool, error)
+	// MarkCommandProcessed is idempotent, marking a command twice is not an error
+	MarkCommandProcessed(ctx context.Context, commandID string, topic string) error
+}
+
+type processingKey struct{}
+
+// Processing is the command a context runs. A repo that makes the change of the command in a transaction marks the
+// command as processed in that transaction, so the change and the mark are stored together or not at all
+type Processing struct {
+	CommandID string
+	Topic     string
+}
+
+func NewProcessingContext(ctx context.Context, processing Processing) context.Context {
+	return context.WithValue(ctx, processingKey{}, processing)
+}
+
+func ProcessingFromContext(ctx context.Context) (Processing, bool) {
+	processing, ok := ctx.Value(processingKey{}).(Processing)
+	return processing, ok
+}
@@ -0,0 +1,15 @@
+package consumer
+
+import (
+	"time"
+	"userservice/internal/infrastructure/messaging"
+)
+
+// Source is where commands are read from, offsets are only committed explicitly
+type Source interface {
+	Subscribe(topics []string) error
+	// ReadMessage returns nil without an error when no message arrived within timeout
+	ReadMessage(timeout time.Duration) (*messaging.InboundMessage, error)
+	CommitMessage(msg messaging.InboundMessage) error
+	Close() error
+}
@@ -2,6 +2,7 @@ package health
 
 import (
 	"context"
+	"github.com/rs/zerolog/log"
 	"time"
 )
 
@@ -13,6 +14,16 @@ const (
 	StatusUnknown    Status = "UNKNOWN"
 )
 
+// Criticality tells whether the service can do its job without a dependency
+type Criticality string
+
+const (
+	// CriticalityCritical dependencies make the service not ready when they are not serving
+	CriticalityCritical Criticality = "critical"
+	// CriticalityNonCritical dependencies only degrade the service, like kafka which the outbox buffers events for
+	CriticalityNonCritical Criticality = "noncritical"
+)
+
 type Checkable interface {
 	GetName() string
 	RunHealthCheck(checkContext context.Context, reportChannel chan Report, checkTimeInterval time.Duration)
@@ -21,8 +32,58 @@ type Checkable interface {
 type Report struct {
 	ServiceName string
 	Status      Status
+	// CheckedAt is when the check finished, which took Latency
+	CheckedAt time.Time
+	Latency   time.Duration
+	// Error is why the check failed, empty when it did not
+	Error string
+	// Details are the numbers the check looked at, nil for checks that only tell whether a dependency answers
+	Details Details
 }
 
+// Details are published with the diagnostics of a dependency, keyed by name
+type Details map[string]any
+
 func NewHealthReport(c Checkable, status Status) Report {
-	return Report{c.GetName(), status}
+	return Report{ServiceName: c.GetName(), Status: status, CheckedAt: time.Now().UTC()}
+}
+
+// runPeriodically calls check right away and then on every tick until checkContext is done, reporting the outcome of
+// every check
+func runPeriodically(checkContext context.Context, c Checkable, reportChannel chan Report, checkTimeInterval time.Duration, check func(ctx context.Context) error) {
+	runPeriodicallyWithDetails(checkContext, c, reportChannel, checkTimeInterval, func(ctx context.Context) (Details, error) {
+		return nil, check(ctx)
+	})
+}
+
+// runPeriodicallyWithDetails is runPeriodically for checks that also report what they measured
+func runPeriodicallyWithDetails(checkContext context.Context, c Checkable, reportChannel chan Report, checkTimeInterval time.Duration, check func(ctx context.Context) (Details, error)) {
+	go func() {
+		ticker := time.NewTicker(checkTimeInterval)
+		defer ticker.Stop()
+		for {
+			started := time.Now()
+			details, err := check(checkContext)
+			report := NewHealthReport(c, StatusServing)
+			report.Latency = time.Since(started)
+			report.Details = details
+			if err != nil {
+				log.Error().Err(err).Msgf("%s Healthcheck Fail", c.GetName())
+				report.Status = StatusNotServing
+				report.Error = err.Error()
+			}
+
+			select {
+			case <-checkContext.Done():
+				return
+			case reportChannel <- report:
+			}
+
+			select {
+			case <-checkContext.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
 }
@@ -0,0 +1,59 @@
+package health
+
+import (
+	"context"
+	"errors"
+	"github.com/stretchr/testify/require"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+type namedCheckable struct{}
+
+func (n namedCheckable) GetName() string {
+	return "Test"
+}
+
+func (n namedCheckable) RunHealthCheck(checkContext context.Context, reportChannel chan Report, checkTimeInterval time.Duration) {
+}
+
+func TestRunPeriodicallyChecksRightAway(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	reports := make(chan Report)
+
+	// the first tick is an hour away
+	runPeriodically(ctx, namedCheckable{}, reports, time.Hour, func(ctx context.Context) error {
+		return errors.New("down")
+	})
+
+	select {
+	case report := <-reports:
+		require.Equal(t, "Test", report.ServiceName)
+		require.Equal(t, StatusNotServing, report.Status)
+		require.Equal(t, "down", report.Error)
+		require.False(t, report.CheckedAt.IsZero())
+	case <-time.After(time.Second):
+		require.Fail(t, "no report before the first tick")
+	}
+}
+
+func TestRunPeriodicallyStopsOnCancel(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	reports := make(chan Report)
+	checks := atomic.Int64{}
+
+	runPeriodically(ctx, namedCheckable{}, reports, time.Millisecond, func(ctx context.Context) error {
+		checks.Add(1)
+		return nil
+	})
+	<-reports
+	cancel()
+
+	// nobody reads the reports anymore, the check must not block on them nor keep running
+	time.Sleep(20 * time.Millisecond)
+	stopped := checks.Load()
+	time.Sleep(20 * time.Millisecond)
+	require.Equal(t, stopped, checks.Load())
+}
@@ -0,0 +1,74 @@
+package health
+
+import (
+	"time"
+)
+
+// Diagnostics is what the checks of a dependency found out so far
+type Diagnostics struct {
+	LastCheckedAt time.Time
+	// Latency is how long the last check took
+	Latency       time.Duration
+	LastSuccessAt time.Time
+	LastError     string
+	LastErrorAt   time.Time
+	// ConsecutiveFailures is reset by every successful check
+	ConsecutiveFailures int
+	// Details are those of the last check
+	Details Details
+}
+
+func (d *Diagnostics) Record(report Report) {
+	d.LastCheckedAt = report.CheckedAt
+	d.Latency = report.Latency
+	d.Details = report.Details
+	if report.Status == StatusServing {
+		d.LastSuccessAt = report.CheckedAt
+		d.ConsecutiveFailures = 0
+		return
+	}
+	d.LastError = report.Error
+	d.LastErrorAt = report.CheckedAt
+	d.ConsecutiveFailures++
+}
+
+// Transition is a change of the status of a dependency
+type Transition struct {
+	ServiceName string
+	From        string
+	To          string
+	At          time.Time
+	// Error is why the check failed, when it made the dependency not serving
+	Error string
+}
+
+// History keeps the latest transitions in a ring buffer, it is not safe for concurrent use
+type History struct {
+	transitions []Transition
+	// next is where the next transition goes, the oldest one once the buffer is full
+	next int
+	full bool
+}
+
+func NewHistory(size int) *History {
+	return &History{transitions: make([]Transition, size)}
+}
+
+func (h *History) Add(transition Transition) {
+	if len(h.transitions) == 0 {
+		return
+	}
+	h.transitions[h.next] = transition
+	h.next = (h.next + 1) % len(h.transitions)
+	if h.next == 0 {
+		h.full = true
+	}
+}
+
+// Transitions returns the kept transitions, oldest first
+func (h *History) Transitions() []Transition {
+	if !h.full {
+		return append([]Transition(nil), h.transitions[:h.next]...)
+	}
+	return append(append([]Transition(nil), h.transitions[h.next:]...), h.transitions[:h.next]...)
+}
@@ -0,0 +1,61 @@
+package health
+
+import (
+	"fmt"
+	"github.com/stretchr/testify/require"
+	"testing"
+	"time"
+)
+
+func TestDiagnosticsCountConsecutiveFailures(t *testing.T) {
+	var diagnostics Diagnostics
+	start := time.Now().UTC()
+
+	diagnostics.Record(Report{Status: StatusServing, CheckedAt: start, Latency: time.Millisecond})
+	diagnostics.Record(Report{Status: StatusNotServing, CheckedAt: start.Add(time.Second), Error: "timeout"})
+	diagnostics.Record(Report{Status: StatusNotServing, CheckedAt: start.Add(2 * time.Second), Error: "refused", Latency: 5 * time.Millisecond})
+	require.Equal(t, Diagnostics{
+		LastCheckedAt:       start.Add(2 * time.Second),
+		Latency:             5 * time.Millisecond,
+		LastSuccessAt:       start,
+		LastError:           "refused",
+		LastErrorAt:         start.Add(2 * time.Second),
+		ConsecutiveFailures: 2,
+	}, diagnostics)
+
+	// the last error is kept for diagnosing flapping dependencies
+	diagnostics.Record(Report{Status: StatusServing, CheckedAt: start.Add(3 * time.Second)})
+	require.Equal(t, 0, diagnostics.ConsecutiveFailures)
+	require.Equal(t, start.Add(3*time.Second), diagnostics.LastSuccessAt)
+	require.Equal(t, "refused", diagnostics.LastError)
+}
+
+func transitions(count int) []Transition {
+	var added []Transition
+	for i := range count {
+		added = append(added, Transition{ServiceName: fmt.Sprintf("service-%d", i)})
+	}
+	return added
+}
+
+func TestHistoryKeepsLatestTransitions(t *testing.T) {
+	history := NewHistory(3)
+	require.Empty(t, history.Transitions())
+
+	added := transitions(5)
+	for i, transition := range added[:2] {
+		history.Add(transition)
+		require.Equal(t, added[:i+1], history.Transitions())
+	}
+	history.Add(added[2])
+	require.Equal(t, added[:3], history.Transitions())
+
+	// the oldest ones are overwritten
+	history.Add(added[3])
+	history.Add(added[4])
+	require.Equal(t, added[2:], history.Transitions())
+
+	empty := NewHistory(0)
+	empty.Add(added[0])
+	require.Empty(t, empty.Transitions())
+}
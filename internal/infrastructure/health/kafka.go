@@ -4,7 +4,6 @@ import (
 	"context"
 	"github.com/confluentinc/confluent-kafka-go/v2/kafka"
 	"github.com/pkg/errors"
-	"github.com/rs/zerolog/log"
 	"time"
 )
 
@@ -21,25 +20,9 @@ func (k *kafkaHealthCheckable) GetName() string {
 }
 
 func (k *kafkaHealthCheckable) RunHealthCheck(checkContext context.Context, reportChannel chan Report, checkTimeInterval time.Duration) {
-	checkTicker := time.NewTicker(checkTimeInterval)
-	go func() {
-
-		for {
-			select {
-			case <-checkContext.Done():
-			case <-checkTicker.C:
-				err := k.checkHealth()
-				var report Report
-				if err != nil {
-					log.Error().Err(err).Msg("Kafka Healthcheck Fail")
-					report = NewHealthReport(k, StatusNotServing)
-				} else {
-					report = NewHealthReport(k, StatusServing)
-				}
-				reportChannel <- report
-			}
-		}
-	}()
+	runPeriodically(checkContext, k, reportChannel, checkTimeInterval, func(ctx context.Context) error {
+		return k.checkHealth()
+	})
 }
 
 func (k *kafkaHealthCheckable) checkHealth() error {
@@ -0,0 +1,24 @@
+package health
+
+import (
+	"context"
+	"time"
+)
+
+type kafkaTopicsHealthCheckable struct {
+	verify func(ctx context.Context) error
+}
+
+// NewKafkaTopicsHealthCheckable is serving while verify finds the topics as configured, which is checked right away
+// and then on every tick
+func NewKafkaTopicsHealthCheckable(verify func(ctx context.Context) error) Checkable {
+	return &kafkaTopicsHealthCheckable{verify: verify}
+}
+
+func (k *kafkaTopicsHealthCheckable) GetName() string {
+	return "KafkaTopics"
+}
+
+func (k *kafkaTopicsHealthCheckable) RunHealthCheck(checkContext context.Context, reportChannel chan Report, checkTimeInterval time.Duration) {
+	runPeriodically(checkContext, k, reportChannel, checkTimeInterval, k.verify)
+}
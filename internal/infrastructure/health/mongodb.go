@@ -30,20 +30,7 @@ func (m *mongoDBHealthCheckable) GetName() string {
 }
 
 func (m *mongoDBHealthCheckable) RunHealthCheck(checkContext context.Context, reportChannel chan Report, checkTimeInterval time.Duration) {
-
-	go func() {
-		ticker := time.NewTicker(checkTimeInterval)
-		for {
-			select {
-			case <-checkContext.Done():
-			case <-ticker.C:
-				err := m.Ping(checkContext, 5*time.Second)
-				if err != nil {
-					reportChannel <- NewHealthReport(m, StatusNotServing)
-				} else {
-					reportChannel <- NewHealthReport(m, StatusServing)
-				}
-			}
-		}
-	}()
+	runPeriodically(checkContext, m, reportChannel, checkTimeInterval, func(ctx context.Context) error {
+		return m.Ping(ctx, 5*time.Second)
+	})
 }
@@ -0,0 +1,76 @@
+package health
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+	"userservice/internal/config"
+	"userservice/internal/infrastructure/messaging"
+)
+
+type OutboxLagRepo interface {
+	GetOutboxLag(ctx context.Context) (messaging.OutboxLag, error)
+}
+
+type outboxLagHealthCheckable struct {
+	repo       OutboxLagRepo
+	thresholds config.OutboxLagThresholds
+}
+
+// NewOutboxLagHealthCheckable is not serving while the outbox is further behind than thresholds allow, the lag is
+// published in the details either way
+func NewOutboxLagHealthCheckable(repo OutboxLagRepo, thresholds config.OutboxLagThresholds) Checkable {
+	return &outboxLagHealthCheckable{repo: repo, thresholds: thresholds}
+}
+
+func (o *outboxLagHealthCheckable) GetName() string {
+	return "OutboxLag"
+}
+
+func (o *outboxLagHealthCheckable) RunHealthCheck(checkContext context.Context, reportChannel chan Report, checkTimeInterval time.Duration) {
+	runPeriodicallyWithDetails(checkContext, o, reportChannel, checkTimeInterval, o.checkLag)
+}
+
+func (o *outboxLagHealthCheckable) checkLag(ctx context.Context) (Details, error) {
+	ctxWithTimeout, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	lag, err := o.repo.GetOutboxLag(ctxWithTimeout)
+	if err != nil {
+		return nil, err
+	}
+	var oldestWaitingAge time.Duration
+	if !lag.OldestWaitingCreatedAt.IsZero() {
+		oldestWaitingAge = time.Since(lag.OldestWaitingCreatedAt)
+	}
+	details := Details{
+		"oldestWaitingAgeSeconds": int64(oldestWaitingAge / time.Second),
+		"processing":              lag.Processing,
+		"deadLettered":            lag.DeadLettered,
+	}
+	if len(lag.BlockedKeys) > 0 {
+		details["blockedKeys"] = lag.BlockedKeys
+	}
+
+	var exceeded []string
+	maxAge := time.Duration(o.thresholds.MaxOldestWaitingAgeSeconds) * time.Second
+	if maxAge > 0 && oldestWaitingAge > maxAge {
+		exceeded = append(exceeded, fmt.Sprintf("oldest waiting message is %s old, above %s", oldestWaitingAge.Round(time.Second), maxAge))
+	}
+	if o.thresholds.MaxProcessing > 0 && lag.Processing > o.thresholds.MaxProcessing {
+		exceeded = append(exceeded, fmt.Sprintf("%d messages are processing, above %d", lag.Processing, o.thresholds.MaxProcessing))
+	}
+	if o.thresholds.MaxDeadLettered > 0 && lag.DeadLettered > o.thresholds.MaxDeadLettered {
+		deadLettered := fmt.Sprintf("%d messages are dead lettered, above %d", lag.DeadLettered, o.thresholds.MaxDeadLettered)
+		if len(lag.BlockedKeys) > 0 {
+			deadLettered += fmt.Sprintf(", holding up the messages of keys %s", strings.Join(lag.BlockedKeys, ", "))
+		}
+		exceeded = append(exceeded, deadLettered)
+	}
+	if len(exceeded) > 0 {
+		return details, errors.New(strings.Join(exceeded, ", "))
+	}
+	return details, nil
+}
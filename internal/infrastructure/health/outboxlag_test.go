@@ -0,0 +1,68 @@
+package health
+
+import (
+	"context"
+	"errors"
+	"github.com/stretchr/testify/require"
+	"testing"
+	"time"
+	"userservice/internal/config"
+	"userservice/internal/infrastructure/messaging"
+)
+
+type fixedOutboxLagRepo struct {
+	lag messaging.OutboxLag
+	err error
+}
+
+func (f fixedOutboxLagRepo) GetOutboxLag(ctx context.Context) (messaging.OutboxLag, error) {
+	return f.lag, f.err
+}
+
+var testThresholds = config.OutboxLagThresholds{MaxOldestWaitingAgeSeconds: 300, MaxProcessing: 100, MaxDeadLettered: 0}
+
+func checkOutboxLag(repo OutboxLagRepo, thresholds config.OutboxLagThresholds) (Details, error) {
+	checkable := NewOutboxLagHealthCheckable(repo, thresholds).(*outboxLagHealthCheckable)
+	return checkable.checkLag(context.Background())
+}
+
+func TestOutboxLagWithinThresholds(t *testing.T) {
+	lag := messaging.OutboxLag{OldestWaitingCreatedAt: time.Now().UTC().Add(-time.Minute), Processing: 100, DeadLettered: 7}
+
+	details, err := checkOutboxLag(fixedOutboxLagRepo{lag: lag}, testThresholds)
+	require.NoError(t, err)
+	require.Equal(t, Details{"oldestWaitingAgeSeconds": int64(60), "processing": int64(100), "deadLettered": int64(7)}, details)
+
+	// nothing waiting is no lag at all
+	details, err = checkOutboxLag(fixedOutboxLagRepo{}, testThresholds)
+	require.NoError(t, err)
+	require.Equal(t, int64(0), details["oldestWaitingAgeSeconds"])
+}
+
+func TestOutboxLagAboveThresholds(t *testing.T) {
+	lag := messaging.OutboxLag{OldestWaitingCreatedAt: time.Now().UTC().Add(-10 * time.Minute), Processing: 101}
+
+	details, err := checkOutboxLag(fixedOutboxLagRepo{lag: lag}, testThresholds)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "oldest waiting message is 10m0s old, above 5m0s")
+	require.Contains(t, err.Error(), "101 messages are processing, above 100")
+	// the numbers are published when the check fails too
+	require.Equal(t, int64(101), details["processing"])
+
+	_, err = checkOutboxLag(fixedOutboxLagRepo{lag: messaging.OutboxLag{DeadLettered: 1}}, config.OutboxLagThresholds{MaxDeadLettered: 0})
+	require.NoError(t, err)
+	_, err = checkOutboxLag(fixedOutboxLagRepo{lag: messaging.OutboxLag{DeadLettered: 2}}, config.OutboxLagThresholds{MaxDeadLettered: 1})
+	require.EqualError(t, err, "2 messages are dead lettered, above 1")
+
+	// dead lettered messages hold up the younger messages of their key
+	blocked := messaging.OutboxLag{DeadLettered: 2, BlockedKeys: []string{"user-1", "user-2"}}
+	details, err = checkOutboxLag(fixedOutboxLagRepo{lag: blocked}, config.OutboxLagThresholds{MaxDeadLettered: 1})
+	require.EqualError(t, err, "2 messages are dead lettered, above 1, holding up the messages of keys user-1, user-2")
+	require.Equal(t, []string{"user-1", "user-2"}, details["blockedKeys"])
+}
+
+func TestOutboxLagRepoError(t *testing.T) {
+	details, err := checkOutboxLag(fixedOutboxLagRepo{err: errors.New("no primary")}, testThresholds)
+	require.EqualError(t, err, "no primary")
+	require.Nil(t, details)
+}
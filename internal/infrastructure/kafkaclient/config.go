@@ -0,0 +1,202 @@
+package kafkaclient
+
+import (
+	"errors"
+	"fmt"
+	confkafka "github.com/confluentinc/confluent-kafka-go/v2/kafka"
+	"slices"
+	"strings"
+	"userservice/internal/config"
+)
+
+const (
+	acksAll         = "all"
+	protocolPlain   = "plaintext"
+	protocolSSL     = "ssl"
+	protocolSASL    = "sasl_plaintext"
+	protocolSASLSSL = "sasl_ssl"
+)
+
+var (
+	validAcks              = []string{acksAll, "-1", "1", "0"}
+	validCompressionTypes  = []string{"none", "gzip", "snappy", "lz4", "zstd"}
+	validProtocols         = []string{protocolPlain, protocolSSL, protocolSASL, protocolSASLSSL}
+	validSASLMechanisms    = []string{"PLAIN", "SCRAM-SHA-256", "SCRAM-SHA-512"}
+	errNoBootstrapServers  = errors.New("no bootstrap servers configured")
+	errIdempotenceNeedsAll = errors.New("idempotent production requires acks all")
+	errSASLNeedsMechanism  = errors.New("sasl protocols require a sasl mechanism")
+	errSASLNeedsUser       = errors.New("sasl requires a username and a password")
+	errSSLKeyWithoutCert   = errors.New("an ssl key requires a certificate and the other way around")
+)
+
+// ownedProperties are set through their own config fields, passing them as properties as well would be ambiguous
+var ownedProperties = []string{
+	"bootstrap.servers", "metadata.broker.list",
+	"acks", "request.required.acks",
+	"enable.idempotence",
+	"compression.type", "compression.codec",
+	"linger.ms", "queue.buffering.max.ms",
+	"batch.size",
+	"security.protocol",
+	"sasl.mechanism", "sasl.mechanisms", "sasl.username", "sasl.password",
+	"ssl.ca.location", "ssl.certificate.location", "ssl.key.location", "ssl.key.password",
+}
+
+func oneOf(field string, value string, valid []string) error {
+	if !slices.Contains(valid, value) {
+		return fmt.Errorf("%s %q is not one of %s", field, value, strings.Join(valid, ", "))
+	}
+	return nil
+}
+
+// IdempotenceEnabled is true unless idempotence was turned off explicitly
+func IdempotenceEnabled(producerConfig config.ProducerConfig) bool {
+	return producerConfig.Idempotence == nil || *producerConfig.Idempotence
+}
+
+func ValidateSecurity(security config.KafkaSecurityConfig) error {
+	protocol := strings.ToLower(security.Protocol)
+	if protocol == "" {
+		protocol = protocolPlain
+	}
+	err := oneOf("security protocol", protocol, validProtocols)
+	if err != nil {
+		return err
+	}
+
+	if protocol == protocolSASL || protocol == protocolSASLSSL {
+		if security.SASLMechanism == "" {
+			return errSASLNeedsMechanism
+		}
+		err = oneOf("sasl mechanism", security.SASLMechanism, validSASLMechanisms)
+		if err != nil {
+			return err
+		}
+		if security.SASLUsername == "" || security.SASLPassword == "" {
+			return errSASLNeedsUser
+		}
+	}
+	if (security.CertificateLocation == "") != (security.KeyLocation == "") {
+		return errSSLKeyWithoutCert
+	}
+	return nil
+}
+
+func ValidateProducer(producerConfig config.ProducerConfig) error {
+	if producerConfig.BootstrapServers == "" {
+		return errNoBootstrapServers
+	}
+	acks := producerConfig.Acks
+	if acks == "" {
+		acks = acksAll
+	}
+	err := oneOf("acks", acks, validAcks)
+	if err != nil {
+		return err
+	}
+	if IdempotenceEnabled(producerConfig) && acks != acksAll && acks != "-1" {
+		return errIdempotenceNeedsAll
+	}
+	if producerConfig.CompressionType != "" {
+		err = oneOf("compression type", producerConfig.CompressionType, validCompressionTypes)
+		if err != nil {
+			return err
+		}
+	}
+	if producerConfig.LingerMillis < 0 || producerConfig.BatchSizeBytes < 0 {
+		return errors.New("linger and batch size can not be negative")
+	}
+	for key := range producerConfig.Properties {
+		if slices.Contains(ownedProperties, key) {
+			return fmt.Errorf("property %s has a config field of its own", key)
+		}
+	}
+	return nil
+}
+
+// securityConfigMap holds the settings shared by producers and consumers
+func securityConfigMap(security config.KafkaSecurityConfig) confkafka.ConfigMap {
+	configMap := confkafka.ConfigMap{}
+	if security.Protocol != "" {
+		configMap["security.protocol"] = strings.ToLower(security.Protocol)
+	}
+	optional := map[string]string{
+		"sasl.mechanism":           security.SASLMechanism,
+		"sasl.username":            security.SASLUsername,
+		"sasl.password":            security.SASLPassword,
+		"ssl.ca.location":          security.CALocation,
+		"ssl.certificate.location": security.CertificateLocation,
+		"ssl.key.location":         security.KeyLocation,
+		"ssl.key.password":         security.KeyPassword,
+	}
+	for key, value := range optional {
+		if value != "" {
+			configMap[key] = value
+		}
+	}
+	return configMap
+}
+
+// ProducerConfigMap validates the config and turns it into librdkafka settings
+func ProducerConfigMap(producerConfig config.ProducerConfig, security config.KafkaSecurityConfig) (*confkafka.ConfigMap, error) {
+	err := ValidateProducer(producerConfig)
+	if err != nil {
+		return nil, err
+	}
+	err = ValidateSecurity(security)
+	if err != nil {
+		return nil, err
+	}
+
+	configMap := securityConfigMap(security)
+	for key, value := range producerConfig.Properties {
+		configMap[key] = value
+	}
+	configMap["bootstrap.servers"] = producerConfig.BootstrapServers
+	configMap["acks"] = acksAll
+	if producerConfig.Acks != "" {
+		configMap["acks"] = producerConfig.Acks
+	}
+	configMap["enable.idempotence"] = IdempotenceEnabled(producerConfig)
+	if producerConfig.CompressionType != "" {
+		configMap["compression.type"] = producerConfig.CompressionType
+	}
+	if producerConfig.LingerMillis > 0 {
+		configMap["linger.ms"] = producerConfig.LingerMillis
+	}
+	if producerConfig.BatchSizeBytes > 0 {
+		configMap["batch.size"] = producerConfig.BatchSizeBytes
+	}
+	return &configMap, nil
+}
+
+// ConsumerConfigMap is for consumers that commit their offsets themselves
+func ConsumerConfigMap(bootstrapServers string, groupID string, security config.KafkaSecurityConfig) (*confkafka.ConfigMap, error) {
+	if bootstrapServers == "" {
+		return nil, errNoBootstrapServers
+	}
+	err := ValidateSecurity(security)
+	if err != nil {
+		return nil, err
+	}
+
+	configMap := securityConfigMap(security)
+	configMap["bootstrap.servers"] = bootstrapServers
+	configMap["group.id"] = groupID
+	configMap["enable.auto.commit"] = false
+	configMap["auto.offset.reset"] = "earliest"
+	return &configMap, nil
+}
+
+// NewProducer also lets librdkafka check the properties, so unknown keys or bad values stop the service at startup
+func NewProducer(producerConfig config.ProducerConfig, security config.KafkaSecurityConfig) (*confkafka.Producer, error) {
+	configMap, err := ProducerConfigMap(producerConfig, security)
+	if err != nil {
+		return nil, err
+	}
+	producer, err := confkafka.NewProducer(configMap)
+	if err != nil {
+		return nil, fmt.Errorf("invalid producer config: %w", err)
+	}
+	return producer, nil
+}
@@ -0,0 +1,105 @@
+package kafkaclient
+
+import (
+	"github.com/stretchr/testify/require"
+	"testing"
+	"userservice/internal/config"
+)
+
+func TestProducerConfigMapDefaults(t *testing.T) {
+	configMap, err := ProducerConfigMap(config.ProducerConfig{BootstrapServers: "broker:9092"}, config.KafkaSecurityConfig{})
+	require.NoError(t, err)
+
+	require.Equal(t, "broker:9092", (*configMap)["bootstrap.servers"])
+	require.Equal(t, "all", (*configMap)["acks"])
+	require.Equal(t, true, (*configMap)["enable.idempotence"])
+	require.NotContains(t, *configMap, "linger.ms")
+	require.NotContains(t, *configMap, "security.protocol")
+}
+
+func TestProducerConfigMap(t *testing.T) {
+	idempotence := false
+	configMap, err := ProducerConfigMap(config.ProducerConfig{
+		BootstrapServers: "broker:9092",
+		Acks:             "1",
+		Idempotence:      &idempotence,
+		CompressionType:  "zstd",
+		LingerMillis:     20,
+		BatchSizeBytes:   65536,
+		Properties:       map[string]string{"message.timeout.ms": "60000"},
+	}, config.KafkaSecurityConfig{
+		Protocol:      "SASL_SSL",
+		SASLMechanism: "SCRAM-SHA-512",
+		SASLUsername:  "userservice",
+		SASLPassword:  "secret",
+		CALocation:    "/etc/ca.pem",
+	})
+	require.NoError(t, err)
+
+	require.Equal(t, "1", (*configMap)["acks"])
+	require.Equal(t, false, (*configMap)["enable.idempotence"])
+	require.Equal(t, "zstd", (*configMap)["compression.type"])
+	require.Equal(t, 20, (*configMap)["linger.ms"])
+	require.Equal(t, 65536, (*configMap)["batch.size"])
+	require.Equal(t, "60000", (*configMap)["message.timeout.ms"])
+	require.Equal(t, "sasl_ssl", (*configMap)["security.protocol"])
+	require.Equal(t, "SCRAM-SHA-512", (*configMap)["sasl.mechanism"])
+	require.Equal(t, "secret", (*configMap)["sasl.password"])
+	require.Equal(t, "/etc/ca.pem", (*configMap)["ssl.ca.location"])
+	require.NotContains(t, *configMap, "ssl.key.location")
+}
+
+func TestProducerConfigValidation(t *testing.T) {
+	valid := config.ProducerConfig{BootstrapServers: "broker:9092"}
+	idempotence := false
+
+	tests := map[string]struct {
+		producer config.ProducerConfig
+		security config.KafkaSecurityConfig
+	}{
+		"no brokers":               {producer: config.ProducerConfig{}},
+		"unknown acks":             {producer: config.ProducerConfig{BootstrapServers: "broker:9092", Acks: "some"}},
+		"idempotence without all":  {producer: config.ProducerConfig{BootstrapServers: "broker:9092", Acks: "1"}},
+		"unknown compression":      {producer: config.ProducerConfig{BootstrapServers: "broker:9092", CompressionType: "brotli"}},
+		"negative linger":          {producer: config.ProducerConfig{BootstrapServers: "broker:9092", LingerMillis: -1}},
+		"property with own field":  {producer: config.ProducerConfig{BootstrapServers: "broker:9092", Properties: map[string]string{"acks": "0"}}},
+		"unknown protocol":         {producer: valid, security: config.KafkaSecurityConfig{Protocol: "tls"}},
+		"sasl without mechanism":   {producer: valid, security: config.KafkaSecurityConfig{Protocol: "sasl_ssl", SASLUsername: "a", SASLPassword: "b"}},
+		"sasl without credentials": {producer: valid, security: config.KafkaSecurityConfig{Protocol: "sasl_plaintext", SASLMechanism: "PLAIN"}},
+		"key without certificate":  {producer: valid, security: config.KafkaSecurityConfig{Protocol: "ssl", KeyLocation: "/etc/key.pem"}},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			_, err := ProducerConfigMap(test.producer, test.security)
+			require.Error(t, err)
+		})
+	}
+
+	_, err := ProducerConfigMap(config.ProducerConfig{BootstrapServers: "broker:9092", Acks: "0", Idempotence: &idempotence}, config.KafkaSecurityConfig{})
+	require.NoError(t, err)
+}
+
+func TestNewProducerRejectsUnknownProperties(t *testing.T) {
+	_, err := NewProducer(config.ProducerConfig{
+		BootstrapServers: "localhost:1",
+		Properties:       map[string]string{"no.such.property": "1"},
+	}, config.KafkaSecurityConfig{})
+	require.Error(t, err)
+
+	producer, err := NewProducer(config.ProducerConfig{
+		BootstrapServers: "localhost:1",
+		Properties:       map[string]string{"message.timeout.ms": "1000"},
+	}, config.KafkaSecurityConfig{})
+	require.NoError(t, err)
+	producer.Close()
+}
+
+func TestConsumerConfigMap(t *testing.T) {
+	configMap, err := ConsumerConfigMap("broker:9092", "group", config.KafkaSecurityConfig{Protocol: "ssl"})
+	require.NoError(t, err)
+	require.Equal(t, false, (*configMap)["enable.auto.commit"])
+	require.Equal(t, "ssl", (*configMap)["security.protocol"])
+
+	_, err = ConsumerConfigMap("", "group", config.KafkaSecurityConfig{})
+	require.Error(t, err)
+}
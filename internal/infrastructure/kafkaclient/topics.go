@@ -0,0 +1,135 @@
+package kafkaclient
+
+import (
+	"context"
+	"fmt"
+	confkafka "github.com/confluentinc/confluent-kafka-go/v2/kafka"
+	"strconv"
+	"userservice/internal/config"
+)
+
+const retentionProperty = "retention.ms"
+
+// Topic is how a topic is, or should be, set up
+type Topic struct {
+	Name     string
+	Settings config.TopicSettings
+}
+
+type TopicAdmin interface {
+	// DescribeTopics leaves out the topics that do not exist
+	DescribeTopics(ctx context.Context, names []string) (map[string]config.TopicSettings, error)
+	// CreateTopics does not fail for topics that were created by someone else in the meantime
+	CreateTopics(ctx context.Context, topics []Topic) error
+	Close()
+}
+
+type KafkaTopicAdmin struct {
+	admin *confkafka.AdminClient
+}
+
+func NewTopicAdmin(bootstrapServers string, security config.KafkaSecurityConfig) (*KafkaTopicAdmin, error) {
+	if bootstrapServers == "" {
+		return nil, errNoBootstrapServers
+	}
+	err := ValidateSecurity(security)
+	if err != nil {
+		return nil, err
+	}
+	configMap := securityConfigMap(security)
+	configMap["bootstrap.servers"] = bootstrapServers
+
+	admin, err := confkafka.NewAdminClient(&configMap)
+	if err != nil {
+		return nil, err
+	}
+	return &KafkaTopicAdmin{admin: admin}, nil
+}
+
+func (k *KafkaTopicAdmin) DescribeTopics(ctx context.Context, names []string) (map[string]config.TopicSettings, error) {
+	described, err := k.admin.DescribeTopics(ctx, confkafka.NewTopicCollectionOfTopicNames(names))
+	if err != nil {
+		return nil, err
+	}
+
+	topics := map[string]config.TopicSettings{}
+	var resources []confkafka.ConfigResource
+	for _, description := range described.TopicDescriptions {
+		if description.Error.Code() == confkafka.ErrUnknownTopicOrPart {
+			continue
+		}
+		if description.Error.Code() != confkafka.ErrNoError {
+			return nil, fmt.Errorf("failed to describe topic %s: %w", description.Name, description.Error)
+		}
+		settings := config.TopicSettings{Partitions: len(description.Partitions)}
+		if len(description.Partitions) > 0 {
+			settings.ReplicationFactor = len(description.Partitions[0].Replicas)
+		}
+		topics[description.Name] = settings
+		resources = append(resources, confkafka.ConfigResource{Type: confkafka.ResourceTopic, Name: description.Name})
+	}
+	if len(resources) == 0 {
+		return topics, nil
+	}
+
+	configs, err := k.admin.DescribeConfigs(ctx, resources)
+	if err != nil {
+		return nil, err
+	}
+	for _, result := range configs {
+		if result.Error.Code() != confkafka.ErrNoError {
+			return nil, fmt.Errorf("failed to describe config of topic %s: %w", result.Name, result.Error)
+		}
+		entry, ok := result.Config[retentionProperty]
+		if !ok {
+			continue
+		}
+		retention, err := strconv.ParseInt(entry.Value, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("topic %s has unexpected retention %q", result.Name, entry.Value)
+		}
+		settings := topics[result.Name]
+		settings.RetentionMillis = retention
+		topics[result.Name] = settings
+	}
+	return topics, nil
+}
+
+func (k *KafkaTopicAdmin) CreateTopics(ctx context.Context, topics []Topic) error {
+	specifications := make([]confkafka.TopicSpecification, 0, len(topics))
+	for _, topic := range topics {
+		specification := confkafka.TopicSpecification{
+			Topic: topic.Name,
+			// -1 leaves it to the broker
+			NumPartitions:     -1,
+			ReplicationFactor: -1,
+			Config:            map[string]string{},
+		}
+		if topic.Settings.Partitions > 0 {
+			specification.NumPartitions = topic.Settings.Partitions
+		}
+		if topic.Settings.ReplicationFactor > 0 {
+			specification.ReplicationFactor = topic.Settings.ReplicationFactor
+		}
+		if topic.Settings.RetentionMillis != 0 {
+			specification.Config[retentionProperty] = strconv.FormatInt(topic.Settings.RetentionMillis, 10)
+		}
+		specifications = append(specifications, specification)
+	}
+
+	results, err := k.admin.CreateTopics(ctx, specifications)
+	if err != nil {
+		return err
+	}
+	for _, result := range results {
+		code := result.Error.Code()
+		if code != confkafka.ErrNoError && code != confkafka.ErrTopicAlreadyExists {
+			return fmt.Errorf("failed to create topic %s: %w", result.Topic, result.Error)
+		}
+	}
+	return nil
+}
+
+func (k *KafkaTopicAdmin) Close() {
+	k.admin.Close()
+}
@@ -0,0 +1,91 @@
+package messaging
+
+import (
+	"context"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"time"
+	timeutil "userservice/internal/util/time"
+	"userservice/internal/util/tracing"
+)
+
+// headers sent with every event, consumers dedupe on the message id and follow the trace through the traceparent
+const (
+	HeaderEventType     = "event-type"
+	HeaderSchemaVersion = "schema-version"
+	HeaderMessageID     = "message-id"
+	HeaderOccurredAt    = "occurred-at"
+	HeaderCorrelationID = "correlation-id"
+	HeaderTraceParent   = "traceparent"
+)
+
+// HeaderRepublishedFrom is the message id of the message a republished message is a copy of
+const HeaderRepublishedFrom = "republished-from"
+
+type Header struct {
+	Key   string
+	Value string
+}
+
+// EventMetadata describes an event independently of its payload
+type EventMetadata struct {
+	// EventType is the full name of the protobuf message of the value, like kafkaschema.UserAddedMessage
+	EventType     string
+	SchemaVersion string
+	MessageID     string
+	OccurredAt    time.Time
+	// CorrelationID is empty for events no request caused
+	CorrelationID string
+	TraceParent   string
+}
+
+// Headers leaves out the metadata that is not set
+func (m EventMetadata) Headers() []Header {
+	var headers []Header
+	add := func(key string, value string) {
+		if value != "" {
+			headers = append(headers, Header{Key: key, Value: value})
+		}
+	}
+	add(HeaderEventType, m.EventType)
+	add(HeaderSchemaVersion, m.SchemaVersion)
+	add(HeaderMessageID, m.MessageID)
+	if !m.OccurredAt.IsZero() {
+		add(HeaderOccurredAt, m.OccurredAt.UTC().Format(time.RFC3339Nano))
+	}
+	add(HeaderCorrelationID, m.CorrelationID)
+	add(HeaderTraceParent, m.TraceParent)
+	return headers
+}
+
+// HeaderValue returns the value of the first header with key
+func HeaderValue(headers []Header, key string) (string, bool) {
+	for _, header := range headers {
+		if header.Key == key {
+			return header.Value, true
+		}
+	}
+	return "", false
+}
+
+// schemaVersions is bumped whenever a kafka schema changes, so consumers can tell which fields to expect
+var schemaVersions = map[protoreflect.FullName]string{
+	"kafkaschema.UserAddedMessage":    "1",
+	"kafkaschema.UserRemovedMessage":  "1",
+	"kafkaschema.UserUpdatedMessage":  "1",
+	"kafkaschema.DeadLetteredCommand": "1",
+}
+
+// NewEventMetadata describes event, the message the value of the kafka message with messageID was serialized from
+func NewEventMetadata(ctx context.Context, messageID string, event proto.Message) EventMetadata {
+	eventType := event.ProtoReflect().Descriptor().FullName()
+	trace := tracing.FromContextOrNew(ctx)
+	return EventMetadata{
+		EventType:     string(eventType),
+		SchemaVersion: schemaVersions[eventType],
+		MessageID:     messageID,
+		OccurredAt:    timeutil.DBNow(),
+		CorrelationID: trace.CorrelationID,
+		TraceParent:   trace.TraceParent,
+	}
+}
@@ -0,0 +1,62 @@
+package messaging
+
+import (
+	"context"
+	"github.com/stretchr/testify/require"
+	"testing"
+	"time"
+	"userservice/internal/util/tracing"
+	"userservice/proto/kafkaschema"
+)
+
+func TestNewEventMetadata(t *testing.T) {
+	trace := tracing.Metadata{CorrelationID: "correlation", TraceParent: "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01"}
+	ctx := tracing.NewContext(context.Background(), trace)
+
+	metadata := NewEventMetadata(ctx, "message", &kafkaschema.UserAddedMessage{Id: "user"})
+	require.Equal(t, "kafkaschema.UserAddedMessage", metadata.EventType)
+	require.Equal(t, "1", metadata.SchemaVersion)
+	require.Equal(t, "message", metadata.MessageID)
+	require.Equal(t, "correlation", metadata.CorrelationID)
+	require.Equal(t, trace.TraceParent, metadata.TraceParent)
+	require.WithinDuration(t, time.Now(), metadata.OccurredAt, time.Second)
+
+	headers := metadata.Headers()
+	require.Len(t, headers, 6)
+	occurredAt, ok := HeaderValue(headers, HeaderOccurredAt)
+	require.True(t, ok)
+	parsed, err := time.Parse(time.RFC3339Nano, occurredAt)
+	require.NoError(t, err)
+	require.True(t, parsed.Equal(metadata.OccurredAt))
+}
+
+func TestEventHeadersWithoutRequest(t *testing.T) {
+	headers := NewEventMetadata(context.Background(), "message", &kafkaschema.UserRemovedMessage{}).Headers()
+
+	_, ok := HeaderValue(headers, HeaderCorrelationID)
+	require.False(t, ok)
+	traceParent, ok := HeaderValue(headers, HeaderTraceParent)
+	require.True(t, ok)
+	require.True(t, tracing.ValidTraceParent(traceParent))
+}
+
+func TestRepublishedMessageHasAnIDOfItsOwn(t *testing.T) {
+	original := NewInternalMessage("userservice.user.added", []byte("user-1"), []byte("value"))
+	original.Headers = NewEventMetadata(context.Background(), original.ID, &kafkaschema.UserAddedMessage{}).Headers()
+
+	copied := original.Republished()
+	require.NotEqual(t, original.ID, copied.ID)
+	require.Equal(t, original.Value, copied.Value)
+	messageID, _ := HeaderValue(copied.Headers, HeaderMessageID)
+	require.Equal(t, copied.ID, messageID)
+	republishedFrom, _ := HeaderValue(copied.Headers, HeaderRepublishedFrom)
+	require.Equal(t, original.ID, republishedFrom)
+	// the original is left as it is
+	messageID, _ = HeaderValue(original.Headers, HeaderMessageID)
+	require.Equal(t, original.ID, messageID)
+
+	copiedAgain := copied.Republished()
+	republishedFrom, _ = HeaderValue(copiedAgain.Headers, HeaderRepublishedFrom)
+	require.Equal(t, copied.ID, republishedFrom)
+	require.Len(t, copiedAgain.Headers, len(copied.Headers))
+}
@@ -0,0 +1,10 @@
+package messaging
+
+// InboundMessage is a message read from kafka, Partition and Offset are needed to commit it and to find it again
+type InboundMessage struct {
+	Topic     string
+	Partition int32
+	Offset    int64
+	Key       []byte
+	Value     []byte
+}
@@ -9,8 +9,30 @@ type KafkaInternalMessage struct {
 	TopicID string
 	Key     []byte
 	Value   []byte
+	// Headers are stored with the message in the outbox, so a retry sends the same ones
+	Headers []Header
 }
 
 func NewInternalMessage(topicID string, key []byte, value []byte) *KafkaInternalMessage {
 	return &KafkaInternalMessage{ID: uuid.New().String(), TopicID: topicID, Key: key, Value: value}
 }
+
+// Republished is a copy of m with an id of its own, so consumers that dedupe on the message id do not drop it. The
+// copy points at m in the republished-from header
+func (m KafkaInternalMessage) Republished() KafkaInternalMessage {
+	copied := m
+	copied.ID = uuid.New().String()
+	copied.Headers = make([]Header, 0, len(m.Headers)+1)
+	for _, header := range m.Headers {
+		switch header.Key {
+		case HeaderMessageID:
+			header.Value = copied.ID
+		case HeaderRepublishedFrom:
+			// a copy of a copy points at the message that was republished last
+			continue
+		}
+		copied.Headers = append(copied.Headers, header)
+	}
+	copied.Headers = append(copied.Headers, Header{Key: HeaderRepublishedFrom, Value: m.ID})
+	return copied
+}
@@ -0,0 +1,43 @@
+package messaging
+
+import "time"
+
+// OutboxEntry is the stored state of a message in the outbox, as seen by operators
+type OutboxEntry struct {
+	ID             string
+	Topic          string
+	Key            string
+	Partition      int
+	Value          []byte
+	Headers        []Header
+	State          string
+	Retries        int64
+	LastError      string
+	CreatedAt      time.Time
+	NextRetry      time.Time
+	SentAt         time.Time
+	DeadLetteredAt time.Time
+	// LeaseOwner is the instance that claimed a processing message, until LeaseExpiresAt
+	LeaseOwner     string
+	LeaseExpiresAt time.Time
+}
+
+// OutboxFilter selects outbox entries, the zero value of a field matches every entry
+type OutboxFilter struct {
+	State string
+	Topic string
+	Key   string
+	// CreatedAfter and CreatedBefore bound the creation time, both are exclusive
+	CreatedAfter  time.Time
+	CreatedBefore time.Time
+}
+
+// OutboxLag tells how far behind the outbox is
+type OutboxLag struct {
+	// OldestWaitingCreatedAt is zero when no message is waiting
+	OldestWaitingCreatedAt time.Time
+	Processing             int64
+	DeadLettered           int64
+	// BlockedKeys are some of the keys whose messages wait for a dead lettered message
+	BlockedKeys []string
+}
@@ -0,0 +1,16 @@
+package messaging
+
+import "hash/fnv"
+
+// DefaultOutboxPartitions is used when no partition count is configured
+const DefaultOutboxPartitions = 16
+
+// PartitionForKey spreads keys over the outbox partitions, all messages with the same key end up in the same one
+func PartitionForKey(key []byte, partitions int) int {
+	if partitions <= 0 {
+		partitions = DefaultOutboxPartitions
+	}
+	hash := fnv.New32a()
+	_, _ = hash.Write(key)
+	return int(hash.Sum32() % uint32(partitions))
+}
@@ -0,0 +1,181 @@
+package metrics
+
+import (
+	"context"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"github.com/rs/zerolog/log"
+	"go.mongodb.org/mongo-driver/event"
+	"net/http"
+	"time"
+)
+
+const namespace = "userservice"
+
+const (
+	PublishResultSuccess = "success"
+	PublishResultFailure = "failure"
+)
+
+const (
+	// RetryReasonPublishFailed is a message the publisher did not take
+	RetryReasonPublishFailed = "publish_failed"
+	// RetryReasonDeliveryFailed is a message the broker did not acknowledge
+	RetryReasonDeliveryFailed = "delivery_failed"
+	// RetryReasonDeliveryTimedOut is a message without delivery report
+	RetryReasonDeliveryTimedOut = "delivery_timed_out"
+)
+
+// outboxCountTimeout bounds the query made on every scrape
+const outboxCountTimeout = 5 * time.Second
+
+// Metrics are kept in a registry of their own, so only what the service records is exposed next to the go and
+// process metrics
+type Metrics struct {
+	registry               *prometheus.Registry
+	grpcRequests           *prometheus.CounterVec
+	grpcRequestDuration    *prometheus.HistogramVec
+	mongoOperationDuration *prometheus.HistogramVec
+	outboxPublished        *prometheus.CounterVec
+	outboxRetries          *prometheus.CounterVec
+	healthStatus           *prometheus.GaugeVec
+}
+
+func NewMetrics() *Metrics {
+	m := &Metrics{
+		registry: prometheus.NewRegistry(),
+		grpcRequests: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "grpc_requests_total",
+			Help:      "Number of handled grpc requests by method and status code.",
+		}, []string{"method", "code"}),
+		grpcRequestDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Name:      "grpc_request_duration_seconds",
+			Help:      "Time it took to handle grpc requests by method and status code, streams are measured until they end.",
+			Buckets:   prometheus.DefBuckets,
+		}, []string{"method", "code"}),
+		mongoOperationDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Name:      "mongodb_operation_duration_seconds",
+			Help:      "Time it took mongodb to answer commands by command name.",
+			Buckets:   prometheus.DefBuckets,
+		}, []string{"operation"}),
+		outboxPublished: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "outbox_published_total",
+			Help:      "Number of outbox messages that were published by topic and result.",
+		}, []string{"topic", "result"}),
+		outboxRetries: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "outbox_retries_total",
+			Help:      "Number of failed outbox attempts that were handed back for a retry, by topic and reason.",
+		}, []string{"topic", "reason"}),
+		healthStatus: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: namespace,
+			Name:      "health_status",
+			Help:      "Whether a checked dependency is serving (1) or not (0).",
+		}, []string{"service"}),
+	}
+	m.registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+		m.grpcRequests,
+		m.grpcRequestDuration,
+		m.mongoOperationDuration,
+		m.outboxPublished,
+		m.outboxRetries,
+		m.healthStatus,
+	)
+	return m
+}
+
+// Handler serves the metrics in the prometheus text exposition format
+func (m *Metrics) Handler() http.Handler {
+	return promhttp.HandlerFor(m.registry, promhttp.HandlerOpts{})
+}
+
+func (m *Metrics) ObserveRPC(method string, code string, duration time.Duration) {
+	m.grpcRequests.WithLabelValues(method, code).Inc()
+	m.grpcRequestDuration.WithLabelValues(method, code).Observe(duration.Seconds())
+}
+
+func (m *Metrics) ObserveMongoOperation(operation string, duration time.Duration) {
+	m.mongoOperationDuration.WithLabelValues(operation).Observe(duration.Seconds())
+}
+
+// MongoCommandMonitor times every command the mongodb client sends, failed ones included
+func (m *Metrics) MongoCommandMonitor() *event.CommandMonitor {
+	return &event.CommandMonitor{
+		Succeeded: func(ctx context.Context, e *event.CommandSucceededEvent) {
+			m.ObserveMongoOperation(e.CommandName, e.Duration)
+		},
+		Failed: func(ctx context.Context, e *event.CommandFailedEvent) {
+			m.ObserveMongoOperation(e.CommandName, e.Duration)
+		},
+	}
+}
+
+func (m *Metrics) PublishSucceeded(topic string) {
+	m.outboxPublished.WithLabelValues(topic, PublishResultSuccess).Inc()
+}
+
+func (m *Metrics) PublishFailed(topic string) {
+	m.outboxPublished.WithLabelValues(topic, PublishResultFailure).Inc()
+}
+
+func (m *Metrics) RetryScheduled(topic string, reason string) {
+	m.outboxRetries.WithLabelValues(topic, reason).Inc()
+}
+
+func (m *Metrics) SetHealthStatus(service string, serving bool) {
+	value := 0.0
+	if serving {
+		value = 1
+	}
+	m.healthStatus.WithLabelValues(service).Set(value)
+}
+
+type OutboxCounter interface {
+	// CountUnfinishedOutboxMessages counts the messages of every state a message is in before it is finished
+	CountUnfinishedOutboxMessages(ctx context.Context) (map[string]int64, error)
+}
+
+// outboxDepthCollector counts the outbox when it is scraped, so the depth is never older than the scrape. Only the
+// indexed counts of the unfinished states are made, finished messages would have to be counted one by one
+type outboxDepthCollector struct {
+	counter OutboxCounter
+	depth   *prometheus.Desc
+}
+
+// RegisterOutboxDepth reports how many outbox messages are in each state but finished
+func (m *Metrics) RegisterOutboxDepth(counter OutboxCounter) {
+	m.registry.MustRegister(&outboxDepthCollector{
+		counter: counter,
+		depth: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, "outbox", "messages"),
+			"Number of outbox messages by state, finished messages are not counted.",
+			[]string{"state"}, nil,
+		),
+	})
+}
+
+func (o *outboxDepthCollector) Describe(descs chan<- *prometheus.Desc) {
+	descs <- o.depth
+}
+
+// Collect leaves the depth out when the outbox could not be counted, the other metrics are still worth scraping
+func (o *outboxDepthCollector) Collect(metrics chan<- prometheus.Metric) {
+	ctx, cancel := context.WithTimeout(context.Background(), outboxCountTimeout)
+	defer cancel()
+
+	counts, err := o.counter.CountUnfinishedOutboxMessages(ctx)
+	if err != nil {
+		log.Warn().Err(err).Msg("failed counting outbox messages for the metrics")
+		return
+	}
+	for state, count := range counts {
+		metrics <- prometheus.MustNewConstMetric(o.depth, prometheus.GaugeValue, float64(count), state)
+	}
+}
@@ -0,0 +1,163 @@
+package metrics
+
+import (
+	"context"
+	"errors"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/event"
+	"strings"
+	"testing"
+	"time"
+)
+
+type outboxCounterStub struct {
+	counts map[string]int64
+	err    error
+}
+
+func (o outboxCounterStub) CountUnfinishedOutboxMessages(ctx context.Context) (map[string]int64, error) {
+	return o.counts, o.err
+}
+
+func TestRPCMetrics(t *testing.T) {
+	m := NewMetrics()
+	m.ObserveRPC("/UserService/AddUser", "OK", 20*time.Millisecond)
+	m.ObserveRPC("/UserService/AddUser", "OK", 200*time.Millisecond)
+
+	expected := `
+# HELP userservice_grpc_request_duration_seconds Time it took to handle grpc requests by method and status code, streams are measured until they end.
+# TYPE userservice_grpc_request_duration_seconds histogram
+userservice_grpc_request_duration_seconds_bucket{code="OK",method="/UserService/AddUser",le="0.005"} 0
+userservice_grpc_request_duration_seconds_bucket{code="OK",method="/UserService/AddUser",le="0.01"} 0
+userservice_grpc_request_duration_seconds_bucket{code="OK",method="/UserService/AddUser",le="0.025"} 1
+userservice_grpc_request_duration_seconds_bucket{code="OK",method="/UserService/AddUser",le="0.05"} 1
+userservice_grpc_request_duration_seconds_bucket{code="OK",method="/UserService/AddUser",le="0.1"} 1
+userservice_grpc_request_duration_seconds_bucket{code="OK",method="/UserService/AddUser",le="0.25"} 2
+userservice_grpc_request_duration_seconds_bucket{code="OK",method="/UserService/AddUser",le="0.5"} 2
+userservice_grpc_request_duration_seconds_bucket{code="OK",method="/UserService/AddUser",le="1"} 2
+userservice_grpc_request_duration_seconds_bucket{code="OK",method="/UserService/AddUser",le="2.5"} 2
+userservice_grpc_request_duration_seconds_bucket{code="OK",method="/UserService/AddUser",le="5"} 2
+userservice_grpc_request_duration_seconds_bucket{code="OK",method="/UserService/AddUser",le="10"} 2
+userservice_grpc_request_duration_seconds_bucket{code="OK",method="/UserService/AddUser",le="+Inf"} 2
+userservice_grpc_request_duration_seconds_sum{code="OK",method="/UserService/AddUser"} 0.22
+userservice_grpc_request_duration_seconds_count{code="OK",method="/UserService/AddUser"} 2
+`
+	require.NoError(t, testutil.GatherAndCompare(m.registry, strings.NewReader(expected), "userservice_grpc_request_duration_seconds"))
+
+	m.ObserveRPC("/UserService/AddUser", "InvalidArgument", time.Millisecond)
+	expected = `
+# HELP userservice_grpc_requests_total Number of handled grpc requests by method and status code.
+# TYPE userservice_grpc_requests_total counter
+userservice_grpc_requests_total{code="InvalidArgument",method="/UserService/AddUser"} 1
+userservice_grpc_requests_total{code="OK",method="/UserService/AddUser"} 2
+`
+	require.NoError(t, testutil.GatherAndCompare(m.registry, strings.NewReader(expected), "userservice_grpc_requests_total"))
+}
+
+func TestMongoCommandMonitor(t *testing.T) {
+	m := NewMetrics()
+	monitor := m.MongoCommandMonitor()
+	monitor.Succeeded(context.Background(), &event.CommandSucceededEvent{CommandFinishedEvent: event.CommandFinishedEvent{CommandName: "find", Duration: 3 * time.Millisecond}})
+	monitor.Failed(context.Background(), &event.CommandFailedEvent{CommandFinishedEvent: event.CommandFinishedEvent{CommandName: "insert", Duration: 2 * time.Second}})
+
+	// failed commands are timed as well
+	expected := `
+# HELP userservice_mongodb_operation_duration_seconds Time it took mongodb to answer commands by command name.
+# TYPE userservice_mongodb_operation_duration_seconds histogram
+userservice_mongodb_operation_duration_seconds_bucket{operation="find",le="0.005"} 1
+userservice_mongodb_operation_duration_seconds_bucket{operation="find",le="0.01"} 1
+userservice_mongodb_operation_duration_seconds_bucket{operation="find",le="0.025"} 1
+userservice_mongodb_operation_duration_seconds_bucket{operation="find",le="0.05"} 1
+userservice_mongodb_operation_duration_seconds_bucket{operation="find",le="0.1"} 1
+userservice_mongodb_operation_duration_seconds_bucket{operation="find",le="0.25"} 1
+userservice_mongodb_operation_duration_seconds_bucket{operation="find",le="0.5"} 1
+userservice_mongodb_operation_duration_seconds_bucket{operation="find",le="1"} 1
+userservice_mongodb_operation_duration_seconds_bucket{operation="find",le="2.5"} 1
+userservice_mongodb_operation_duration_seconds_bucket{operation="find",le="5"} 1
+userservice_mongodb_operation_duration_seconds_bucket{operation="find",le="10"} 1
+userservice_mongodb_operation_duration_seconds_bucket{operation="find",le="+Inf"} 1
+userservice_mongodb_operation_duration_seconds_sum{operation="find"} 0.003
+userservice_mongodb_operation_duration_seconds_count{operation="find"} 1
+userservice_mongodb_operation_duration_seconds_bucket{operation="insert",le="0.005"} 0
+userservice_mongodb_operation_duration_seconds_bucket{operation="insert",le="0.01"} 0
+userservice_mongodb_operation_duration_seconds_bucket{operation="insert",le="0.025"} 0
+userservice_mongodb_operation_duration_seconds_bucket{operation="insert",le="0.05"} 0
+userservice_mongodb_operation_duration_seconds_bucket{operation="insert",le="0.1"} 0
+userservice_mongodb_operation_duration_seconds_bucket{operation="insert",le="0.25"} 0
+userservice_mongodb_operation_duration_seconds_bucket{operation="insert",le="0.5"} 0
+userservice_mongodb_operation_duration_seconds_bucket{operation="insert",le="1"} 0
+userservice_mongodb_operation_duration_seconds_bucket{operation="insert",le="2.5"} 1
+userservice_mongodb_operation_duration_seconds_bucket{operation="insert",le="5"} 1
+userservice_mongodb_operation_duration_seconds_bucket{operation="insert",le="10"} 1
+userservice_mongodb_operation_duration_seconds_bucket{operation="insert",le="+Inf"} 1
+userservice_mongodb_operation_duration_seconds_sum{operation="insert"} 2
+userservice_mongodb_operation_duration_seconds_count{operation="insert"} 1
+`
+	require.NoError(t, testutil.GatherAndCompare(m.registry, strings.NewReader(expected), "userservice_mongodb_operation_duration_seconds"))
+}
+
+func TestOutboxMetrics(t *testing.T) {
+	m := NewMetrics()
+	m.PublishSucceeded("userservice.user.added")
+	m.PublishSucceeded("userservice.user.added")
+	m.PublishFailed("userservice.user.added")
+	m.RetryScheduled("userservice.user.added", RetryReasonDeliveryFailed)
+	m.RetryScheduled("userservice.user.removed", RetryReasonDeliveryTimedOut)
+	m.RegisterOutboxDepth(outboxCounterStub{counts: map[string]int64{"waiting": 3, "dead_lettered": 1}})
+
+	expected := `
+# HELP userservice_outbox_messages Number of outbox messages by state, finished messages are not counted.
+# TYPE userservice_outbox_messages gauge
+userservice_outbox_messages{state="dead_lettered"} 1
+userservice_outbox_messages{state="waiting"} 3
+# HELP userservice_outbox_published_total Number of outbox messages that were published by topic and result.
+# TYPE userservice_outbox_published_total counter
+userservice_outbox_published_total{result="failure",topic="userservice.user.added"} 1
+userservice_outbox_published_total{result="success",topic="userservice.user.added"} 2
+# HELP userservice_outbox_retries_total Number of failed outbox attempts that were handed back for a retry, by topic and reason.
+# TYPE userservice_outbox_retries_total counter
+userservice_outbox_retries_total{reason="delivery_failed",topic="userservice.user.added"} 1
+userservice_outbox_retries_total{reason="delivery_timed_out",topic="userservice.user.removed"} 1
+`
+	require.NoError(t, testutil.GatherAndCompare(m.registry, strings.NewReader(expected),
+		"userservice_outbox_messages", "userservice_outbox_published_total", "userservice_outbox_retries_total"))
+}
+
+func TestOutboxDepthIsLeftOutWhenCountingFails(t *testing.T) {
+	m := NewMetrics()
+	m.RegisterOutboxDepth(outboxCounterStub{err: errors.New("no connection")})
+
+	count, err := testutil.GatherAndCount(m.registry, "userservice_outbox_messages")
+	require.NoError(t, err)
+	require.Zero(t, count)
+}
+
+func TestHealthStatus(t *testing.T) {
+	m := NewMetrics()
+	m.SetHealthStatus("MongoDB", true)
+	m.SetHealthStatus("Kafka", true)
+	m.SetHealthStatus("Kafka", false)
+
+	expected := `
+# HELP userservice_health_status Whether a checked dependency is serving (1) or not (0).
+# TYPE userservice_health_status gauge
+userservice_health_status{service="Kafka"} 0
+userservice_health_status{service="MongoDB"} 1
+`
+	require.NoError(t, testutil.GatherAndCompare(m.registry, strings.NewReader(expected), "userservice_health_status"))
+}
+
+func TestMetricNamesFollowConventions(t *testing.T) {
+	m := NewMetrics()
+	m.ObserveRPC("/UserService/AddUser", "OK", time.Millisecond)
+	m.ObserveMongoOperation("find", time.Millisecond)
+	m.PublishSucceeded("userservice.user.added")
+	m.RetryScheduled("userservice.user.added", RetryReasonPublishFailed)
+	m.SetHealthStatus("MongoDB", true)
+	m.RegisterOutboxDepth(outboxCounterStub{counts: map[string]int64{"waiting": 1}})
+
+	problems, err := testutil.GatherAndLint(m.registry)
+	require.NoError(t, err)
+	require.Empty(t, problems)
+}
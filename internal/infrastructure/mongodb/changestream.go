@@ -0,0 +1,133 @@
+package mongodb
+
+import (
+	"context"
+	"errors"
+	"github.com/rs/zerolog/log"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"time"
+	timeutil "userservice/internal/util/time"
+)
+
+const (
+	leaseKindResumeToken = "resume_token"
+	// resumeTokenID is shared by all replicas, the notifications only wake up the outbox so it does not matter who
+	// saw a change first
+	resumeTokenID = leaseKindResumeToken + "/outbox"
+)
+
+const (
+	// resumeTokenSaveInterval bounds the writes to the shared resume token while changes keep coming in, a restarted
+	// service replays the changes since the last save, which only wakes up the outbox a few times too often
+	resumeTokenSaveInterval = 5 * time.Second
+	resumeTokenSaveTimeout  = 5 * time.Second
+)
+
+var errChangeStreamInvalidated = errors.New("outbox change stream was invalidated, the collection was dropped or renamed")
+
+type ResumeToken struct {
+	ID        string    `bson:"_id"`
+	Kind      string    `bson:"kind"`
+	Token     bson.Raw  `bson:"token"`
+	UpdatedAt time.Time `bson:"updated_at"`
+}
+
+// changesThatMakeMessagesDue are inserts, requeued messages and finished messages, which let the next message with the
+// same key through
+var changesThatMakeMessagesDue = mongo.Pipeline{
+	{{"$match", bson.D{{"$or", bson.A{
+		bson.D{{"operationType", "insert"}},
+		bson.D{
+			{"operationType", "update"},
+			{"updateDescription.updatedFields.state", bson.D{{"$in", bson.A{StateWaiting, StateFinished}}}},
+		},
+	}}}}},
+}
+
+func (c *Connection) loadResumeToken(ctx context.Context) (bson.Raw, error) {
+	var resumeToken ResumeToken
+	err := c.leaseCollection.FindOne(ctx, bson.D{{"_id", resumeTokenID}}).Decode(&resumeToken)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return resumeToken.Token, nil
+}
+
+func (c *Connection) saveResumeToken(ctx context.Context, token bson.Raw) error {
+	update := bson.D{{"$set", bson.D{
+		{"kind", leaseKindResumeToken},
+		{"token", token},
+		{"updated_at", timeutil.DBNow()},
+	}}}
+	_, err := c.leaseCollection.UpdateOne(ctx, bson.D{{"_id", resumeTokenID}}, update, options.Update().SetUpsert(true))
+	return err
+}
+
+func (c *Connection) openChangeStream(ctx context.Context) (*mongo.ChangeStream, error) {
+	token, err := c.loadResumeToken(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if token != nil {
+		stream, err := c.outboxCollection.Watch(ctx, changesThatMakeMessagesDue, options.ChangeStream().SetResumeAfter(token))
+		if err == nil {
+			return stream, nil
+		}
+		// the token fell out of the oplog, polling picks up whatever happened in between
+		log.Warn().Err(err).Msg("failed to resume the outbox change stream, starting from now")
+	}
+	return c.outboxCollection.Watch(ctx, changesThatMakeMessagesDue)
+}
+
+// WatchMessages calls notify for every change that can make a message due, and stores how far it got so a restarted
+// service continues where it left off. The position is stored at most every resumeTokenSaveInterval and once more when
+// the stream ends
+func (c *Connection) WatchMessages(ctx context.Context, notify func()) error {
+	stream, err := c.openChangeStream(ctx)
+	if err != nil {
+		return err
+	}
+	defer stream.Close(context.Background())
+
+	var unsavedToken bson.Raw
+	var savedAt time.Time
+	for stream.Next(ctx) {
+		notify()
+		unsavedToken = stream.ResumeToken()
+		if time.Since(savedAt) < resumeTokenSaveInterval {
+			continue
+		}
+		c.trySaveResumeToken(ctx, unsavedToken)
+		unsavedToken = nil
+		savedAt = time.Now()
+	}
+	if stream.Err() != nil || ctx.Err() != nil {
+		if unsavedToken != nil {
+			// ctx is done already when the service shuts down
+			saveCtx, cancel := context.WithTimeout(context.Background(), resumeTokenSaveTimeout)
+			defer cancel()
+			c.trySaveResumeToken(saveCtx, unsavedToken)
+		}
+		return stream.Err()
+	}
+
+	// resuming would only replay the invalidation, so the next stream starts from scratch
+	_, err = c.leaseCollection.DeleteOne(ctx, bson.D{{"_id", resumeTokenID}})
+	if err != nil {
+		log.Warn().Err(err).Msg("failed to delete the outbox change stream resume token")
+	}
+	return errChangeStreamInvalidated
+}
+
+func (c *Connection) trySaveResumeToken(ctx context.Context, token bson.Raw) {
+	err := c.saveResumeToken(ctx, token)
+	if err != nil {
+		log.Warn().Err(err).Msg("failed to save the outbox change stream resume token")
+	}
+}
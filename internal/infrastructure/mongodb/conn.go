@@ -5,26 +5,45 @@ import (
 	"github.com/rs/zerolog/log"
 	"go.mongodb.org/mongo-driver/mongo"
 	"userservice/internal/config"
+	"userservice/internal/infrastructure/cloudevents"
+	"userservice/internal/infrastructure/serialization"
 )
 
 type Connection struct {
 	client           *mongo.Client
 	usersCollection  *mongo.Collection
 	outboxCollection *mongo.Collection
-	dbConfig         config.DatabaseConfig
-	kafkaConfig      config.KafkaConfig
+	leaseCollection  *mongo.Collection
+	// webhook subscriptions and their delivery log
+	webhookSubscriptionCollection *mongo.Collection
+	webhookDeliveryCollection     *mongo.Collection
+	// ids of the consumed commands
+	processedCommandCollection *mongo.Collection
+	dbConfig                   config.DatabaseConfig
+	kafkaConfig                config.KafkaConfig
+	webhookConfig              config.WebhookConfig
+	serializer                 serialization.Serializer
+	// wraps the events in cloud events, for the topics configured for it
+	envelope *cloudevents.Envelope
 }
 
-func NewMongoDBConnection(client *mongo.Client, dbConfig config.DatabaseConfig, kafkaConfig config.KafkaConfig) *Connection {
+func NewMongoDBConnection(client *mongo.Client, dbConfig config.DatabaseConfig, kafkaConfig config.KafkaConfig, webhookConfig config.WebhookConfig, serializer serialization.Serializer, envelope *cloudevents.Envelope) *Connection {
 
 	appDB := client.Database(dbConfig.DatabaseName)
 
 	return &Connection{
-		client:           client,
-		usersCollection:  appDB.Collection(dbConfig.UserCollectionName),
-		outboxCollection: appDB.Collection(dbConfig.KafkaOutboxCollectionName),
-		dbConfig:         dbConfig,
-		kafkaConfig:      kafkaConfig,
+		client:                        client,
+		usersCollection:               appDB.Collection(dbConfig.UserCollectionName),
+		outboxCollection:              appDB.Collection(dbConfig.KafkaOutboxCollectionName),
+		leaseCollection:               appDB.Collection(dbConfig.KafkaOutboxLeaseCollectionName),
+		webhookSubscriptionCollection: appDB.Collection(dbConfig.WebhookSubscriptionCollectionName),
+		webhookDeliveryCollection:     appDB.Collection(dbConfig.WebhookDeliveryCollectionName),
+		processedCommandCollection:    appDB.Collection(dbConfig.ProcessedCommandCollectionName),
+		dbConfig:                      dbConfig,
+		kafkaConfig:                   kafkaConfig,
+		webhookConfig:                 webhookConfig,
+		serializer:                    serializer,
+		envelope:                      envelope,
 	}
 }
 
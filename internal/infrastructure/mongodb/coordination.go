@@ -0,0 +1,104 @@
+package mongodb
+
+import (
+	"context"
+	"fmt"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"time"
+	timeutil "userservice/internal/util/time"
+)
+
+const (
+	leaseKindMember    = "member"
+	leaseKindPartition = "partition"
+)
+
+// Lease is either a replica announcing that it runs the outbox, or a replica owning an outbox partition
+type Lease struct {
+	ID        string    `bson:"_id"`
+	Kind      string    `bson:"kind"`
+	Owner     string    `bson:"owner"`
+	Partition int       `bson:"partition,omitempty"`
+	ExpiresAt time.Time `bson:"expires_at"`
+}
+
+func memberLeaseID(owner string) string {
+	return fmt.Sprintf("%s/%s", leaseKindMember, owner)
+}
+
+func partitionLeaseID(partition int) string {
+	return fmt.Sprintf("%s/%d", leaseKindPartition, partition)
+}
+
+func (c *Connection) Heartbeat(ctx context.Context, owner string, ttl time.Duration) error {
+	update := bson.D{{"$set", bson.D{
+		{"kind", leaseKindMember},
+		{"owner", owner},
+		{"expires_at", timeutil.DBNow().Add(ttl)},
+	}}}
+	_, err := c.leaseCollection.UpdateOne(ctx, bson.D{{"_id", memberLeaseID(owner)}}, update, options.Update().SetUpsert(true))
+	return err
+}
+
+func (c *Connection) LiveMembers(ctx context.Context) ([]string, error) {
+	filter := bson.D{
+		{"kind", leaseKindMember},
+		{"expires_at", bson.D{{"$gt", timeutil.DBNow()}}},
+	}
+	cursor, err := c.leaseCollection.Find(ctx, filter, options.Find().SetSort(bson.D{{"owner", 1}}))
+	if err != nil {
+		return nil, err
+	}
+	var leases []Lease
+	err = cursor.All(ctx, &leases)
+	if err != nil {
+		return nil, err
+	}
+
+	members := make([]string, 0, len(leases))
+	for _, lease := range leases {
+		members = append(members, lease.Owner)
+	}
+	return members, nil
+}
+
+// AcquirePartition takes over or renews the lease of a partition, it fails without error while another replica holds
+// an unexpired lease on it
+func (c *Connection) AcquirePartition(ctx context.Context, partition int, owner string, ttl time.Duration) (bool, error) {
+	filter := bson.D{
+		{"_id", partitionLeaseID(partition)},
+		{"$or", bson.A{
+			bson.D{{"owner", owner}},
+			bson.D{{"expires_at", bson.D{{"$lt", timeutil.DBNow()}}}},
+		}},
+	}
+	update := bson.D{{"$set", bson.D{
+		{"kind", leaseKindPartition},
+		{"owner", owner},
+		{"partition", partition},
+		{"expires_at", timeutil.DBNow().Add(ttl)},
+	}}}
+
+	// when the filter does not match because someone else holds the lease, the upsert collides with their document
+	_, err := c.leaseCollection.UpdateOne(ctx, filter, update, options.Update().SetUpsert(true))
+	if mongo.IsDuplicateKeyError(err) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+func (c *Connection) ReleasePartition(ctx context.Context, partition int, owner string) error {
+	_, err := c.leaseCollection.DeleteOne(ctx, bson.D{{"_id", partitionLeaseID(partition)}, {"owner", owner}})
+	return err
+}
+
+// Leave drops the member lease and all partitions of owner, so the other replicas do not have to wait for them to expire
+func (c *Connection) Leave(ctx context.Context, owner string) error {
+	_, err := c.leaseCollection.DeleteMany(ctx, bson.D{{"owner", owner}})
+	return err
+}
@@ -6,25 +6,44 @@ import (
 	"errors"
 	"github.com/rs/zerolog/log"
 	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
 	"go.mongodb.org/mongo-driver/mongo"
 	"go.mongodb.org/mongo-driver/mongo/options"
-	"math"
-	"math/rand"
+	"slices"
 	"time"
 	"userservice/internal/infrastructure/messaging"
-	"userservice/internal/infrastructure/outbox"
 	timeutil "userservice/internal/util/time"
 )
 
 type Message struct {
-	MsgID     string    `bson:"msg_id"` // have an id that is easily accessed without needing to deserialize data
+	MongoDBID primitive.ObjectID `bson:"_id,omitempty"`
+	MsgID     string             `bson:"msg_id"` // have an id that is easily accessed without needing to deserialize data
+	Topic     string             `bson:"topic,omitempty"`
+	Key       string             `bson:"key,omitempty"`
+	// Partition decides which replica sends the message, see messaging.PartitionForKey
+	Partition int       `bson:"partition"`
 	Data      []byte    `bson:"data"`
+	CreatedAt time.Time `bson:"created_at,omitempty"`
 	NextRetry time.Time `bson:"next_retry"`
 	SentAt    time.Time `bson:"sent_at"`
 	Retries   int64     `bson:"retries"`
 	State     string    `bson:"state"`
+	// LastError is the reason of the latest failed delivery attempt
+	LastError      string    `bson:"last_error,omitempty"`
+	DeadLetteredAt time.Time `bson:"dead_lettered_at,omitempty"`
+	// LeaseOwner is the outbox instance that claimed the message, it owns it until LeaseExpiresAt
+	LeaseOwner     string    `bson:"lease_owner,omitempty"`
+	LeaseExpiresAt time.Time `bson:"lease_expires_at,omitempty"`
 }
 
+// defaultMaxAttempts is used when no maxAttempts is configured
+const defaultMaxAttempts = 10
+
+var errLeaseExpired = errors.New("lease expired before the delivery was confirmed")
+
+// releaseLease clears the claim of a message that is no longer processing
+var releaseLease = bson.E{Key: "$unset", Value: bson.D{{"lease_owner", ""}, {"lease_expires_at", ""}}}
+
 type MessageState string
 
 func (k MessageState) String() string {
@@ -35,145 +54,311 @@ const (
 	StateWaiting    MessageState = "waiting"
 	StateProcessing MessageState = "processing"
 	StateFinished   MessageState = "finished"
+	// StateDeadLettered messages ran out of attempts and wait for an operator to requeue or discard them
+	StateDeadLettered MessageState = "dead_lettered"
 )
 
-func calculateNextAttempt(baseTimeSeconds int64, maxTimeSeconds int64, retryCount int) time.Time {
-
-	maxTime := time.Second * time.Duration(maxTimeSeconds)
-	// exponential backoff based on retryCount
-	backoffDuration := time.Duration(float64(baseTimeSeconds) * math.Pow(2, float64(retryCount)))
-	if backoffDuration > maxTime {
-		backoffDuration = maxTime
+func (c *Connection) maxAttempts() int64 {
+	if c.kafkaConfig.Outbox.MaxAttempts <= 0 {
+		return defaultMaxAttempts
 	}
+	return int64(c.kafkaConfig.Outbox.MaxAttempts)
+}
 
+// backoffExpression is when the next attempt is due, as expression for pipeline updates. The backoff is exponential in
+// the attempt count, which attempts evaluates to, and capped at maxTimeSeconds
+func backoffExpression(baseTimeSeconds int64, maxTimeSeconds int64, attempts any) bson.D {
+	backoffMillis := bson.D{{"$min", bson.A{
+		bson.D{{"$multiply", bson.A{baseTimeSeconds * 1000, bson.D{{"$pow", bson.A{2, attempts}}}}}},
+		maxTimeSeconds * 1000,
+	}}}
 	// add jitter to prevent thundering herd problem
-	jitter := time.Duration(rand.Int63n(int64(backoffDuration)))
-
-	return time.Now().Add(jitter)
+	jitterMillis := bson.D{{"$toLong", bson.D{{"$multiply", bson.A{bson.D{{"$rand", bson.D{}}}, backoffMillis}}}}}
+	return bson.D{{"$add", bson.A{"$$NOW", jitterMillis}}}
 }
-func (c *Connection) setState(ctx context.Context, msgID string, state MessageState) (Message, error) {
 
-	upsert := false
-	returnDocument := options.After
-	opts := options.FindOneAndUpdateOptions{
-		Upsert:         &upsert,
-		ReturnDocument: &returnDocument,
+// failedAttemptPipeline counts a failed attempt and either schedules the next attempt at nextRetry or dead letters the
+// message when it ran out of attempts, nextRetry can refer to the incremented $retries
+func (c *Connection) failedAttemptPipeline(cause error, nextRetry any) mongo.Pipeline {
+	outOfAttempts := bson.D{{"$gte", bson.A{"$retries", c.maxAttempts()}}}
+	return mongo.Pipeline{
+		{{"$set", bson.D{
+			{"retries", bson.D{{"$add", bson.A{"$retries", 1}}}},
+			// literal, so an error starting with $ is not taken for a field path
+			{"last_error", bson.D{{"$literal", cause.Error()}}},
+		}}},
+		{{"$set", bson.D{
+			{"state", bson.D{{"$cond", bson.A{outOfAttempts, StateDeadLettered, StateWaiting}}}},
+			{"dead_lettered_at", bson.D{{"$cond", bson.A{outOfAttempts, "$$NOW", "$dead_lettered_at"}}}},
+			{"next_retry", bson.D{{"$cond", bson.A{outOfAttempts, "$next_retry", nextRetry}}}},
+		}}},
+		{{"$unset", bson.A{"lease_owner", "lease_expires_at"}}},
 	}
+}
 
-	setFilter := bson.D{{"$set", bson.D{{"state", state.String()}}}}
+// recordFailedAttempt counts a failed attempt of a message that owner is still processing, in a single update so a
+// message that was reclaimed in the meantime is left to its new owner. It returns mongo.ErrNoDocuments then
+func (c *Connection) recordFailedAttempt(ctx context.Context, owner string, msgID string, cause error) (Message, error) {
+	filter := bson.D{
+		{"msg_id", msgID},
+		{"state", StateProcessing},
+		{"lease_owner", owner},
+	}
+	nextRetry := backoffExpression(c.kafkaConfig.Outbox.BaseRetryTimeSeconds, c.kafkaConfig.Outbox.MaxRetryTimeSeconds, "$retries")
+	opts := options.FindOneAndUpdate().SetReturnDocument(options.After)
 
 	var updatedMessage Message
-	err := c.outboxCollection.FindOneAndUpdate(ctx, bson.D{{"msg_id", msgID}}, setFilter, &opts).Decode(&updatedMessage)
+	err := c.outboxCollection.FindOneAndUpdate(ctx, filter, c.failedAttemptPipeline(cause, nextRetry), opts).Decode(&updatedMessage)
 	if err != nil {
 		return Message{}, err
 	}
+	if updatedMessage.State == StateDeadLettered.String() {
+		log.Error().Err(cause).Msgf("message %s failed %d times, dead lettering it", msgID, updatedMessage.Retries)
+	}
 	return updatedMessage, nil
 }
 
-func (c *Connection) incrementNumberOfRetries(ctx context.Context, msgID string) (Message, error) {
-
-	// first find the document
-	// then update the document with the right values (retries,state,next_retry)
-
-	findFilter := bson.D{{"msg_id", msgID}}
-	message := c.outboxCollection.FindOne(ctx, findFilter)
-	var decoded Message
-	err := message.Decode(&decoded)
+// deadLetterMessage skips the remaining attempts, used for messages that can never be delivered
+func (c *Connection) deadLetterMessage(ctx context.Context, msgID string, cause error) {
+	setFilter := bson.D{{"$set", bson.D{
+		{"state", StateDeadLettered},
+		{"last_error", cause.Error()},
+		{"dead_lettered_at", timeutil.DBNow()},
+	}}, releaseLease}
+	_, err := c.outboxCollection.UpdateOne(ctx, bson.D{{"msg_id", msgID}}, setFilter)
 	if err != nil {
-		return Message{}, err
-	}
-	if decoded.State != StateProcessing.String() {
-		return Message{}, err
-	}
-	attempts := decoded.Retries + 1
-	a := bson.D{}
-	a = append(a, bson.E{Key: "retries", Value: attempts})
-	a = append(a, bson.E{Key: "next_retry", Value: calculateNextAttempt(c.kafkaConfig.Outbox.BaseRetryTimeSeconds, c.kafkaConfig.Outbox.MaxRetryTimeSeconds, int(attempts))})
-
-	upsert := false
-	returnDocument := options.After
-	opts := options.FindOneAndUpdateOptions{
-		Upsert:         &upsert,
-		ReturnDocument: &returnDocument,
-	}
-
-	var updatedMessage Message
-	err = c.outboxCollection.FindOneAndUpdate(ctx, findFilter, a, &opts).Decode(&updatedMessage)
-	if err != nil {
-		return Message{}, err
+		log.Error().Err(err).Msgf("failed to dead letter message %s", msgID)
 	}
-	return updatedMessage, nil
 }
 
-func (c *Connection) GetPendingMessage(ctx context.Context) (messaging.KafkaInternalMessage, error) {
+// ClaimPendingMessages moves up to limit due messages of the given partitions from waiting to processing in one
+// transaction, so concurrent claimers never receive the same message. The claimed messages are leased to owner for
+// leaseDuration.
+// Only the oldest unfinished message of every key can be claimed, so the messages of a user are sent one after the
+// other in the order they were stored, even when their partition moves to another replica in between. A dead lettered
+// message holds up the messages of its key until it is requeued or discarded
+func (c *Connection) ClaimPendingMessages(ctx context.Context, owner string, leaseDuration time.Duration, partitions []int, limit int) ([]messaging.KafkaInternalMessage, error) {
+	if len(partitions) == 0 {
+		return nil, nil
+	}
 
-	var pendingMessage Message
+	var claimedMessages []Message
 	err := c.executeInTransaction(ctx, func(sessionContext mongo.SessionContext) error {
 
-		// find message with correct state:
-		findFilter := bson.D{{"$and", bson.A{
-			bson.D{{"state", StateWaiting}},
-			bson.D{{"next_retry", bson.D{{"$lt", time.Now().UTC()}}}},
-		}}}
-
-		setFilter := bson.D{{
-			"$set", bson.D{{"state", StateProcessing}}},
+		// find the ids of the messages that are due and first in line for their key, oldest first
+		msgIDs, err := c.findHeadsOfLine(sessionContext, partitions, limit)
+		if err != nil {
+			return err
+		}
+		if len(msgIDs) == 0 {
+			return nil
 		}
 
-		opts := options.FindOneAndUpdate().SetReturnDocument(options.After)
-		var processingMessage Message
-		err := c.outboxCollection.FindOneAndUpdate(sessionContext, findFilter, setFilter, opts).Decode(&processingMessage)
+		// then flip them to processing, the state check guards against messages claimed in the meantime
+		claimFilter := bson.D{
+			{"msg_id", bson.D{{"$in", msgIDs}}},
+			{"state", StateWaiting},
+		}
+		setFilter := bson.D{{"$set", bson.D{
+			{"state", StateProcessing},
+			{"lease_owner", owner},
+			{"lease_expires_at", timeutil.DBNow().Add(leaseDuration)},
+		}}}
+		_, err = c.outboxCollection.UpdateMany(sessionContext, claimFilter, setFilter)
 		if err != nil {
-			if errors.Is(err, mongo.ErrNoDocuments) {
-				return outbox.ErrNoPendingMessage
-			}
 			return err
 		}
-		// then we set the retry fields and next_attempt_at
-		_, err = c.incrementNumberOfRetries(sessionContext, processingMessage.MsgID)
-		pendingMessage, err = c.setState(sessionContext, processingMessage.MsgID, StateProcessing)
+
+		cursor, err := c.outboxCollection.Find(sessionContext, bson.D{
+			{"msg_id", bson.D{{"$in", msgIDs}}},
+			{"state", StateProcessing},
+			{"lease_owner", owner},
+		}, options.Find().SetSort(bson.D{{"next_retry", 1}}))
 		if err != nil {
 			return err
 		}
-
-		return nil
+		return cursor.All(sessionContext, &claimedMessages)
 	})
 	if err != nil {
-		return messaging.KafkaInternalMessage{}, err
+		return nil, err
 	}
 
-	var toSend messaging.KafkaInternalMessage
-	err = json.Unmarshal(pendingMessage.Data, &toSend)
-	if err != nil {
-		return messaging.KafkaInternalMessage{}, err
+	toSend := make([]messaging.KafkaInternalMessage, 0, len(claimedMessages))
+	for _, claimedMessage := range claimedMessages {
+		var decoded messaging.KafkaInternalMessage
+		err = json.Unmarshal(claimedMessage.Data, &decoded)
+		if err != nil {
+			log.Error().Err(err).Msgf("failed to decode outbox message %s", claimedMessage.MsgID)
+			c.deadLetterMessage(ctx, claimedMessage.MsgID, err)
+			continue
+		}
+		toSend = append(toSend, decoded)
 	}
 	return toSend, nil
 }
-func (c *Connection) RetryMessage(ctx context.Context, id string) {
-	err := c.executeInTransaction(ctx, func(sessionContext mongo.SessionContext) error {
-		_, err := c.incrementNumberOfRetries(sessionContext, id)
-		if err != nil {
-			return err
-		}
-		_, err = c.setState(sessionContext, id, StateWaiting)
-		if err != nil {
-			return err
-		}
-		return nil
-	})
-
+func (c *Connection) RetryMessage(ctx context.Context, owner string, id string, cause error) bool {
+	updatedMessage, err := c.recordFailedAttempt(ctx, owner, id, cause)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		log.Warn().Msgf("message %s is no longer processed by %s, leaving it to its new owner", id, owner)
+		return false
+	}
 	if err != nil {
 		log.Warn().Err(err).Msgf("failed to retry message %s", id)
+		return false
 	}
+	return updatedMessage.State == StateWaiting.String()
 }
 func (c *Connection) MarkMessageSent(ctx context.Context, id string) {
 	a := bson.D{}
 	a = append(a, bson.E{Key: "sent_at", Value: timeutil.DBNow()})
 	a = append(a, bson.E{Key: "state", Value: StateFinished})
-	setFilter := bson.D{{"$set", a}}
+	setFilter := bson.D{{"$set", a}, releaseLease}
 
 	update := c.outboxCollection.FindOneAndUpdate(ctx, bson.D{{"msg_id", id}}, setFilter)
 	if update.Err() != nil {
 		log.Error().Err(update.Err()).Msgf("failed to mark message %s as sent", id)
 	}
 }
+
+// inPartitions matches the messages of the given partitions
+func inPartitions(partitions []int) bson.E {
+	partitionFilter := bson.A{bson.D{{"partition", bson.D{{"$in", partitions}}}}}
+	if slices.Contains(partitions, 0) {
+		// messages stored before partitions existed belong to the first partition
+		partitionFilter = append(partitionFilter, bson.D{{"partition", bson.D{{"$exists", false}}}})
+	}
+	return bson.E{Key: "$or", Value: partitionFilter}
+}
+
+// maxHeadOfLineRounds bounds the queries made by a claim, every round leaves out the keys that were found blocked
+// before, so a long line of messages behind a failing one does not hide the messages of other keys
+const maxHeadOfLineRounds = 4
+
+// unfinishedStates are the states that keep the younger messages of a key waiting. Dead lettered messages are still in
+// line, the messages after them are only sent once they were requeued and sent, or discarded
+var unfinishedStates = bson.A{StateWaiting, StateProcessing, StateDeadLettered}
+
+// findHeadsOfLine returns the ids of up to limit due messages that have no older unfinished message with the same key,
+// due first. The due messages are found with the {state, partition, next_retry} index and every candidate is checked
+// with the {key, state, created_at} index, so a claim does not depend on the size of the backlog
+func (c *Connection) findHeadsOfLine(ctx context.Context, partitions []int, limit int) ([]string, error) {
+	// empty instead of nil, $nin needs an array
+	msgIDs := []string{}
+	skippedKeys := bson.A{}
+	for round := 0; round < maxHeadOfLineRounds && len(msgIDs) < limit; round++ {
+		filter := bson.D{
+			{"state", StateWaiting},
+			inPartitions(partitions),
+			{"next_retry", bson.D{{"$lt", timeutil.DBNow()}}},
+			{"key", bson.D{{"$nin", skippedKeys}}},
+			{"msg_id", bson.D{{"$nin", msgIDs}}},
+		}
+		findOptions := options.Find().
+			SetSort(bson.D{{"next_retry", 1}}).
+			SetLimit(int64(limit - len(msgIDs))).
+			SetProjection(bson.D{{"msg_id", 1}, {"key", 1}, {"created_at", 1}})
+		cursor, err := c.outboxCollection.Find(ctx, filter, findOptions)
+		if err != nil {
+			return nil, err
+		}
+		var candidates []Message
+		err = cursor.All(ctx, &candidates)
+		if err != nil {
+			return nil, err
+		}
+		if len(candidates) == 0 {
+			break
+		}
+
+		for _, candidate := range candidates {
+			// messages without key have no order to keep
+			if candidate.Key != "" {
+				if !slices.Contains(skippedKeys, any(candidate.Key)) {
+					skippedKeys = append(skippedKeys, candidate.Key)
+				}
+				isHead, err := c.isHeadOfLine(ctx, candidate)
+				if err != nil {
+					return nil, err
+				}
+				if !isHead {
+					continue
+				}
+			}
+			msgIDs = append(msgIDs, candidate.MsgID)
+		}
+	}
+	return msgIDs, nil
+}
+
+// isHeadOfLine tells whether no unfinished message of the same key was stored before message, messages stored in the
+// same millisecond are ordered by their object id
+func (c *Connection) isHeadOfLine(ctx context.Context, message Message) (bool, error) {
+	olderFilter := bson.D{
+		{"key", message.Key},
+		{"state", bson.D{{"$in", unfinishedStates}}},
+		{"$or", bson.A{
+			bson.D{{"created_at", bson.D{{"$lt", message.CreatedAt}}}},
+			bson.D{{"created_at", message.CreatedAt}, {"_id", bson.D{{"$lt", message.MongoDBID}}}},
+		}},
+	}
+	err := c.outboxCollection.FindOne(ctx, olderFilter, options.FindOne().SetProjection(bson.D{{"_id", 1}})).Err()
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return true, nil
+	}
+	return false, err
+}
+
+// ReleaseExpiredLeases gives processing messages whose owner did not report back in time back to the outbox. The lost
+// delivery counts as a failed attempt, so a message that keeps crashing its owner is dead lettered eventually
+func (c *Connection) ReleaseExpiredLeases(ctx context.Context) (int64, error) {
+	// processing messages without a lease were claimed before leases existed and are stuck for good
+	filter := bson.D{
+		{"state", StateProcessing},
+		{"$or", bson.A{
+			bson.D{{"lease_expires_at", bson.D{{"$lt", timeutil.DBNow()}}}},
+			bson.D{{"lease_expires_at", bson.D{{"$exists", false}}}},
+		}},
+	}
+	// the message is due again right away, unless it ran out of attempts like any other failed message
+	update := c.failedAttemptPipeline(errLeaseExpired, "$$NOW")
+	result, err := c.outboxCollection.UpdateMany(ctx, filter, update)
+	if err != nil {
+		return 0, err
+	}
+	return result.ModifiedCount, nil
+}
+
+func (c *Connection) FindFinishedMessages(ctx context.Context, partitions []int, sentBefore time.Time, limit int) ([]messaging.OutboxEntry, error) {
+	filter := bson.D{
+		{"state", StateFinished},
+		inPartitions(partitions),
+		{"sent_at", bson.D{{"$lt", sentBefore}}},
+	}
+	findOptions := options.Find().SetSort(bson.D{{"sent_at", 1}}).SetLimit(int64(limit))
+	cursor, err := c.outboxCollection.Find(ctx, filter, findOptions)
+	if err != nil {
+		return nil, err
+	}
+	var messages []Message
+	err = cursor.All(ctx, &messages)
+	if err != nil {
+		return nil, err
+	}
+
+	entries := make([]messaging.OutboxEntry, 0, len(messages))
+	for _, message := range messages {
+		entries = append(entries, toOutboxEntry(message))
+	}
+	return entries, nil
+}
+
+func (c *Connection) DeleteFinishedMessages(ctx context.Context, ids []string) (int64, error) {
+	result, err := c.outboxCollection.DeleteMany(ctx, bson.D{
+		{"msg_id", bson.D{{"$in", ids}}},
+		{"state", StateFinished},
+	})
+	if err != nil {
+		return 0, err
+	}
+	return result.DeletedCount, nil
+}
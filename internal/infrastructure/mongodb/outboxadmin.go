@@ -0,0 +1,369 @@
+package mongodb
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"slices"
+	"userservice/internal/domain/domainerror"
+	"userservice/internal/infrastructure/messaging"
+	timeutil "userservice/internal/util/time"
+)
+
+var errOutboxMessageNotFound = domainerror.NotFound("outbox message not found")
+var errOutboxMessageNotDeadLettered = domainerror.FailedPrecondition("outbox message is not dead lettered")
+var errOutboxMessageNotWaiting = domainerror.FailedPrecondition("only waiting outbox messages can be retried")
+var errOutboxMessageNotFinished = domainerror.FailedPrecondition("only finished outbox messages can be republished")
+var errNewerOutboxMessages = domainerror.FailedPrecondition("newer messages of the key are in the outbox, the republished message would be sent after them")
+var errInvalidOutboxCursor = domainerror.InvalidField("paging.cursor", "cursor is not valid")
+var errInvalidOutboxState = domainerror.InvalidField("state", "state is not one of waiting, processing, finished or dead_lettered")
+
+func toOutboxEntry(message Message) messaging.OutboxEntry {
+	entry := messaging.OutboxEntry{
+		ID:             message.MsgID,
+		Topic:          message.Topic,
+		Key:            message.Key,
+		Partition:      message.Partition,
+		State:          message.State,
+		Retries:        message.Retries,
+		LastError:      message.LastError,
+		CreatedAt:      message.CreatedAt,
+		NextRetry:      message.NextRetry,
+		SentAt:         message.SentAt,
+		DeadLetteredAt: message.DeadLetteredAt,
+		LeaseOwner:     message.LeaseOwner,
+		LeaseExpiresAt: message.LeaseExpiresAt,
+	}
+
+	// the data holds the full kafka message, messages stored before topic and key were added only have it there
+	var decoded messaging.KafkaInternalMessage
+	if json.Unmarshal(message.Data, &decoded) == nil {
+		entry.Value = decoded.Value
+		entry.Headers = decoded.Headers
+		if entry.Topic == "" {
+			entry.Topic = decoded.TopicID
+		}
+		if entry.Key == "" {
+			entry.Key = string(decoded.Key)
+		}
+	}
+	return entry
+}
+
+func (c *Connection) ListDeadLetteredMessages(ctx context.Context, limit int64, cursor string) ([]messaging.OutboxEntry, string, error) {
+	return c.findOutboxPage(ctx, bson.D{{"state", StateDeadLettered}}, limit, cursor)
+}
+
+// findOutboxPage returns the messages matching filter after cursor in the order they were inserted
+func (c *Connection) findOutboxPage(ctx context.Context, filter bson.D, limit int64, cursor string) ([]messaging.OutboxEntry, string, error) {
+
+	if cursor != "" {
+		lastID, err := primitive.ObjectIDFromHex(cursor)
+		if err != nil {
+			return nil, "", errInvalidOutboxCursor
+		}
+		filter = append(filter, bson.E{Key: "_id", Value: bson.D{{"$gt", lastID}}})
+	}
+
+	usedLimit := c.getUsedLimit(limit)
+	findOptions := options.Find().SetSort(bson.D{{"_id", 1}}).SetLimit(usedLimit)
+	findResult, err := c.outboxCollection.Find(ctx, filter, findOptions)
+	if err != nil {
+		return nil, "", err
+	}
+	defer findResult.Close(ctx)
+
+	var messages []Message
+	err = findResult.All(ctx, &messages)
+	if err != nil {
+		return nil, "", err
+	}
+
+	entries := make([]messaging.OutboxEntry, 0, len(messages))
+	for _, message := range messages {
+		entries = append(entries, toOutboxEntry(message))
+	}
+
+	nextCursor := ""
+	if int64(len(messages)) == usedLimit {
+		nextCursor = messages[len(messages)-1].MongoDBID.Hex()
+	}
+	return entries, nextCursor, nil
+}
+
+func (c *Connection) findOutboxMessage(ctx context.Context, id string) (Message, error) {
+	var message Message
+	err := c.outboxCollection.FindOne(ctx, bson.D{{"msg_id", id}}).Decode(&message)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return Message{}, errOutboxMessageNotFound
+	}
+	if err != nil {
+		return Message{}, err
+	}
+	return message, nil
+}
+
+// explainMissingOutboxMessage tells apart an unknown message from one that is in another state, after a filter on
+// the state matched nothing. wrongState is returned for the latter
+func (c *Connection) explainMissingOutboxMessage(ctx context.Context, id string, wrongState error) error {
+	_, err := c.findOutboxMessage(ctx, id)
+	if err != nil {
+		return err
+	}
+	return wrongState
+}
+
+func (c *Connection) findDeadLetteredMessage(ctx context.Context, id string) (Message, error) {
+	message, err := c.findOutboxMessage(ctx, id)
+	if err != nil {
+		return Message{}, err
+	}
+	if message.State != StateDeadLettered.String() {
+		return Message{}, errOutboxMessageNotDeadLettered
+	}
+	return message, nil
+}
+
+func (c *Connection) GetDeadLetteredMessage(ctx context.Context, id string) (messaging.OutboxEntry, error) {
+	message, err := c.findDeadLetteredMessage(ctx, id)
+	if err != nil {
+		return messaging.OutboxEntry{}, err
+	}
+	return toOutboxEntry(message), nil
+}
+
+func (c *Connection) RequeueDeadLetteredMessage(ctx context.Context, id string) (messaging.OutboxEntry, error) {
+
+	update := bson.D{
+		{"$set", bson.D{
+			{"state", StateWaiting},
+			{"retries", 0},
+			{"next_retry", timeutil.DBNow()},
+		}},
+		{"$unset", bson.D{{"dead_lettered_at", ""}}},
+	}
+	// the state in the filter makes sure that a message is only requeued once
+	return c.updateOutboxMessageInState(ctx, id, StateDeadLettered, update, errOutboxMessageNotDeadLettered)
+}
+
+func (c *Connection) DiscardDeadLetteredMessage(ctx context.Context, id string) (messaging.OutboxEntry, error) {
+
+	var discarded Message
+	err := c.outboxCollection.FindOneAndDelete(ctx, bson.D{{"msg_id", id}, {"state", StateDeadLettered}}).Decode(&discarded)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return messaging.OutboxEntry{}, c.explainMissingOutboxMessage(ctx, id, errOutboxMessageNotDeadLettered)
+	}
+	if err != nil {
+		return messaging.OutboxEntry{}, err
+	}
+	return toOutboxEntry(discarded), nil
+}
+
+func toOutboxQuery(filter messaging.OutboxFilter) (bson.D, error) {
+	query := bson.D{}
+	if filter.State != "" {
+		switch MessageState(filter.State) {
+		case StateWaiting, StateProcessing, StateFinished, StateDeadLettered:
+		default:
+			return nil, errInvalidOutboxState
+		}
+		query = append(query, bson.E{Key: "state", Value: filter.State})
+	}
+	if filter.Topic != "" {
+		query = append(query, bson.E{Key: "topic", Value: filter.Topic})
+	}
+	if filter.Key != "" {
+		query = append(query, bson.E{Key: "key", Value: filter.Key})
+	}
+
+	createdAt := bson.D{}
+	if !filter.CreatedAfter.IsZero() {
+		createdAt = append(createdAt, bson.E{Key: "$gt", Value: filter.CreatedAfter})
+	}
+	if !filter.CreatedBefore.IsZero() {
+		createdAt = append(createdAt, bson.E{Key: "$lt", Value: filter.CreatedBefore})
+	}
+	if len(createdAt) > 0 {
+		query = append(query, bson.E{Key: "created_at", Value: createdAt})
+	}
+	return query, nil
+}
+
+func (c *Connection) ListOutboxMessages(ctx context.Context, filter messaging.OutboxFilter, limit int64, cursor string) ([]messaging.OutboxEntry, string, error) {
+	query, err := toOutboxQuery(filter)
+	if err != nil {
+		return nil, "", err
+	}
+	return c.findOutboxPage(ctx, query, limit, cursor)
+}
+
+func (c *Connection) GetOutboxMessage(ctx context.Context, id string) (messaging.OutboxEntry, error) {
+	message, err := c.findOutboxMessage(ctx, id)
+	if err != nil {
+		return messaging.OutboxEntry{}, err
+	}
+	return toOutboxEntry(message), nil
+}
+
+// updateOutboxMessageInState applies update to the message when it is in state, wrongState is returned when it is not
+func (c *Connection) updateOutboxMessageInState(ctx context.Context, id string, state MessageState, update bson.D, wrongState error) (messaging.OutboxEntry, error) {
+	returnDocument := options.After
+	opts := options.FindOneAndUpdateOptions{ReturnDocument: &returnDocument}
+
+	var updated Message
+	err := c.outboxCollection.FindOneAndUpdate(ctx, bson.D{{"msg_id", id}, {"state", state}}, update, &opts).Decode(&updated)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return messaging.OutboxEntry{}, c.explainMissingOutboxMessage(ctx, id, wrongState)
+	}
+	if err != nil {
+		return messaging.OutboxEntry{}, err
+	}
+	return toOutboxEntry(updated), nil
+}
+
+func (c *Connection) RetryOutboxMessage(ctx context.Context, id string) (messaging.OutboxEntry, error) {
+	// the message is still only sent once the older messages of its key are finished
+	update := bson.D{{"$set", bson.D{{"next_retry", timeutil.DBNow()}}}}
+	return c.updateOutboxMessageInState(ctx, id, StateWaiting, update, errOutboxMessageNotWaiting)
+}
+
+// RepublishOutboxMessage puts a copy of a finished message in the outbox, see messaging.KafkaInternalMessage.Republished.
+// It is refused while newer messages of the same key are in the outbox, consumers would get the old event after them
+func (c *Connection) RepublishOutboxMessage(ctx context.Context, id string) (messaging.OutboxEntry, error) {
+	original, err := c.findOutboxMessage(ctx, id)
+	if err != nil {
+		return messaging.OutboxEntry{}, err
+	}
+	if original.State != StateFinished.String() {
+		return messaging.OutboxEntry{}, errOutboxMessageNotFinished
+	}
+	var msg messaging.KafkaInternalMessage
+	err = json.Unmarshal(original.Data, &msg)
+	if err != nil {
+		return messaging.OutboxEntry{}, err
+	}
+
+	if len(msg.Key) > 0 {
+		newerFilter := bson.D{
+			{"key", string(msg.Key)},
+			{"$or", bson.A{
+				bson.D{{"created_at", bson.D{{"$gt", original.CreatedAt}}}},
+				bson.D{{"created_at", original.CreatedAt}, {"_id", bson.D{{"$gt", original.MongoDBID}}}},
+			}},
+		}
+		err = c.outboxCollection.FindOne(ctx, newerFilter, options.FindOne().SetProjection(bson.D{{"_id", 1}})).Err()
+		if err == nil {
+			return messaging.OutboxEntry{}, errNewerOutboxMessages
+		}
+		if !errors.Is(err, mongo.ErrNoDocuments) {
+			return messaging.OutboxEntry{}, err
+		}
+	}
+
+	republished := msg.Republished()
+	err = c.insertOutboxMessage(ctx, republished)
+	if err != nil {
+		return messaging.OutboxEntry{}, err
+	}
+	return c.GetOutboxMessage(ctx, republished.ID)
+}
+
+type stateCount struct {
+	State string `bson:"_id"`
+	Count int64  `bson:"count"`
+}
+
+func (c *Connection) CountOutboxMessages(ctx context.Context) (map[string]int64, error) {
+	pipeline := mongo.Pipeline{
+		{{"$group", bson.D{{"_id", "$state"}, {"count", bson.D{{"$sum", 1}}}}}},
+	}
+	cursor, err := c.outboxCollection.Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, err
+	}
+	var stateCounts []stateCount
+	err = cursor.All(ctx, &stateCounts)
+	if err != nil {
+		return nil, err
+	}
+
+	counts := make(map[string]int64, len(stateCounts))
+	for _, stateCount := range stateCounts {
+		counts[stateCount.State] = stateCount.Count
+	}
+	return counts, nil
+}
+
+// CountUnfinishedOutboxMessages counts every state but finished with the state indexes, so it is cheap enough to run on
+// every metrics scrape. Finished messages are left out, counting them would mean going through all of them
+func (c *Connection) CountUnfinishedOutboxMessages(ctx context.Context) (map[string]int64, error) {
+	counts := map[string]int64{}
+	for _, state := range []MessageState{StateWaiting, StateProcessing, StateDeadLettered} {
+		count, err := c.outboxCollection.CountDocuments(ctx, bson.D{{"state", state}})
+		if err != nil {
+			return nil, err
+		}
+		counts[state.String()] = count
+	}
+	return counts, nil
+}
+
+// GetOutboxLag counts with the state indexes, instead of grouping all messages like CountOutboxMessages
+func (c *Connection) GetOutboxLag(ctx context.Context) (messaging.OutboxLag, error) {
+	var lag messaging.OutboxLag
+
+	var oldestWaiting Message
+	findOptions := options.FindOne().SetSort(bson.D{{"created_at", 1}}).SetProjection(bson.D{{"created_at", 1}})
+	err := c.outboxCollection.FindOne(ctx, bson.D{{"state", StateWaiting}}, findOptions).Decode(&oldestWaiting)
+	if err != nil && !errors.Is(err, mongo.ErrNoDocuments) {
+		return messaging.OutboxLag{}, err
+	}
+	lag.OldestWaitingCreatedAt = oldestWaiting.CreatedAt
+
+	lag.Processing, err = c.outboxCollection.CountDocuments(ctx, bson.D{{"state", StateProcessing}})
+	if err != nil {
+		return messaging.OutboxLag{}, err
+	}
+	lag.DeadLettered, err = c.outboxCollection.CountDocuments(ctx, bson.D{{"state", StateDeadLettered}})
+	if err != nil {
+		return messaging.OutboxLag{}, err
+	}
+	lag.BlockedKeys, err = c.findBlockedKeys(ctx)
+	if err != nil {
+		return messaging.OutboxLag{}, err
+	}
+	return lag, nil
+}
+
+// maxReportedBlockedKeys bounds the keys in the lag, the dead lettered messages can be listed for the others
+const maxReportedBlockedKeys = 10
+
+// findBlockedKeys returns the keys of the oldest dead lettered messages, their younger messages are held up until the
+// dead lettered ones were requeued and sent or discarded
+func (c *Connection) findBlockedKeys(ctx context.Context) ([]string, error) {
+	findOptions := options.Find().
+		SetSort(bson.D{{"created_at", 1}}).
+		SetLimit(maxReportedBlockedKeys).
+		SetProjection(bson.D{{"key", 1}})
+	cursor, err := c.outboxCollection.Find(ctx, bson.D{{"state", StateDeadLettered}, {"key", bson.D{{"$gt", ""}}}}, findOptions)
+	if err != nil {
+		return nil, err
+	}
+	var deadLettered []Message
+	err = cursor.All(ctx, &deadLettered)
+	if err != nil {
+		return nil, err
+	}
+
+	var keys []string
+	for _, message := range deadLettered {
+		if !slices.Contains(keys, message.Key) {
+			keys = append(keys, message.Key)
+		}
+	}
+	return keys, nil
+}
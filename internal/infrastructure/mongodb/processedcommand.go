@@ -0,0 +1,66 @@
+package mongodb
+
+import (
+	"context"
+	"errors"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"time"
+	"userservice/internal/infrastructure/consumer"
+	timeutil "userservice/internal/util/time"
+)
+
+// ProcessedCommand is stored once a consumed command ran, processed_at has a TTL index so the collection does not
+// grow forever
+type ProcessedCommand struct {
+	CommandID   string    `bson:"_id"`
+	Topic       string    `bson:"topic"`
+	ProcessedAt time.Time `bson:"processed_at"`
+}
+
+func (c *Connection) IsCommandProcessed(ctx context.Context, commandID string) (bool, error) {
+	err := c.processedCommandCollection.FindOne(ctx, bson.D{{"_id", commandID}}).Err()
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+func (c *Connection) MarkCommandProcessed(ctx context.Context, commandID string, topic string) error {
+	_, err := c.processedCommandCollection.InsertOne(ctx, ProcessedCommand{
+		CommandID:   commandID,
+		Topic:       topic,
+		ProcessedAt: timeutil.DBNow(),
+	})
+	if mongo.IsDuplicateKeyError(err) {
+		return nil
+	}
+	return err
+}
+
+// markingProcessedCommand stores the processed command after the change of inner. When the command was marked
+// meanwhile, by another run of it, the transaction is aborted so the change is not made twice
+func (c *Connection) markingProcessedCommand(ctx context.Context, inner func(ctx mongo.SessionContext) error) func(ctx mongo.SessionContext) error {
+	processing, ok := consumer.ProcessingFromContext(ctx)
+	if !ok {
+		return inner
+	}
+	return func(sessionContext mongo.SessionContext) error {
+		err := inner(sessionContext)
+		if err != nil {
+			return err
+		}
+		_, err = c.processedCommandCollection.InsertOne(sessionContext, ProcessedCommand{
+			CommandID:   processing.CommandID,
+			Topic:       processing.Topic,
+			ProcessedAt: timeutil.DBNow(),
+		})
+		if mongo.IsDuplicateKeyError(err) {
+			return consumer.ErrCommandAlreadyProcessed
+		}
+		return err
+	}
+}
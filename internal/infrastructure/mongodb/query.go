@@ -7,6 +7,7 @@ import (
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/bson/primitive"
 	"strings"
+	"userservice/internal/domain/domainerror"
 	"userservice/internal/domain/model/listusers"
 	"userservice/internal/domain/model/updateuser"
 	"userservice/internal/util/crypto"
@@ -19,11 +20,12 @@ var errInvalidComparer = errors.New("invalid comparer")
 var errNoCursor = errors.New("no cursor")
 var errInvalidCursorNoSeparator = errors.New("cursor is missing separator")
 var errInvalidCursorBadMongoID = errors.New("cursor has incorrect mongoid")
-var errNoSorting = errors.New("invalid sort field")
+var errInvalidEmailAddress = domainerror.InvalidField("email", "invalid email address")
+var errInvalidCountryCode = domainerror.InvalidField("country", "invalid country code")
 
 var userFieldToStringMap = map[listusers.UserField]string{
 	listusers.UserFieldFirstName:  "first_name",
-	listusers.UserFieldSecondName: "second_name",
+	listusers.UserFieldSecondName: "last_name",
 	listusers.UserFieldCountry:    "country",
 	listusers.UserFieldEmail:      "email",
 	listusers.UserFieldNickname:   "nickname",
@@ -52,8 +54,8 @@ func comparerString(comparer listusers.Comparer) (string, error) {
 	return parsed, nil
 }
 
-func getPaginationFilterWhenNoSorting(mongoID string) bson.D {
-	if mongoID == "" {
+func getPaginationFilterWhenNoSorting(mongoID primitive.ObjectID) bson.D {
+	if mongoID.IsZero() {
 		return bson.D{}
 	}
 	return bson.D{{"_id", bson.D{{"$gt", mongoID}}}}
@@ -62,7 +64,7 @@ func getPaginationFilterWhenNoSorting(mongoID string) bson.D {
 func getPaginationFilter(rawCursor string, sorting *listusers.SortInfo) (bson.D, error) {
 	mongoID, value, err := deconstructCursor(rawCursor)
 
-	// let's log when getting a bad cursor to make it easier to debug with frontenders
+	// a bad cursor is the caller's mistake, starting over at the first page would make it loop
 	if err != nil && !errors.Is(err, errNoCursor) {
 		return bson.D{}, err
 	}
@@ -74,7 +76,7 @@ func getPaginationFilter(rawCursor string, sorting *listusers.SortInfo) (bson.D,
 
 	sortByFieldString, err := fieldToString(sorting.By)
 	if err != nil {
-		log.Warn().Err(err).Msgf("error creating sorting string %s", sorting.By)
+		log.Warn().Err(err).Msgf("error creating sorting string %d", sorting.By)
 		return getPaginationFilterWhenNoSorting(mongoID), nil
 	}
 
@@ -84,7 +86,7 @@ func getPaginationFilter(rawCursor string, sorting *listusers.SortInfo) (bson.D,
 			bson.D{{sortByFieldString, bson.D{{"$gt", value}}}},
 			bson.D{ // In case of ties, use _id instead
 				{sortByFieldString, value},
-				{"_id", bson.D{{"$gt", rawCursor}}},
+				{"_id", bson.D{{"$gt", mongoID}}},
 			},
 		}},
 	}, nil
@@ -115,22 +117,21 @@ func getFilterFilter(filter *listusers.FilterInfo) (bson.D, error) {
 }
 
 // constructListUsersFilter using the approach found here https://medium.com/swlh/mongodb-pagination-fast-consistent-ece2a97070f3
-// TLDR: keyset pagination using internal mongodb _id for ties, which is possible because of how _id are generated
-func constructListUsersFilter(filter *listusers.FilterInfo, sorting *listusers.SortInfo, rawCursor string) bson.D {
+// TLDR: keyset pagination using internal mongodb _id for ties, which is possible because of how _id are generated. An
+// invalid filter is an error, leaving it out would match every user
+func constructListUsersFilter(filter *listusers.FilterInfo, sorting *listusers.SortInfo, rawCursor string) (bson.D, error) {
 
 	paginationFilter, err := getPaginationFilter(rawCursor, sorting)
-
-	if err != nil && !errors.Is(err, errNoSorting) {
-		log.Warn().Err(err).Msgf("invalid sorting: %s", sorting)
+	if err != nil {
+		return bson.D{}, invalidCursor(err)
 	}
 	if filter == nil {
-		return paginationFilter
+		return paginationFilter, nil
 	}
 
 	filteringFilter, err := getFilterFilter(filter)
-
 	if err != nil {
-		return paginationFilter
+		return bson.D{}, invalidFilter(err)
 	}
 
 	return bson.D{
@@ -138,7 +139,17 @@ func constructListUsersFilter(filter *listusers.FilterInfo, sorting *listusers.S
 			filteringFilter,
 			paginationFilter,
 		}},
-	}
+	}, nil
+}
+
+// invalidCursor is returned for cursors that were not handed out by us, like the outbox and webhook listings do
+func invalidCursor(err error) error {
+	return domainerror.InvalidField("paging.cursor", err.Error())
+}
+
+// invalidFilter tells the caller which part of the filter we could not translate
+func invalidFilter(err error) error {
+	return domainerror.InvalidField("filtering", err.Error())
 }
 
 func constructListUsersSortBy(sortBy *listusers.SortInfo) bson.D {
@@ -169,7 +180,7 @@ func constructUpdateFilter(modifiedUser updateuser.Request, passwordSalt string)
 	}
 	if modifiedUser.Email != nil {
 		if !validation.IsEmailValid(*modifiedUser.Email) {
-			return nil, errors.New("invalid email address")
+			return nil, errInvalidEmailAddress
 		}
 		a = append(a, bson.E{Key: "email", Value: *modifiedUser.Email})
 	}
@@ -179,7 +190,7 @@ func constructUpdateFilter(modifiedUser updateuser.Request, passwordSalt string)
 	if modifiedUser.Country != nil {
 		err := validation.ValidateCountryCode(*modifiedUser.Country)
 		if err != nil {
-			return nil, err
+			return nil, errInvalidCountryCode
 		}
 		a = append(a, bson.E{Key: "country", Value: *modifiedUser.Country})
 	}
@@ -210,7 +221,7 @@ func getValue(user DBUser, field listusers.UserField) string {
 		return user.Country
 	}
 
-	log.Warn().Msgf("unhandled userfield for user value extraction: %s", field)
+	log.Warn().Msgf("unhandled userfield for user value extraction: %d", field)
 	return ""
 }
 
@@ -222,21 +233,21 @@ func constructCursor(user DBUser, sortByField *listusers.SortInfo) string {
 	return fmt.Sprintf("%s:%s", user.MongoDBID.Hex(), sortFilter)
 }
 
-func deconstructCursor(cursor string) (string, string, error) {
+// deconstructCursor splits the cursor in the _id of the last returned user and its value of the sorted field, the _id
+// is parsed, as mongodb does not compare object ids with strings
+func deconstructCursor(cursor string) (primitive.ObjectID, string, error) {
 	if cursor == "" {
-		return "", "", errNoCursor
+		return primitive.NilObjectID, "", errNoCursor
 	}
-	split := strings.Split(cursor, ":")
-	if len(split) != 2 {
-		return "", "", errInvalidCursorNoSeparator
+	rawID, value, found := strings.Cut(cursor, ":")
+	if !found {
+		return primitive.NilObjectID, "", errInvalidCursorNoSeparator
 	}
 
-	rawID, value := split[0], split[1]
-
-	_, err := primitive.ObjectIDFromHex(rawID)
+	mongoID, err := primitive.ObjectIDFromHex(rawID)
 	if err != nil {
-		return "", "", errInvalidCursorBadMongoID
+		return primitive.NilObjectID, "", errInvalidCursorBadMongoID
 	}
 
-	return rawID, value, nil
+	return mongoID, value, nil
 }
@@ -0,0 +1,69 @@
+package mongodb
+
+import (
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"testing"
+	"userservice/internal/domain/domainerror"
+	"userservice/internal/domain/model/listusers"
+)
+
+func TestCursorRoundTrip(t *testing.T) {
+	user := DBUser{MongoDBID: primitive.NewObjectID(), FirstName: "Ada: the first"}
+	sorting := &listusers.SortInfo{By: listusers.UserFieldFirstName}
+
+	mongoID, value, err := deconstructCursor(constructCursor(user, sorting))
+	require.NoError(t, err)
+	require.Equal(t, user.MongoDBID, mongoID)
+	require.Equal(t, user.FirstName, value)
+
+	_, _, err = deconstructCursor("no separator")
+	require.ErrorIs(t, err, errInvalidCursorNoSeparator)
+	_, _, err = deconstructCursor("nothex:")
+	require.ErrorIs(t, err, errInvalidCursorBadMongoID)
+}
+
+func TestPaginationWithoutSortingComparesObjectIDs(t *testing.T) {
+	user := DBUser{MongoDBID: primitive.NewObjectID()}
+
+	filter, err := constructListUsersFilter(nil, nil, constructCursor(user, nil))
+	require.NoError(t, err)
+	require.Equal(t, bson.D{{"_id", bson.D{{"$gt", user.MongoDBID}}}}, filter)
+
+	filter, err = constructListUsersFilter(nil, nil, "")
+	require.NoError(t, err)
+	require.Equal(t, bson.D{}, filter)
+}
+
+func TestPaginationFilterBreaksTiesOnObjectID(t *testing.T) {
+	user := DBUser{MongoDBID: primitive.NewObjectID(), LastName: "Smith"}
+	sorting := &listusers.SortInfo{By: listusers.UserFieldSecondName}
+
+	filter, err := constructListUsersFilter(nil, sorting, constructCursor(user, sorting))
+	require.NoError(t, err)
+	require.Equal(t, bson.D{{"$or", bson.A{
+		bson.D{{"last_name", bson.D{{"$gt", "Smith"}}}},
+		bson.D{{"last_name", "Smith"}, {"_id", bson.D{{"$gt", user.MongoDBID}}}},
+	}}}, filter)
+}
+
+func TestInvalidCursorIsNotDropped(t *testing.T) {
+	for _, cursor := range []string{"no separator", "nothex:Smith"} {
+		_, err := constructListUsersFilter(nil, nil, cursor)
+		var domainErr *domainerror.Error
+		require.ErrorAs(t, err, &domainErr)
+		require.Equal(t, domainerror.KindInvalidArgument, domainErr.Kind)
+		require.Equal(t, "paging.cursor", domainErr.Violations[0].Field)
+	}
+}
+
+func TestInvalidFilterIsNotDropped(t *testing.T) {
+	for _, filter := range []*listusers.FilterInfo{
+		{Left: listusers.UserField(42), Comparer: listusers.ComparerEqual, Right: "DK"},
+		{Left: listusers.UserFieldCountry, Comparer: listusers.Comparer(42), Right: "DK"},
+	} {
+		_, err := constructListUsersFilter(filter, nil, "")
+		require.Equal(t, domainerror.KindInvalidArgument, domainerror.KindOf(err))
+	}
+}
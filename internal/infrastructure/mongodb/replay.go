@@ -0,0 +1,214 @@
+package mongodb
+
+import (
+	"context"
+	"errors"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"time"
+	"userservice/internal/domain/domainerror"
+	"userservice/internal/domain/model/listusers"
+	"userservice/internal/domain/model/replayusers"
+	timeutil "userservice/internal/util/time"
+	"userservice/proto/kafkaschema"
+)
+
+// replay checkpoints live next to the leases and the resume token
+const leaseKindReplayCheckpoint = "replay_checkpoint"
+
+var errReplayNotFound = domainerror.NotFound("replay not found")
+var errReplayAlreadyExists = domainerror.AlreadyExists("replay already exists")
+var errReplayCheckpointMoved = domainerror.FailedPrecondition("replay checkpoint was moved by another run of the replay")
+
+type ReplayCheckpoint struct {
+	ID             string        `bson:"_id"`
+	Kind           string        `bson:"kind"`
+	ReplayID       string        `bson:"replay_id"`
+	Filtering      *ReplayFilter `bson:"filtering,omitempty"`
+	BatchSize      int64         `bson:"batch_size"`
+	UsersPerSecond int64         `bson:"users_per_second"`
+	Cursor         string        `bson:"cursor"`
+	Enqueued       int64         `bson:"enqueued"`
+	StartedAt      time.Time     `bson:"started_at"`
+	UpdatedAt      time.Time     `bson:"updated_at"`
+	FinishedAt     time.Time     `bson:"finished_at,omitempty"`
+}
+
+type ReplayFilter struct {
+	Left     listusers.UserField `bson:"left"`
+	Comparer listusers.Comparer  `bson:"comparer"`
+	Right    string              `bson:"right"`
+}
+
+func replayCheckpointID(replayID string) string {
+	return leaseKindReplayCheckpoint + "/" + replayID
+}
+
+func toDomainReplayCheckpoint(checkpoint ReplayCheckpoint) replayusers.Checkpoint {
+	var filtering *listusers.FilterInfo
+	if checkpoint.Filtering != nil {
+		filtering = &listusers.FilterInfo{
+			Left:     checkpoint.Filtering.Left,
+			Comparer: checkpoint.Filtering.Comparer,
+			Right:    checkpoint.Filtering.Right,
+		}
+	}
+	return replayusers.Checkpoint{
+		ID:             checkpoint.ReplayID,
+		Filtering:      filtering,
+		BatchSize:      checkpoint.BatchSize,
+		UsersPerSecond: checkpoint.UsersPerSecond,
+		Cursor:         checkpoint.Cursor,
+		Enqueued:       checkpoint.Enqueued,
+		StartedAt:      checkpoint.StartedAt,
+		UpdatedAt:      checkpoint.UpdatedAt,
+		FinishedAt:     checkpoint.FinishedAt,
+	}
+}
+
+func (c *Connection) GetReplayCheckpoint(ctx context.Context, id string) (replayusers.Checkpoint, error) {
+	var checkpoint ReplayCheckpoint
+	err := c.leaseCollection.FindOne(ctx, bson.D{{"_id", replayCheckpointID(id)}}).Decode(&checkpoint)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return replayusers.Checkpoint{}, errReplayNotFound
+	}
+	if err != nil {
+		return replayusers.Checkpoint{}, err
+	}
+	return toDomainReplayCheckpoint(checkpoint), nil
+}
+
+// CreateReplayCheckpoint refuses filters we can not translate, so a replay never falls back to every user
+func (c *Connection) CreateReplayCheckpoint(ctx context.Context, checkpoint replayusers.Checkpoint) (replayusers.Checkpoint, error) {
+	var filtering *ReplayFilter
+	if checkpoint.Filtering != nil {
+		_, err := getFilterFilter(checkpoint.Filtering)
+		if err != nil {
+			return replayusers.Checkpoint{}, invalidFilter(err)
+		}
+		filtering = &ReplayFilter{
+			Left:     checkpoint.Filtering.Left,
+			Comparer: checkpoint.Filtering.Comparer,
+			Right:    checkpoint.Filtering.Right,
+		}
+	}
+	_, err := c.leaseCollection.InsertOne(ctx, &ReplayCheckpoint{
+		ID:             replayCheckpointID(checkpoint.ID),
+		Kind:           leaseKindReplayCheckpoint,
+		ReplayID:       checkpoint.ID,
+		Filtering:      filtering,
+		BatchSize:      checkpoint.BatchSize,
+		UsersPerSecond: checkpoint.UsersPerSecond,
+		Cursor:         checkpoint.Cursor,
+		Enqueued:       checkpoint.Enqueued,
+		StartedAt:      checkpoint.StartedAt,
+		UpdatedAt:      checkpoint.UpdatedAt,
+	})
+	if mongo.IsDuplicateKeyError(err) {
+		return replayusers.Checkpoint{}, errReplayAlreadyExists
+	}
+	if err != nil {
+		return replayusers.Checkpoint{}, err
+	}
+	return checkpoint, nil
+}
+
+func (c *Connection) ListUnfinishedReplayCheckpoints(ctx context.Context) ([]replayusers.Checkpoint, error) {
+	cursor, err := c.leaseCollection.Find(ctx, bson.D{{"kind", leaseKindReplayCheckpoint}, {"finished_at", bson.D{{"$exists", false}}}})
+	if err != nil {
+		return nil, err
+	}
+	var stored []ReplayCheckpoint
+	err = cursor.All(ctx, &stored)
+	if err != nil {
+		return nil, err
+	}
+	checkpoints := make([]replayusers.Checkpoint, 0, len(stored))
+	for _, checkpoint := range stored {
+		checkpoints = append(checkpoints, toDomainReplayCheckpoint(checkpoint))
+	}
+	return checkpoints, nil
+}
+
+// EnqueueReplayBatch walks the users with the keyset pagination of ListUsers, sorted by _id so users added during the
+// replay are still reached
+func (c *Connection) EnqueueReplayBatch(ctx context.Context, checkpoint replayusers.Checkpoint, limit int64) (replayusers.Checkpoint, error) {
+
+	var moved replayusers.Checkpoint
+	err := c.executeInTransaction(ctx, func(innerContext mongo.SessionContext) error {
+		filter, innerErr := constructListUsersFilter(checkpoint.Filtering, nil, checkpoint.Cursor)
+		if innerErr != nil {
+			return innerErr
+		}
+		findOptions := options.Find().SetSort(constructListUsersSortBy(nil)).SetLimit(limit)
+		findResult, innerErr := c.usersCollection.Find(innerContext, filter, findOptions)
+		if innerErr != nil {
+			return innerErr
+		}
+		var users []DBUser
+		innerErr = findResult.All(innerContext, &users)
+		if innerErr != nil {
+			return innerErr
+		}
+
+		for _, user := range users {
+			innerErr = c.enqueueUserSnapshot(innerContext, user)
+			if innerErr != nil {
+				return innerErr
+			}
+		}
+
+		moved = checkpoint
+		moved.Enqueued += int64(len(users))
+		moved.UpdatedAt = timeutil.DBNow()
+		if len(users) > 0 {
+			moved.Cursor = constructCursor(users[len(users)-1], nil)
+		}
+		set := bson.D{
+			{"cursor", moved.Cursor},
+			{"enqueued", moved.Enqueued},
+			{"updated_at", moved.UpdatedAt},
+		}
+		if int64(len(users)) < limit {
+			moved.FinishedAt = moved.UpdatedAt
+			set = append(set, bson.E{Key: "finished_at", Value: moved.FinishedAt})
+		}
+
+		// only moves the checkpoint when nobody else did in the meantime
+		result, innerErr := c.leaseCollection.UpdateOne(innerContext, bson.D{
+			{"_id", replayCheckpointID(checkpoint.ID)},
+			{"cursor", checkpoint.Cursor},
+			{"finished_at", bson.D{{"$exists", false}}},
+		}, bson.D{{"$set", set}})
+		if innerErr != nil {
+			return innerErr
+		}
+		if result.MatchedCount == 0 {
+			return errReplayCheckpointMoved
+		}
+		return nil
+	})
+	if err != nil {
+		return checkpoint, err
+	}
+	return moved, nil
+}
+
+// enqueueUserSnapshot puts the current state of user in the outbox as a replayed UserAddedMessage, partners with
+// webhooks are not sent replays
+func (c *Connection) enqueueUserSnapshot(ctx context.Context, user DBUser) error {
+	messageToSend, err := c.createKafkaMessage(ctx, c.kafkaConfig.Topics.UserAddedTopicName, user.ID, &kafkaschema.UserAddedMessage{
+		Id:        user.ID,
+		FirstName: user.FirstName,
+		LastName:  user.LastName,
+		Nickname:  user.Nickname,
+		Email:     user.Email,
+		Country:   user.Country,
+		Replay:    true,
+	})
+	if err != nil {
+		return err
+	}
+	return c.insertOutboxMessage(ctx, messageToSend)
+}
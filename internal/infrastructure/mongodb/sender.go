@@ -4,12 +4,23 @@ import (
 	"context"
 	"encoding/json"
 	"github.com/rs/zerolog/log"
+	"google.golang.org/protobuf/proto"
 	"time"
 	"userservice/internal/infrastructure/messaging"
 	timeutil "userservice/internal/util/time"
 )
 
-func (c *Connection) putKafkaMessageInOutbox(ctx context.Context, msg messaging.KafkaInternalMessage) error {
+// putKafkaMessageInOutbox stores msg for the outbox and the webhook deliveries of event, the message it was serialized
+// from
+func (c *Connection) putKafkaMessageInOutbox(ctx context.Context, msg messaging.KafkaInternalMessage, event proto.Message) error {
+	err := c.insertOutboxMessage(ctx, msg)
+	if err != nil {
+		return err
+	}
+	return c.scheduleWebhookDeliveries(ctx, msg, event)
+}
+
+func (c *Connection) insertOutboxMessage(ctx context.Context, msg messaging.KafkaInternalMessage) error {
 
 	log.Info().Msgf("Putting kafka message in outbox %s", msg.TopicID)
 	msgData, err := json.Marshal(msg)
@@ -18,7 +29,11 @@ func (c *Connection) putKafkaMessageInOutbox(ctx context.Context, msg messaging.
 	}
 	_, err = c.outboxCollection.InsertOne(ctx, &Message{
 		MsgID:     msg.ID,
+		Topic:     msg.TopicID,
+		Key:       string(msg.Key),
+		Partition: messaging.PartitionForKey(msg.Key, c.kafkaConfig.Outbox.Partitions),
 		Data:      msgData,
+		CreatedAt: timeutil.DBNow(),
 		NextRetry: timeutil.DBNow().Add(time.Duration(c.dbConfig.InitialRetryDelaySeconds)),
 		SentAt:    time.Time{},
 		Retries:   0,
@@ -6,7 +6,9 @@ import (
 	"go.mongodb.org/mongo-driver/mongo"
 )
 
+// executeInTransaction marks the command ctx runs as processed in the same transaction
 func (c *Connection) executeInTransaction(ctx context.Context, inner func(ctx mongo.SessionContext) error) error {
+	inner = c.markingProcessedCommand(ctx, inner)
 	session, err := c.client.StartSession()
 	if err != nil {
 		return err
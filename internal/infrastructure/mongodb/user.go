@@ -2,7 +2,6 @@ package mongodb
 
 import (
 	"context"
-	"encoding/json"
 	"errors"
 	"github.com/google/uuid"
 	"github.com/rs/zerolog/log"
@@ -10,16 +9,21 @@ import (
 	"go.mongodb.org/mongo-driver/bson/primitive"
 	"go.mongodb.org/mongo-driver/mongo"
 	"go.mongodb.org/mongo-driver/mongo/options"
+	"google.golang.org/protobuf/proto"
 	"time"
+	"userservice/internal/domain/domainerror"
 	"userservice/internal/domain/model"
 	"userservice/internal/domain/model/listusers"
 	"userservice/internal/domain/model/updateuser"
 	"userservice/internal/infrastructure/messaging"
+	"userservice/internal/util/converter"
 	"userservice/internal/util/crypto"
+	timeutil "userservice/internal/util/time"
 	"userservice/proto/kafkaschema"
 )
 
-var errUserNotFound = errors.New("user not found")
+var errUserNotFound = domainerror.NotFound("user not found")
+var errUserAlreadyExists = domainerror.AlreadyExists("user already exists")
 
 type DBUser struct {
 	MongoDBID primitive.ObjectID `bson:"_id,omitempty"`
@@ -33,19 +37,25 @@ type DBUser struct {
 	CreatedAt time.Time          `bson:"created_at"`
 	UpdatedAt time.Time          `bson:"updated_at"`
 	Salt      string             `bson:"salt"`
+	Suspended bool               `bson:"suspended,omitempty"`
+	// SuspendedAt and SuspensionReason are kept for audits
+	SuspendedAt      time.Time `bson:"suspended_at,omitempty"`
+	SuspensionReason string    `bson:"suspension_reason,omitempty"`
 }
 
 func toDomainUser(user DBUser) model.User {
 	return model.User{
-		ID:        user.ID,
-		FirstName: user.FirstName,
-		LastName:  user.LastName,
-		Nickname:  user.Nickname,
-		Password:  user.Password,
-		Email:     user.Email,
-		Country:   user.Country,
-		CreatedAt: user.CreatedAt,
-		UpdatedAt: user.UpdatedAt,
+		ID:          user.ID,
+		FirstName:   user.FirstName,
+		LastName:    user.LastName,
+		Nickname:    user.Nickname,
+		Password:    user.Password,
+		Email:       user.Email,
+		Country:     user.Country,
+		CreatedAt:   user.CreatedAt,
+		UpdatedAt:   user.UpdatedAt,
+		Suspended:   user.Suspended,
+		SuspendedAt: user.SuspendedAt,
 	}
 }
 
@@ -71,17 +81,27 @@ func createNewDBUser(request model.User) DBUser {
 	}
 }
 
-func createKafkaMessage(topic string, key string, data interface{}) (messaging.KafkaInternalMessage, error) {
+// createKafkaMessage serializes message and describes it in the headers, with the trace of the request in ctx. key is
+// the id of the user, which is the subject of cloud events
+func (c *Connection) createKafkaMessage(ctx context.Context, topic string, key string, message proto.Message) (messaging.KafkaInternalMessage, error) {
 
-	kafkaValue, err := json.Marshal(data)
+	kafkaValue, err := c.serializer.Serialize(topic, message)
 	if err != nil {
 		return messaging.KafkaInternalMessage{}, err
 	}
-	return *messaging.NewInternalMessage(
+	kafkaMessage := messaging.NewInternalMessage(
 		topic,
 		[]byte(key),
 		kafkaValue,
-	), nil
+	)
+	metadata := messaging.NewEventMetadata(ctx, kafkaMessage.ID, message)
+	kafkaMessage.Headers = metadata.Headers()
+
+	err = c.envelope.Wrap(kafkaMessage, c.eventKind(topic), key, metadata.OccurredAt)
+	if err != nil {
+		return messaging.KafkaInternalMessage{}, err
+	}
+	return *kafkaMessage, nil
 }
 
 func (c *Connection) AddUser(ctx context.Context, user model.User) (model.User, error) {
@@ -92,20 +112,27 @@ func (c *Connection) AddUser(ctx context.Context, user model.User) (model.User,
 		userToAdd := createNewDBUser(user)
 		_, innerErr := c.usersCollection.InsertOne(innerContext, userToAdd)
 		if innerErr != nil {
+			if mongo.IsDuplicateKeyError(innerErr) {
+				return errUserAlreadyExists
+			}
 			return innerErr
 		}
 		addedUser = toDomainUser(userToAdd)
 
-		messageToSend, innerErr := createKafkaMessage(c.kafkaConfig.Topics.UserAddedTopicName, userToAdd.ID, &kafkaschema.UserAddedMessage{
+		addedMessage := &kafkaschema.UserAddedMessage{
 			Id:        addedUser.ID,
 			FirstName: addedUser.FirstName,
 			LastName:  addedUser.LastName,
 			Nickname:  addedUser.Nickname,
 			Email:     addedUser.Email,
 			Country:   addedUser.Country,
-		})
+		}
+		messageToSend, innerErr := c.createKafkaMessage(innerContext, c.kafkaConfig.Topics.UserAddedTopicName, userToAdd.ID, addedMessage)
+		if innerErr != nil {
+			return innerErr
+		}
 
-		innerErr = c.putKafkaMessageInOutbox(innerContext, messageToSend)
+		innerErr = c.putKafkaMessageInOutbox(innerContext, messageToSend, addedMessage)
 		if innerErr != nil {
 			return innerErr
 		}
@@ -124,14 +151,21 @@ func (c *Connection) RemoveUser(ctx context.Context, userID string) (model.User,
 		user := DBUser{}
 		innerErr := result.Decode(&user)
 		if innerErr != nil {
+			if errors.Is(innerErr, mongo.ErrNoDocuments) {
+				return errUserNotFound
+			}
 			return innerErr
 		}
 
-		messageToSend, innerErr := createKafkaMessage(c.kafkaConfig.Topics.UserRemovedTopicName, userID, &kafkaschema.UserRemovedMessage{
+		removedMessage := &kafkaschema.UserRemovedMessage{
 			Id: userID,
-		})
+		}
+		messageToSend, innerErr := c.createKafkaMessage(innerContext, c.kafkaConfig.Topics.UserRemovedTopicName, userID, removedMessage)
+		if innerErr != nil {
+			return innerErr
+		}
 
-		innerErr = c.putKafkaMessageInOutbox(innerContext, messageToSend)
+		innerErr = c.putKafkaMessageInOutbox(innerContext, messageToSend, removedMessage)
 		if innerErr != nil {
 			return innerErr
 		}
@@ -143,37 +177,107 @@ func (c *Connection) RemoveUser(ctx context.Context, userID string) (model.User,
 
 func (c *Connection) UpdateUser(ctx context.Context, userID string, updateUser updateuser.Request) (model.User, error) {
 
-	storedUser, err := c.getUserInternal(ctx, userID)
-	if err != nil {
-		if errors.Is(err, mongo.ErrNoDocuments) {
-			return model.User{}, errUserNotFound
+	var modifiedUser model.User
+	// update the user and add an outbox message describing the changes in the same transaction
+	err := c.executeInTransaction(ctx, func(innerContext mongo.SessionContext) error {
+		storedUser, innerErr := c.getUserInternal(innerContext, userID)
+		if innerErr != nil {
+			if errors.Is(innerErr, mongo.ErrNoDocuments) {
+				return errUserNotFound
+			}
+			return innerErr
 		}
-		return model.User{}, err
-	}
 
-	filter, err := constructUpdateFilter(updateUser, storedUser.Salt)
+		filter, innerErr := constructUpdateFilter(updateUser, storedUser.Salt)
+		if innerErr != nil {
+			return innerErr
+		}
+
+		log.Info().Msgf("updating user %s with filter: %s", userID, filter)
+
+		upsert := false
+		returnDocument := options.After
+		opt := options.FindOneAndUpdateOptions{
+			Upsert:         &upsert,
+			ReturnDocument: &returnDocument,
+		}
+
+		result := c.usersCollection.FindOneAndUpdate(innerContext, bson.M{c.dbConfig.UserIdName: userID}, filter, &opt)
+
+		updatedUser := DBUser{}
+		innerErr = result.Decode(&updatedUser)
+		if innerErr != nil {
+			if errors.Is(innerErr, mongo.ErrNoDocuments) {
+				return errUserNotFound
+			}
+			return innerErr
+		}
+		modifiedUser = toDomainUser(updatedUser)
+
+		updatedMessage := converter.FromUserUpdateToKafkaUpdatedUserMessage(toDomainUser(storedUser), modifiedUser)
+		if len(updatedMessage.Changes) == 0 && !updatedMessage.PasswordChanged {
+			// nothing downstream cares about, only updated_at was touched
+			return nil
+		}
+
+		messageToSend, innerErr := c.createKafkaMessage(innerContext, c.kafkaConfig.Topics.UserUpdatedTopicName, userID, updatedMessage)
+		if innerErr != nil {
+			return innerErr
+		}
+
+		return c.putKafkaMessageInOutbox(innerContext, messageToSend, updatedMessage)
+	})
 	if err != nil {
 		return model.User{}, err
 	}
 
-	log.Info().Msgf("updating user %s with filter: %s", userID, filter)
+	return modifiedUser, nil
+}
 
-	upsert := false
-	returnDocument := options.After
-	opt := options.FindOneAndUpdateOptions{
-		Upsert:         &upsert,
-		ReturnDocument: &returnDocument,
-	}
+// SuspendUser flags the user and raises a UserUpdatedMessage for it, suspending a suspended user changes nothing
+func (c *Connection) SuspendUser(ctx context.Context, userID string, reason string) (model.User, error) {
+
+	var suspendedUser model.User
+	err := c.executeInTransaction(ctx, func(innerContext mongo.SessionContext) error {
+		storedUser, innerErr := c.getUserInternal(innerContext, userID)
+		if innerErr != nil {
+			if errors.Is(innerErr, mongo.ErrNoDocuments) {
+				return errUserNotFound
+			}
+			return innerErr
+		}
+		if storedUser.Suspended {
+			suspendedUser = toDomainUser(storedUser)
+			return nil
+		}
 
-	result := c.usersCollection.FindOneAndUpdate(ctx, bson.M{c.dbConfig.UserIdName: userID}, filter, &opt)
+		now := timeutil.DBNow()
+		update := bson.D{{"$set", bson.D{
+			{"suspended", true},
+			{"suspended_at", now},
+			{"suspension_reason", reason},
+			{"updated_at", now},
+		}}}
+		updatedUser := DBUser{}
+		innerErr = c.usersCollection.FindOneAndUpdate(innerContext, bson.M{c.dbConfig.UserIdName: userID}, update, options.FindOneAndUpdate().SetReturnDocument(options.After)).Decode(&updatedUser)
+		if innerErr != nil {
+			return innerErr
+		}
+		suspendedUser = toDomainUser(updatedUser)
 
-	updatedUser := DBUser{}
-	err = result.Decode(&updatedUser)
+		updatedMessage := converter.FromUserUpdateToKafkaUpdatedUserMessage(toDomainUser(storedUser), suspendedUser)
+		messageToSend, innerErr := c.createKafkaMessage(innerContext, c.kafkaConfig.Topics.UserUpdatedTopicName, userID, updatedMessage)
+		if innerErr != nil {
+			return innerErr
+		}
+
+		return c.putKafkaMessageInOutbox(innerContext, messageToSend, updatedMessage)
+	})
 	if err != nil {
 		return model.User{}, err
 	}
 
-	return toDomainUser(updatedUser), nil
+	return suspendedUser, nil
 }
 
 func (c *Connection) getUserInternal(ctx context.Context, userID string) (DBUser, error) {
@@ -198,6 +302,30 @@ func (c *Connection) GetUser(ctx context.Context, id string) (model.User, error)
 	return toDomainUser(user), nil
 }
 
+func (c *Connection) GetUsers(ctx context.Context, ids []string) ([]model.User, error) {
+
+	findResult, err := c.usersCollection.Find(ctx, bson.M{c.dbConfig.UserIdName: bson.M{"$in": ids}})
+	if err != nil {
+		return nil, err
+	}
+	defer findResult.Close(ctx)
+
+	var users []model.User
+	for findResult.Next(ctx) {
+		userResult := DBUser{}
+		err = findResult.Decode(&userResult)
+		if err != nil {
+			return nil, err
+		}
+		users = append(users, toDomainUser(userResult))
+	}
+	if err = findResult.Err(); err != nil {
+		return nil, err
+	}
+
+	return users, nil
+}
+
 func (c *Connection) getUsedLimit(limit int64) int64 {
 	if limit == 0 {
 		return c.dbConfig.ListUserDefaultLimit
@@ -217,7 +345,10 @@ func (c *Connection) ListUsers(ctx context.Context, request listusers.Request) (
 		rawCursor = request.Paging.Cursor
 	}
 
-	filter := constructListUsersFilter(request.Filtering, request.Sorting, rawCursor)
+	filter, err := constructListUsersFilter(request.Filtering, request.Sorting, rawCursor)
+	if err != nil {
+		return listusers.Response{}, err
+	}
 	sortBy := constructListUsersSortBy(request.Sorting)
 
 	usedLimit := c.getUsedLimit(limit)
@@ -0,0 +1,440 @@
+package mongodb
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"github.com/google/uuid"
+	"github.com/rs/zerolog/log"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"time"
+	"userservice/internal/domain/domainerror"
+	"userservice/internal/domain/model"
+	"userservice/internal/infrastructure/messaging"
+	timeutil "userservice/internal/util/time"
+)
+
+var errWebhookSubscriptionNotFound = domainerror.NotFound("webhook subscription not found")
+var errInvalidWebhookCursor = domainerror.InvalidField("paging.cursor", "cursor is not valid")
+var errWebhookDeliveryNotProcessing = errors.New("webhook delivery is not processing")
+var errWebhookLeaseExpired = errors.New("lease expired before the attempt was recorded")
+
+// releaseWebhookLease clears the claim of a delivery that is no longer processing
+var releaseWebhookLease = bson.E{Key: "$unset", Value: bson.D{{"lease_expires_at", ""}, {"lease_token", ""}}}
+
+// defaultWebhookMaxAttempts is used when no webhook maxAttempts is configured
+const defaultWebhookMaxAttempts = 10
+
+type WebhookDeliveryState string
+
+func (s WebhookDeliveryState) String() string {
+	return string(s)
+}
+
+const (
+	WebhookStateWaiting    WebhookDeliveryState = "waiting"
+	WebhookStateProcessing WebhookDeliveryState = "processing"
+	WebhookStateDelivered  WebhookDeliveryState = "delivered"
+	// WebhookStateFailed deliveries ran out of attempts
+	WebhookStateFailed WebhookDeliveryState = "failed"
+	// WebhookStateCancelled deliveries belonged to a subscription that was removed before they were sent
+	WebhookStateCancelled WebhookDeliveryState = "cancelled"
+)
+
+type WebhookSubscription struct {
+	MongoDBID  primitive.ObjectID `bson:"_id,omitempty"`
+	ID         string             `bson:"id"`
+	URL        string             `bson:"url"`
+	EventTypes []string           `bson:"event_types,omitempty"`
+	Secret     string             `bson:"secret"`
+	CreatedAt  time.Time          `bson:"created_at"`
+}
+
+type WebhookDelivery struct {
+	MongoDBID      primitive.ObjectID `bson:"_id,omitempty"`
+	DeliveryID     string             `bson:"delivery_id"`
+	SubscriptionID string             `bson:"subscription_id"`
+	URL            string             `bson:"url"`
+	EventID        string             `bson:"event_id"`
+	EventType      string             `bson:"event_type"`
+	Payload        []byte             `bson:"payload"`
+	State          string             `bson:"state"`
+	CreatedAt      time.Time          `bson:"created_at"`
+	NextRetry      time.Time          `bson:"next_retry"`
+	DeliveredAt    time.Time          `bson:"delivered_at,omitempty"`
+	LastError      string             `bson:"last_error,omitempty"`
+	// LeaseExpiresAt is when a processing delivery may be claimed again, because its sender is gone
+	LeaseExpiresAt time.Time `bson:"lease_expires_at,omitempty"`
+	// LeaseToken is new on every claim, so a sender whose lease expired can not record the outcome anymore
+	LeaseToken string           `bson:"lease_token,omitempty"`
+	Attempts   []WebhookAttempt `bson:"attempts"`
+}
+
+type WebhookAttempt struct {
+	AttemptedAt time.Time `bson:"attempted_at"`
+	StatusCode  int       `bson:"status_code"`
+	Error       string    `bson:"error,omitempty"`
+	DurationMS  int64     `bson:"duration_ms"`
+}
+
+// webhookPayload is the body posted to the endpoints
+type webhookPayload struct {
+	ID         string          `json:"id"`
+	Type       string          `json:"type"`
+	OccurredAt time.Time       `json:"occurredAt"`
+	Data       json.RawMessage `json:"data"`
+}
+
+func toDomainWebhookSubscription(subscription WebhookSubscription) model.WebhookSubscription {
+	return model.WebhookSubscription{
+		ID:         subscription.ID,
+		URL:        subscription.URL,
+		EventTypes: subscription.EventTypes,
+		Secret:     subscription.Secret,
+		CreatedAt:  subscription.CreatedAt,
+	}
+}
+
+func toDomainWebhookDelivery(delivery WebhookDelivery) model.WebhookDelivery {
+	attempts := make([]model.WebhookAttempt, 0, len(delivery.Attempts))
+	for _, attempt := range delivery.Attempts {
+		attempts = append(attempts, model.WebhookAttempt{
+			AttemptedAt: attempt.AttemptedAt,
+			StatusCode:  attempt.StatusCode,
+			Error:       attempt.Error,
+			Duration:    time.Duration(attempt.DurationMS) * time.Millisecond,
+		})
+	}
+	return model.WebhookDelivery{
+		ID:             delivery.DeliveryID,
+		SubscriptionID: delivery.SubscriptionID,
+		URL:            delivery.URL,
+		EventID:        delivery.EventID,
+		EventType:      delivery.EventType,
+		Payload:        delivery.Payload,
+		State:          delivery.State,
+		CreatedAt:      delivery.CreatedAt,
+		NextRetry:      delivery.NextRetry,
+		DeliveredAt:    delivery.DeliveredAt,
+		LastError:      delivery.LastError,
+		Attempts:       attempts,
+		LeaseToken:     delivery.LeaseToken,
+		LeaseExpiresAt: delivery.LeaseExpiresAt,
+	}
+}
+
+func toWebhookAttempt(attempt model.WebhookAttempt) WebhookAttempt {
+	return WebhookAttempt{
+		AttemptedAt: attempt.AttemptedAt,
+		StatusCode:  attempt.StatusCode,
+		Error:       attempt.Error,
+		DurationMS:  attempt.Duration.Milliseconds(),
+	}
+}
+
+// eventKind maps the topics of the user events to the event types webhook subscriptions filter on and cloud events
+// are typed with
+func (c *Connection) eventKind(topic string) string {
+	switch topic {
+	case c.kafkaConfig.Topics.UserAddedTopicName:
+		return model.EventTypeUserAdded
+	case c.kafkaConfig.Topics.UserRemovedTopicName:
+		return model.EventTypeUserRemoved
+	case c.kafkaConfig.Topics.UserUpdatedTopicName:
+		return model.EventTypeUserUpdated
+	}
+	return ""
+}
+
+// scheduleWebhookDeliveries records a delivery for every subscription that wants the event, it runs in the
+// transaction that puts the event in the outbox, so partners get exactly the events that reach kafka
+func (c *Connection) scheduleWebhookDeliveries(ctx context.Context, msg messaging.KafkaInternalMessage, event proto.Message) error {
+	if !c.webhookConfig.Enabled {
+		return nil
+	}
+	eventType := c.eventKind(msg.TopicID)
+	if eventType == "" {
+		return nil
+	}
+
+	subscriptions, err := c.ListWebhookSubscriptions(ctx)
+	if err != nil {
+		return err
+	}
+	var deliveries []interface{}
+	var payload []byte
+	for _, subscription := range subscriptions {
+		if !subscription.Wants(eventType) {
+			continue
+		}
+		if payload == nil {
+			payload, err = createWebhookPayload(msg.ID, eventType, event)
+			if err != nil {
+				return err
+			}
+		}
+		now := timeutil.DBNow()
+		deliveries = append(deliveries, &WebhookDelivery{
+			DeliveryID:     uuid.NewString(),
+			SubscriptionID: subscription.ID,
+			URL:            subscription.URL,
+			EventID:        msg.ID,
+			EventType:      eventType,
+			Payload:        payload,
+			State:          WebhookStateWaiting.String(),
+			CreatedAt:      now,
+			NextRetry:      now,
+			Attempts:       []WebhookAttempt{},
+		})
+	}
+	if len(deliveries) == 0 {
+		return nil
+	}
+
+	log.Info().Msgf("scheduling %d webhook deliveries of %s", len(deliveries), msg.ID)
+	_, err = c.webhookDeliveryCollection.InsertMany(ctx, deliveries)
+	return err
+}
+
+func createWebhookPayload(eventID string, eventType string, event proto.Message) ([]byte, error) {
+	data, err := protojson.Marshal(event)
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(webhookPayload{
+		ID:         eventID,
+		Type:       eventType,
+		OccurredAt: timeutil.DBNow(),
+		Data:       data,
+	})
+}
+
+func (c *Connection) AddWebhookSubscription(ctx context.Context, subscription model.WebhookSubscription) (model.WebhookSubscription, error) {
+	_, err := c.webhookSubscriptionCollection.InsertOne(ctx, &WebhookSubscription{
+		ID:         subscription.ID,
+		URL:        subscription.URL,
+		EventTypes: subscription.EventTypes,
+		Secret:     subscription.Secret,
+		CreatedAt:  subscription.CreatedAt,
+	})
+	if err != nil {
+		return model.WebhookSubscription{}, err
+	}
+	return subscription, nil
+}
+
+func (c *Connection) ListWebhookSubscriptions(ctx context.Context) ([]model.WebhookSubscription, error) {
+	findResult, err := c.webhookSubscriptionCollection.Find(ctx, bson.D{}, options.Find().SetSort(bson.D{{"_id", 1}}))
+	if err != nil {
+		return nil, err
+	}
+	defer findResult.Close(ctx)
+
+	var stored []WebhookSubscription
+	err = findResult.All(ctx, &stored)
+	if err != nil {
+		return nil, err
+	}
+	subscriptions := make([]model.WebhookSubscription, 0, len(stored))
+	for _, subscription := range stored {
+		subscriptions = append(subscriptions, toDomainWebhookSubscription(subscription))
+	}
+	return subscriptions, nil
+}
+
+func (c *Connection) GetWebhookSubscription(ctx context.Context, id string) (model.WebhookSubscription, error) {
+	var subscription WebhookSubscription
+	err := c.webhookSubscriptionCollection.FindOne(ctx, bson.D{{"id", id}}).Decode(&subscription)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return model.WebhookSubscription{}, errWebhookSubscriptionNotFound
+	}
+	if err != nil {
+		return model.WebhookSubscription{}, err
+	}
+	return toDomainWebhookSubscription(subscription), nil
+}
+
+// RemoveWebhookSubscription keeps the delivery log of the subscription, its pending deliveries are cancelled when
+// they come up
+func (c *Connection) RemoveWebhookSubscription(ctx context.Context, id string) (model.WebhookSubscription, error) {
+	var subscription WebhookSubscription
+	err := c.webhookSubscriptionCollection.FindOneAndDelete(ctx, bson.D{{"id", id}}).Decode(&subscription)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return model.WebhookSubscription{}, errWebhookSubscriptionNotFound
+	}
+	if err != nil {
+		return model.WebhookSubscription{}, err
+	}
+	return toDomainWebhookSubscription(subscription), nil
+}
+
+func (c *Connection) ListWebhookDeliveries(ctx context.Context, subscriptionID string, limit int64, cursor string) ([]model.WebhookDelivery, string, error) {
+
+	filter := bson.D{{"subscription_id", subscriptionID}}
+	if cursor != "" {
+		lastID, err := primitive.ObjectIDFromHex(cursor)
+		if err != nil {
+			return nil, "", errInvalidWebhookCursor
+		}
+		filter = append(filter, bson.E{Key: "_id", Value: bson.D{{"$gt", lastID}}})
+	}
+
+	usedLimit := c.getUsedLimit(limit)
+	findOptions := options.Find().SetSort(bson.D{{"_id", 1}}).SetLimit(usedLimit)
+	findResult, err := c.webhookDeliveryCollection.Find(ctx, filter, findOptions)
+	if err != nil {
+		return nil, "", err
+	}
+	defer findResult.Close(ctx)
+
+	var stored []WebhookDelivery
+	err = findResult.All(ctx, &stored)
+	if err != nil {
+		return nil, "", err
+	}
+
+	deliveries := make([]model.WebhookDelivery, 0, len(stored))
+	for _, delivery := range stored {
+		deliveries = append(deliveries, toDomainWebhookDelivery(delivery))
+	}
+
+	nextCursor := ""
+	if int64(len(stored)) == usedLimit {
+		nextCursor = stored[len(stored)-1].MongoDBID.Hex()
+	}
+	return deliveries, nextCursor, nil
+}
+
+// ClaimDueWebhookDeliveries hands out deliveries one by one, so several instances can send at the same time. A
+// processing delivery whose lease expired is claimed again, its sender is gone so that counts as a failed attempt and
+// a delivery that ran out of attempts fails instead
+func (c *Connection) ClaimDueWebhookDeliveries(ctx context.Context, leaseDuration time.Duration, limit int) ([]model.WebhookDelivery, error) {
+
+	var claimed []model.WebhookDelivery
+	for len(claimed) < limit {
+		now := timeutil.DBNow()
+		filter := bson.D{{"$or", bson.A{
+			bson.D{{"state", WebhookStateWaiting}, {"next_retry", bson.D{{"$lte", now}}}},
+			bson.D{{"state", WebhookStateProcessing}, {"lease_expires_at", bson.D{{"$lte", now}}}},
+		}}}
+		expiredAttempt := WebhookAttempt{AttemptedAt: now, Error: errWebhookLeaseExpired.Error()}
+		outOfAttempts := bson.D{{"$and", bson.A{
+			"$lease_expired",
+			bson.D{{"$gte", bson.A{bson.D{{"$size", bson.D{{"$ifNull", bson.A{"$attempts", bson.A{}}}}}}, c.webhookMaxAttempts()}}},
+		}}}
+		update := mongo.Pipeline{
+			{{"$set", bson.D{{"lease_expired", bson.D{{"$eq", bson.A{"$state", WebhookStateProcessing}}}}}}},
+			{{"$set", bson.D{
+				{"attempts", bson.D{{"$cond", bson.A{
+					"$lease_expired",
+					bson.D{{"$concatArrays", bson.A{bson.D{{"$ifNull", bson.A{"$attempts", bson.A{}}}}, bson.A{bson.D{{"$literal", expiredAttempt}}}}}},
+					"$attempts",
+				}}}},
+				{"last_error", bson.D{{"$cond", bson.A{"$lease_expired", errWebhookLeaseExpired.Error(), "$last_error"}}}},
+			}}},
+			{{"$set", bson.D{
+				{"state", bson.D{{"$cond", bson.A{outOfAttempts, WebhookStateFailed, WebhookStateProcessing}}}},
+				{"lease_expires_at", bson.D{{"$cond", bson.A{outOfAttempts, "$$REMOVE", now.Add(leaseDuration)}}}},
+				{"lease_token", bson.D{{"$cond", bson.A{outOfAttempts, "$$REMOVE", uuid.NewString()}}}},
+			}}},
+			{{"$unset", "lease_expired"}},
+		}
+		opts := options.FindOneAndUpdate().SetSort(bson.D{{"next_retry", 1}}).SetReturnDocument(options.After)
+
+		var delivery WebhookDelivery
+		err := c.webhookDeliveryCollection.FindOneAndUpdate(ctx, filter, update, opts).Decode(&delivery)
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			break
+		}
+		if err != nil {
+			return claimed, err
+		}
+		if delivery.State == WebhookStateFailed.String() {
+			log.Error().Msgf("webhook delivery %s failed %d times, giving up", delivery.DeliveryID, len(delivery.Attempts))
+			continue
+		}
+		claimed = append(claimed, toDomainWebhookDelivery(delivery))
+	}
+	return claimed, nil
+}
+
+func (c *Connection) webhookMaxAttempts() int {
+	if c.webhookConfig.MaxAttempts <= 0 {
+		return defaultWebhookMaxAttempts
+	}
+	return c.webhookConfig.MaxAttempts
+}
+
+// finishDeliveryFilter matches a delivery that is still processing under the claim of leaseToken
+func finishDeliveryFilter(deliveryID string, leaseToken string) bson.D {
+	return bson.D{{"delivery_id", deliveryID}, {"state", WebhookStateProcessing}, {"lease_token", leaseToken}}
+}
+
+// finishWebhookDelivery logs the attempt and moves a processing delivery on, only the instance holding it may do so
+func (c *Connection) finishWebhookDelivery(ctx context.Context, deliveryID string, leaseToken string, attempt model.WebhookAttempt, set bson.D) error {
+	update := bson.D{
+		{"$set", set},
+		{"$push", bson.D{{"attempts", toWebhookAttempt(attempt)}}},
+		releaseWebhookLease,
+	}
+	result, err := c.webhookDeliveryCollection.UpdateOne(ctx, finishDeliveryFilter(deliveryID, leaseToken), update)
+	if err != nil {
+		return err
+	}
+	if result.MatchedCount == 0 {
+		return errWebhookDeliveryNotProcessing
+	}
+	return nil
+}
+
+func (c *Connection) MarkWebhookDelivered(ctx context.Context, deliveryID string, leaseToken string, attempt model.WebhookAttempt) error {
+	return c.finishWebhookDelivery(ctx, deliveryID, leaseToken, attempt, bson.D{
+		{"state", WebhookStateDelivered},
+		{"delivered_at", timeutil.DBNow()},
+	})
+}
+
+// RetryWebhookDelivery schedules the next attempt with the same backoff as the outbox, or gives up after the last one.
+// It is a single update, the attempts counted are the ones of the claim that is recorded
+func (c *Connection) RetryWebhookDelivery(ctx context.Context, deliveryID string, leaseToken string, attempt model.WebhookAttempt) error {
+	outOfAttempts := bson.D{{"$gte", bson.A{bson.D{{"$size", "$attempts"}}, c.webhookMaxAttempts()}}}
+	nextRetry := backoffExpression(c.webhookConfig.BaseRetryTimeSeconds, c.webhookConfig.MaxRetryTimeSeconds, bson.D{{"$size", "$attempts"}})
+	update := mongo.Pipeline{
+		{{"$set", bson.D{
+			{"attempts", bson.D{{"$concatArrays", bson.A{bson.D{{"$ifNull", bson.A{"$attempts", bson.A{}}}}, bson.A{bson.D{{"$literal", toWebhookAttempt(attempt)}}}}}}},
+			{"last_error", bson.D{{"$literal", attempt.Error}}},
+		}}},
+		{{"$set", bson.D{
+			{"state", bson.D{{"$cond", bson.A{outOfAttempts, WebhookStateFailed, WebhookStateWaiting}}}},
+			{"next_retry", bson.D{{"$cond", bson.A{outOfAttempts, "$next_retry", nextRetry}}}},
+		}}},
+		{{"$unset", bson.A{"lease_expires_at", "lease_token"}}},
+	}
+	opts := options.FindOneAndUpdate().SetReturnDocument(options.After)
+
+	var delivery WebhookDelivery
+	err := c.webhookDeliveryCollection.FindOneAndUpdate(ctx, finishDeliveryFilter(deliveryID, leaseToken), update, opts).Decode(&delivery)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return errWebhookDeliveryNotProcessing
+	}
+	if err != nil {
+		return err
+	}
+	if delivery.State == WebhookStateFailed.String() {
+		log.Error().Msgf("webhook delivery %s failed %d times, giving up", deliveryID, len(delivery.Attempts))
+	}
+	return nil
+}
+
+func (c *Connection) CancelWebhookDelivery(ctx context.Context, deliveryID string, leaseToken string, reason string) error {
+	_, err := c.webhookDeliveryCollection.UpdateOne(ctx, finishDeliveryFilter(deliveryID, leaseToken), bson.D{
+		{"$set", bson.D{{"state", WebhookStateCancelled}, {"last_error", reason}}},
+		releaseWebhookLease,
+	})
+	return err
+}
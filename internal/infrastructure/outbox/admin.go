@@ -0,0 +1,29 @@
+package outbox
+
+import (
+	"context"
+	"userservice/internal/infrastructure/messaging"
+)
+
+// AdminRepo lets operators look into the outbox, decide to requeue or discard dead lettered messages and push other
+// messages along
+type AdminRepo interface {
+	// ListDeadLetteredMessages returns up to limit dead lettered messages after cursor, oldest first, together with
+	// the cursor of the next page which is empty on the last page
+	ListDeadLetteredMessages(ctx context.Context, limit int64, cursor string) ([]messaging.OutboxEntry, string, error)
+	GetDeadLetteredMessage(ctx context.Context, id string) (messaging.OutboxEntry, error)
+	// RequeueDeadLetteredMessage moves the message back to waiting with a fresh set of attempts
+	RequeueDeadLetteredMessage(ctx context.Context, id string) (messaging.OutboxEntry, error)
+	// DiscardDeadLetteredMessage deletes the message, the returned entry is the message as it was before deletion
+	DiscardDeadLetteredMessage(ctx context.Context, id string) (messaging.OutboxEntry, error)
+	// ListOutboxMessages pages through the messages matching filter like ListDeadLetteredMessages
+	ListOutboxMessages(ctx context.Context, filter messaging.OutboxFilter, limit int64, cursor string) ([]messaging.OutboxEntry, string, error)
+	GetOutboxMessage(ctx context.Context, id string) (messaging.OutboxEntry, error)
+	// RetryOutboxMessage makes a waiting message due now, the attempts it already used are kept
+	RetryOutboxMessage(ctx context.Context, id string) (messaging.OutboxEntry, error)
+	// RepublishOutboxMessage puts a copy of a finished message in the outbox, with an id of its own and the original
+	// id in the republished-from header. It fails while newer messages of the same key are in the outbox
+	RepublishOutboxMessage(ctx context.Context, id string) (messaging.OutboxEntry, error)
+	// CountOutboxMessages counts the messages of every state, states without messages are left out
+	CountOutboxMessages(ctx context.Context) (map[string]int64, error)
+}
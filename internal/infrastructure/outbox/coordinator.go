@@ -0,0 +1,145 @@
+package outbox
+
+import (
+	"context"
+	"github.com/rs/zerolog/log"
+	"slices"
+	"sync"
+	"time"
+)
+
+// missedIntervalsBeforeDead is how many coordination intervals a replica may miss before its partitions are taken over
+const missedIntervalsBeforeDead = 3
+
+// CoordinationRepo lets replicas share the outbox partitions, every partition is owned by at most one live replica
+type CoordinationRepo interface {
+	// Heartbeat keeps owner in the list of live replicas for ttl
+	Heartbeat(ctx context.Context, owner string, ttl time.Duration) error
+	// LiveMembers returns the live replicas, sorted, so every replica computes the same assignment
+	LiveMembers(ctx context.Context) ([]string, error)
+	// AcquirePartition takes or renews the lease of a partition for ttl, false means another replica holds it
+	AcquirePartition(ctx context.Context, partition int, owner string, ttl time.Duration) (bool, error)
+	ReleasePartition(ctx context.Context, partition int, owner string) error
+	// Leave gives up everything owner holds
+	Leave(ctx context.Context, owner string) error
+}
+
+type coordinator struct {
+	repo       CoordinationRepo
+	instanceID string
+	partitions int
+	interval   time.Duration
+	ownedLock  sync.RWMutex
+	owned      []int
+}
+
+func newCoordinator(repo CoordinationRepo, instanceID string, partitions int, interval time.Duration) *coordinator {
+	return &coordinator{
+		repo:       repo,
+		instanceID: instanceID,
+		partitions: partitions,
+		interval:   interval,
+	}
+}
+
+func (c *coordinator) ttl() time.Duration {
+	return missedIntervalsBeforeDead * c.interval
+}
+
+// ownedPartitions are the partitions this replica may claim messages from
+func (c *coordinator) ownedPartitions() []int {
+	c.ownedLock.RLock()
+	defer c.ownedLock.RUnlock()
+	return slices.Clone(c.owned)
+}
+
+func (c *coordinator) setOwned(owned []int) {
+	c.ownedLock.Lock()
+	defer c.ownedLock.Unlock()
+	c.owned = owned
+}
+
+// assignedPartitions spreads the partitions round robin over the live replicas
+func (c *coordinator) assignedPartitions(members []string) []int {
+	index := slices.Index(members, c.instanceID)
+	if index < 0 {
+		return nil
+	}
+	var assigned []int
+	for partition := index; partition < c.partitions; partition += len(members) {
+		assigned = append(assigned, partition)
+	}
+	return assigned
+}
+
+// rebalance announces this replica, gives away partitions that belong to someone else now and takes the ones
+// assigned to it. A partition that is still leased by its previous owner is taken over on a later round, once that
+// owner released it or died
+func (c *coordinator) rebalance(ctx context.Context) {
+	err := c.repo.Heartbeat(ctx, c.instanceID, c.ttl())
+	if err != nil {
+		// our leases run out soon, stop claiming before someone else takes over
+		log.Error().Err(err).Msg("Outbox: failed to send heartbeat, pausing until the next round")
+		c.setOwned(nil)
+		return
+	}
+	members, err := c.repo.LiveMembers(ctx)
+	if err != nil {
+		log.Error().Err(err).Msg("Outbox: failed to list live replicas, pausing until the next round")
+		c.setOwned(nil)
+		return
+	}
+
+	assigned := c.assignedPartitions(members)
+	for _, partition := range c.ownedPartitions() {
+		if slices.Contains(assigned, partition) {
+			continue
+		}
+		err = c.repo.ReleasePartition(ctx, partition, c.instanceID)
+		if err != nil {
+			log.Error().Err(err).Msgf("Outbox: failed to release partition %d", partition)
+		}
+	}
+
+	var owned []int
+	for _, partition := range assigned {
+		acquired, err := c.repo.AcquirePartition(ctx, partition, c.instanceID, c.ttl())
+		if err != nil {
+			log.Error().Err(err).Msgf("Outbox: failed to acquire partition %d", partition)
+			continue
+		}
+		if acquired {
+			owned = append(owned, partition)
+		}
+	}
+
+	if !slices.Equal(owned, c.ownedPartitions()) {
+		log.Info().Msgf("Outbox: %d replicas live, %s owns partitions %v", len(members), c.instanceID, owned)
+	}
+	c.setOwned(owned)
+}
+
+// run rebalances every interval until ctx is cancelled, then leaves so the others take over right away
+func (c *coordinator) run(ctx context.Context) {
+	ticker := time.NewTicker(c.interval)
+	defer ticker.Stop()
+
+	c.rebalance(ctx)
+	for {
+		select {
+		case <-ctx.Done():
+			c.leave()
+			return
+		case <-ticker.C:
+			c.rebalance(ctx)
+		}
+	}
+}
+
+func (c *coordinator) leave() {
+	c.setOwned(nil)
+	err := c.repo.Leave(context.Background(), c.instanceID)
+	if err != nil {
+		log.Error().Err(err).Msg("Outbox: failed to leave, partitions are taken over once the leases expire")
+	}
+}
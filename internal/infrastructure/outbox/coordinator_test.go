@@ -0,0 +1,86 @@
+package outbox
+
+import (
+	"context"
+	"github.com/stretchr/testify/require"
+	"slices"
+	"testing"
+	"time"
+	"userservice/internal/infrastructure/messaging"
+	"userservice/internal/mock"
+)
+
+const testInterval = 20 * time.Millisecond
+
+func rebalanceAll(ctx context.Context, coordinators ...*coordinator) {
+	// the first rounds only release, the partitions are picked up by their new owner in the following round
+	for range 3 {
+		for _, c := range coordinators {
+			c.rebalance(ctx)
+		}
+	}
+}
+
+func requireDisjointCover(t *testing.T, coordinators ...*coordinator) {
+	var all []int
+	for _, c := range coordinators {
+		owned := c.ownedPartitions()
+		require.NotEmpty(t, owned, c.instanceID)
+		all = append(all, owned...)
+	}
+	slices.Sort(all)
+	require.Equal(t, allPartitions(), all)
+}
+
+func TestPartitionsAreSharedBetweenReplicas(t *testing.T) {
+	ctx := context.Background()
+	repo := mock.NewCoordinationRepoMock()
+	a := newCoordinator(repo, "a", messaging.DefaultOutboxPartitions, testInterval)
+	b := newCoordinator(repo, "b", messaging.DefaultOutboxPartitions, testInterval)
+	c := newCoordinator(repo, "c", messaging.DefaultOutboxPartitions, testInterval)
+
+	a.rebalance(ctx)
+	require.Equal(t, allPartitions(), a.ownedPartitions())
+
+	rebalanceAll(ctx, a, b, c)
+	requireDisjointCover(t, a, b, c)
+}
+
+func TestDeadReplicaIsTakenOver(t *testing.T) {
+	ctx := context.Background()
+	repo := mock.NewCoordinationRepoMock()
+	a := newCoordinator(repo, "a", messaging.DefaultOutboxPartitions, testInterval)
+	b := newCoordinator(repo, "b", messaging.DefaultOutboxPartitions, testInterval)
+	rebalanceAll(ctx, a, b)
+	requireDisjointCover(t, a, b)
+
+	// a stops sending heartbeats without leaving, b waits for its leases to expire
+	b.rebalance(ctx)
+	require.Len(t, b.ownedPartitions(), messaging.DefaultOutboxPartitions/2)
+
+	time.Sleep(a.ttl() + testInterval)
+	rebalanceAll(ctx, b)
+	require.Equal(t, allPartitions(), b.ownedPartitions())
+}
+
+func TestLeavingReplicaHandsOverRightAway(t *testing.T) {
+	ctx := context.Background()
+	repo := mock.NewCoordinationRepoMock()
+	a := newCoordinator(repo, "a", messaging.DefaultOutboxPartitions, time.Hour)
+	b := newCoordinator(repo, "b", messaging.DefaultOutboxPartitions, time.Hour)
+	rebalanceAll(ctx, a, b)
+	requireDisjointCover(t, a, b)
+
+	runCtx, stop := context.WithCancel(ctx)
+	done := make(chan struct{})
+	go func() {
+		a.run(runCtx)
+		close(done)
+	}()
+	stop()
+	<-done
+	require.Empty(t, a.ownedPartitions())
+
+	b.rebalance(ctx)
+	require.Equal(t, allPartitions(), b.ownedPartitions())
+}
@@ -0,0 +1,42 @@
+package outbox
+
+import (
+	"context"
+	"github.com/rs/zerolog/log"
+	"time"
+)
+
+// watchRestartDelay is how long the outbox waits before watching again after the change stream failed
+const watchRestartDelay = 5 * time.Second
+
+// ChangeNotifier tells the outbox right away when messages might have become due, instead of waiting for the next poll
+type ChangeNotifier interface {
+	// WatchMessages calls notify for every new message, and every message that is first in line for its key now. It
+	// blocks until ctx is cancelled or watching fails
+	WatchMessages(ctx context.Context, notify func()) error
+}
+
+// nudge wakes up the main loop, notifications that arrive while a drain is already pending are merged into it
+func (o *outbox) nudge() {
+	select {
+	case o.wakeUp <- struct{}{}:
+	default:
+	}
+}
+
+// watch keeps the change stream open until ctx is cancelled, while it is down the outbox relies on polling
+func (o *outbox) watch(ctx context.Context) {
+	for {
+		err := o.changeNotifier.WatchMessages(ctx, o.nudge)
+		if ctx.Err() != nil {
+			return
+		}
+		log.Error().Err(err).Msgf("Outbox: watching for messages failed, polling until it is restarted in %s", watchRestartDelay)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(watchRestartDelay):
+		}
+	}
+}
@@ -0,0 +1,48 @@
+package outbox
+
+import (
+	"context"
+	"github.com/stretchr/testify/require"
+	"testing"
+	"time"
+	"userservice/internal/config"
+	"userservice/internal/infrastructure/metrics"
+	"userservice/internal/infrastructure/publisher"
+	"userservice/internal/mock"
+)
+
+func TestNudgesAreMerged(t *testing.T) {
+	o := newTestOutbox(mock.NewMessageOutboxRepoMock(), "owner")
+
+	// a burst of inserts results in a single pending drain, without blocking the notifier
+	for range 10 {
+		o.nudge()
+	}
+	require.Len(t, o.wakeUp, 1)
+}
+
+func TestWatchWakesUpTheOutbox(t *testing.T) {
+	notifier := mock.NewChangeNotifierMock()
+	o := NewKafkaOutbox(publisher.NewMemoryPublisher(), mock.NewMessageOutboxRepoMock(), mock.NewCoordinationRepoMock(), notifier, metrics.NewMetrics(), config.OutboxConfig{ChangeStream: true}).(*outbox)
+
+	ctx, stop := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		o.watch(ctx)
+		close(done)
+	}()
+
+	notifier.Changes <- struct{}{}
+	select {
+	case <-o.wakeUp:
+	case <-time.After(time.Second):
+		require.Fail(t, "outbox was not woken up")
+	}
+
+	stop()
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		require.Fail(t, "watch did not stop")
+	}
+}
@@ -4,99 +4,259 @@ import (
 	"context"
 	"errors"
 	"fmt"
-	confkafka "github.com/confluentinc/confluent-kafka-go/v2/kafka"
+	"github.com/google/uuid"
 	"github.com/rs/zerolog/log"
+	"os"
 	"sync"
 	"time"
 	"userservice/internal/config"
 	"userservice/internal/infrastructure/messaging"
+	"userservice/internal/infrastructure/metrics"
 )
 
+const (
+	defaultBatchSize             = 100
+	defaultConcurrency           = 4
+	defaultMaxInFlight           = 1000
+	defaultLeaseDurationSeconds  = 600
+	defaultReaperIntervalSeconds = 30
+	defaultCoordinationInterval  = 5
+	// maxDeliveryWaitSeconds is how long we wait for a delivery report before giving up on a message
+	maxDeliveryWaitSeconds = 300
+)
+
+var errDeliveryReportTimedOut = errors.New("timed out waiting for delivery report")
+
 type outbox struct {
-	producer        *confkafka.Producer
+	publisher       Publisher
 	outboxLock      sync.RWMutex
 	kafkaOutboxRepo MessageOutboxRepo
 	shutdownChannel chan struct{}
 	hasBeenShutDown bool
 	config          config.OutboxConfig
+	// jobs feeds claimed messages to the worker pool
+	jobs chan produceJob
+	// inFlight holds one slot per message handed to the publisher that has no delivery report yet
+	inFlight chan struct{}
+	// instanceID is the lease owner of the messages claimed by this outbox
+	instanceID string
+	// coordinator decides which partitions of the outbox this replica sends
+	coordinator *coordinator
+	// changeNotifier is only used when the change stream mode is enabled
+	changeNotifier ChangeNotifier
+	// wakeUp triggers a drain before the next tick
+	wakeUp     chan struct{}
+	statusLock sync.Mutex
+	status     Status
+	metrics    *metrics.Metrics
+}
+
+type produceJob struct {
+	message messaging.KafkaInternalMessage
+	done    *sync.WaitGroup
 }
 
 type Outbox interface {
 	CleanUp()
 	Run()
+	Status() Status
 }
 
-func NewKafkaOutbox(producer *confkafka.Producer, repo MessageOutboxRepo, config config.OutboxConfig) Outbox {
+func NewKafkaOutbox(publisher Publisher, repo MessageOutboxRepo, coordinationRepo CoordinationRepo, changeNotifier ChangeNotifier, metrics *metrics.Metrics, config config.OutboxConfig) Outbox {
+	if config.BatchSize <= 0 {
+		config.BatchSize = defaultBatchSize
+	}
+	if config.Concurrency <= 0 {
+		config.Concurrency = defaultConcurrency
+	}
+	if config.MaxInFlight <= 0 {
+		config.MaxInFlight = defaultMaxInFlight
+	}
+	if config.LeaseDurationSeconds <= maxDeliveryWaitSeconds {
+		if config.LeaseDurationSeconds != 0 {
+			log.Warn().Msgf("Outbox: lease of %d seconds is shorter than the delivery wait, using %d seconds", config.LeaseDurationSeconds, defaultLeaseDurationSeconds)
+		}
+		config.LeaseDurationSeconds = defaultLeaseDurationSeconds
+	}
+	if config.ReaperIntervalSeconds <= 0 {
+		config.ReaperIntervalSeconds = defaultReaperIntervalSeconds
+	}
+	if config.InstanceID == "" {
+		config.InstanceID = newInstanceID()
+	}
+	if config.Partitions <= 0 {
+		config.Partitions = messaging.DefaultOutboxPartitions
+	}
+	if config.CoordinationIntervalSeconds <= 0 {
+		config.CoordinationIntervalSeconds = defaultCoordinationInterval
+	}
+	if config.Retention.PurgeIntervalSeconds <= 0 {
+		config.Retention.PurgeIntervalSeconds = defaultPurgeIntervalSeconds
+	}
+	if config.Retention.PurgeBatchSize <= 0 {
+		config.Retention.PurgeBatchSize = defaultPurgeBatchSize
+	}
+	coordinationInterval := time.Duration(config.CoordinationIntervalSeconds) * time.Second
+
 	return &outbox{
-		producer:        producer,
+		publisher:       publisher,
 		outboxLock:      sync.RWMutex{},
 		kafkaOutboxRepo: repo,
 		shutdownChannel: make(chan struct{}),
-		hasBeenShutDown: true,
+		hasBeenShutDown: false,
 		config:          config,
+		jobs:            make(chan produceJob),
+		inFlight:        make(chan struct{}, config.MaxInFlight),
+		instanceID:      config.InstanceID,
+		coordinator:     newCoordinator(coordinationRepo, config.InstanceID, config.Partitions, coordinationInterval),
+		changeNotifier:  changeNotifier,
+		wakeUp:          make(chan struct{}, 1),
+		metrics:         metrics,
+	}
+}
+
+// newInstanceID is unique per process, so a restarted replica never mistakes the claims of its crashed predecessor for
+// its own
+func newInstanceID() string {
+	hostname, err := os.Hostname()
+	if err != nil {
+		hostname = "outbox"
 	}
+	return fmt.Sprintf("%s-%s", hostname, uuid.New().String()[:8])
 }
 
 func (o *outbox) CleanUp() {
+	o.outboxLock.Lock()
+	defer o.outboxLock.Unlock()
 	if o.hasBeenShutDown {
 		return
 	}
 
-	o.producer.Close()
-	o.shutdownChannel <- struct{}{}
+	// closing instead of sending, so cleaning up an outbox that never ran does not block
 	close(o.shutdownChannel)
+	o.publisher.Close()
 	o.hasBeenShutDown = true
 }
 
 func (o *outbox) Run() {
 	ctx := context.Background()
+	// only the background loops stop with the main loop, delivery reports that are still underway should be recorded
+	backgroundCtx, stopBackground := context.WithCancel(ctx)
+	defer stopBackground()
 	ticker := time.NewTicker(time.Duration(o.config.SleepIntervalSeconds) * time.Second)
 
-	log.Info().Msgf("Outbox: starting")
+	log.Info().Msgf("Outbox: starting %d workers as %s", o.config.Concurrency, o.instanceID)
+	for range o.config.Concurrency {
+		go o.worker(ctx)
+	}
+	go o.reaper(backgroundCtx)
+	go o.coordinator.run(backgroundCtx)
+	if o.config.Retention.FinishedRetentionSeconds > 0 {
+		go o.retention(backgroundCtx)
+	}
+	if o.config.ChangeStream {
+		// polling stays on for retries and for anything the change stream missed
+		log.Info().Msg("Outbox: watching for new messages")
+		go o.watch(backgroundCtx)
+	}
+
 outerLoop:
 	for {
 		select {
 		case <-o.shutdownChannel:
 			break outerLoop
 		case <-ticker.C:
-			message, err := o.getOnePendingKafkaMessage(ctx)
-			if errors.Is(err, ErrNoPendingMessage) {
-				log.Info().Msgf("Outbox: found no pending messages, going back to sleep")
-				break
-			}
-			err = o.produce(ctx, message)
-			if err != nil {
-				log.Error().Err(err).Msgf("Outbox: failed to produce message with id %o, going back to sleep", message.ID)
-			}
+			o.drain(ctx)
+		case <-o.wakeUp:
+			o.drain(ctx)
 		}
 	}
+	close(o.jobs)
 	log.Info().Msgf("Outbox: stopped main loop")
 }
 
-func toConfluentKafkaMessage(m messaging.KafkaInternalMessage) *confkafka.Message {
-	return &confkafka.Message{
-		TopicPartition: confkafka.TopicPartition{
-			Topic: &m.TopicID,
-		},
-		Value: m.Value,
-		Key:   m.Key,
+// drain claims and produces batches until no due message is left, only then the outbox goes back to sleep
+func (o *outbox) drain(ctx context.Context) {
+	for {
+		messages, err := o.claimPendingKafkaMessages(ctx)
+		if err != nil {
+			log.Error().Err(err).Msg("Outbox: failed to claim pending messages, going back to sleep")
+			return
+		}
+		if len(messages) == 0 {
+			log.Info().Msgf("Outbox: found no pending messages, going back to sleep")
+			return
+		}
+
+		log.Info().Msgf("Outbox: claimed %d messages", len(messages))
+		batchDone := &sync.WaitGroup{}
+		batchDone.Add(len(messages))
+		for _, message := range messages {
+			o.jobs <- produceJob{message: message, done: batchDone}
+		}
+		batchDone.Wait()
+
+		if len(messages) < o.config.BatchSize {
+			// a partial batch means the queue is empty, no need to ask again
+			return
+		}
+	}
+}
+
+// reaper returns messages whose owner crashed before it could report the delivery, until ctx is cancelled
+func (o *outbox) reaper(ctx context.Context) {
+	ticker := time.NewTicker(time.Duration(o.config.ReaperIntervalSeconds) * time.Second)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			o.reapExpiredLeases(ctx)
+		}
 	}
 }
 
-func (o *outbox) getOnePendingKafkaMessage(ctx context.Context) (messaging.KafkaInternalMessage, error) {
+func (o *outbox) reapExpiredLeases(ctx context.Context) {
 	o.outboxLock.RLock()
 	defer o.outboxLock.RUnlock()
-	message, err := o.kafkaOutboxRepo.GetPendingMessage(ctx)
+	released, err := o.kafkaOutboxRepo.ReleaseExpiredLeases(ctx)
 	if err != nil {
-		return messaging.KafkaInternalMessage{}, err
+		log.Error().Err(err).Msg("Outbox: failed to release expired leases")
+		return
+	}
+	if released > 0 {
+		log.Warn().Msgf("Outbox: released %d messages with an expired lease", released)
+	}
+}
+
+func (o *outbox) worker(ctx context.Context) {
+	for job := range o.jobs {
+		err := o.produce(ctx, job.message)
+		if err != nil {
+			log.Error().Err(err).Msgf("Outbox: failed to produce message with id %s, scheduling retry", job.message.ID)
+			o.metrics.PublishFailed(job.message.TopicID)
+			o.retryKafkaMessage(ctx, job.message, metrics.RetryReasonPublishFailed, err)
+		}
+		job.done.Done()
 	}
-	return message, nil
 }
 
-func (o *outbox) retryKafkaMessage(ctx context.Context, id string) {
+func (o *outbox) claimPendingKafkaMessages(ctx context.Context) ([]messaging.KafkaInternalMessage, error) {
 	o.outboxLock.RLock()
 	defer o.outboxLock.RUnlock()
-	o.kafkaOutboxRepo.RetryMessage(ctx, id)
+	leaseDuration := time.Duration(o.config.LeaseDurationSeconds) * time.Second
+	return o.kafkaOutboxRepo.ClaimPendingMessages(ctx, o.instanceID, leaseDuration, o.coordinator.ownedPartitions(), o.config.BatchSize)
+}
+
+func (o *outbox) retryKafkaMessage(ctx context.Context, msg messaging.KafkaInternalMessage, reason string, cause error) {
+	o.outboxLock.RLock()
+	defer o.outboxLock.RUnlock()
+	// dead lettered messages and messages lost to another instance are not retried by this attempt
+	if o.kafkaOutboxRepo.RetryMessage(ctx, o.instanceID, msg.ID, cause) {
+		o.metrics.RetryScheduled(msg.TopicID, reason)
+	}
 }
 
 func (o *outbox) markKafkaMessageSent(ctx context.Context, id string) {
@@ -107,20 +267,26 @@ func (o *outbox) markKafkaMessageSent(ctx context.Context, id string) {
 
 func (o *outbox) produce(ctx context.Context, msg messaging.KafkaInternalMessage) error {
 
-	successChan := make(chan confkafka.Event)
-	err := o.producer.Produce(toConfluentKafkaMessage(msg), successChan)
+	// blocks while too many messages are waiting for their delivery report
+	o.inFlight <- struct{}{}
+
+	deliveryReport, err := o.publisher.Publish(msg)
 	if err != nil {
-		return fmt.Errorf("failed to produce message %v", err.Error())
+		<-o.inFlight
+		return fmt.Errorf("failed to publish message %v", err.Error())
 	}
-	go o.listenForKafkaMessageProduced(ctx, successChan, msg)
+	go o.listenForDeliveryReport(ctx, deliveryReport, msg)
 
 	return nil
 }
 
-func (o *outbox) listenForKafkaMessageProduced(ctx context.Context, successChannel chan confkafka.Event, msg messaging.KafkaInternalMessage) {
-	maxWaitingTime := 300 // 5 minutes, then we assume we failed
+func (o *outbox) listenForDeliveryReport(ctx context.Context, deliveryReport <-chan error, msg messaging.KafkaInternalMessage) {
+	defer func() { <-o.inFlight }()
+
+	maxWaitingTime := maxDeliveryWaitSeconds // then we assume we failed
 	tickerTimeSeconds := 5
 	timer := time.NewTicker(time.Duration(tickerTimeSeconds) * time.Second)
+	defer timer.Stop()
 	tickCount := 0
 	allowedTicks := maxWaitingTime / tickerTimeSeconds
 
@@ -131,11 +297,19 @@ func (o *outbox) listenForKafkaMessageProduced(ctx context.Context, successChann
 			if tickCount > allowedTicks {
 				errMessage := fmt.Sprintf("waited for %d seconds, unable to verify that message was successfully sent, message: %v", maxWaitingTime, msg)
 				log.Error().Msg(errMessage)
-				o.retryKafkaMessage(ctx, msg.ID)
+				o.metrics.PublishFailed(msg.TopicID)
+				o.retryKafkaMessage(ctx, msg, metrics.RetryReasonDeliveryTimedOut, errDeliveryReportTimedOut)
+				return
+			}
+		case err := <-deliveryReport:
+			if err != nil {
+				log.Error().Err(err).Msgf("Outbox: delivery of message with id %s failed", msg.ID)
+				o.metrics.PublishFailed(msg.TopicID)
+				o.retryKafkaMessage(ctx, msg, metrics.RetryReasonDeliveryFailed, err)
 				return
 			}
-		case <-successChannel:
-			log.Info().Msgf("Outbox: message sent with id %o", msg.ID)
+			log.Info().Msgf("Outbox: message sent with id %s", msg.ID)
+			o.metrics.PublishSucceeded(msg.TopicID)
 			o.markKafkaMessageSent(ctx, msg.ID)
 			return
 		}
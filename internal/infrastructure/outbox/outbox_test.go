@@ -0,0 +1,315 @@
+package outbox
+
+import (
+	"context"
+	"errors"
+	"github.com/stretchr/testify/require"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+	"userservice/internal/config"
+	"userservice/internal/infrastructure/messaging"
+	"userservice/internal/infrastructure/metrics"
+	"userservice/internal/infrastructure/publisher"
+	"userservice/internal/mock"
+)
+
+// newTestOutbox returns an outbox that owns all partitions
+func newTestOutbox(repo MessageOutboxRepo, instanceID string) *outbox {
+	o := NewKafkaOutbox(publisher.NewMemoryPublisher(), repo, mock.NewCoordinationRepoMock(), nil, metrics.NewMetrics(), config.OutboxConfig{InstanceID: instanceID, ReaperIntervalSeconds: 1}).(*outbox)
+	o.coordinator.rebalance(context.Background())
+	return o
+}
+
+func TestNewKafkaOutboxDefaults(t *testing.T) {
+	o := NewKafkaOutbox(publisher.NewMemoryPublisher(), mock.NewMessageOutboxRepoMock(), mock.NewCoordinationRepoMock(), nil, metrics.NewMetrics(), config.OutboxConfig{LeaseDurationSeconds: 10}).(*outbox)
+
+	// a lease shorter than the delivery wait would hand out messages that are still being delivered
+	require.Equal(t, int64(defaultLeaseDurationSeconds), o.config.LeaseDurationSeconds)
+	require.NotEmpty(t, o.instanceID)
+	require.NotEqual(t, o.instanceID, newTestOutbox(mock.NewMessageOutboxRepoMock(), "").instanceID)
+	require.Equal(t, messaging.DefaultOutboxPartitions, o.config.Partitions)
+}
+
+func TestCrashedInstanceMessagesAreRecovered(t *testing.T) {
+	ctx := context.Background()
+	repo := mock.NewMessageOutboxRepoMock()
+	msg := messaging.NewInternalMessage("userservice.user.added", []byte("key"), []byte("value"))
+	require.NoError(t, repo.PutMessageInOutbox(ctx, *msg))
+
+	// the first instance claims the message and dies before the delivery report arrives
+	claimed, err := repo.ClaimPendingMessages(ctx, "crashed", time.Millisecond, allPartitions(), defaultBatchSize)
+	require.NoError(t, err)
+	require.Len(t, claimed, 1)
+
+	survivor := newTestOutbox(repo, "survivor")
+	claimed, err = survivor.claimPendingKafkaMessages(ctx)
+	require.NoError(t, err)
+	require.Empty(t, claimed, "message is still leased to the crashed instance")
+
+	time.Sleep(10 * time.Millisecond)
+	reaperCtx, stopReaper := context.WithCancel(ctx)
+	go survivor.reaper(reaperCtx)
+	require.Eventually(t, func() bool {
+		entry, _ := repo.Entry(msg.ID)
+		return entry.State == "waiting"
+	}, 5*time.Second, 50*time.Millisecond)
+	stopReaper()
+
+	entry, _ := repo.Entry(msg.ID)
+	require.Equal(t, int64(1), entry.Retries)
+	require.Empty(t, entry.LeaseOwner)
+
+	claimed, err = survivor.claimPendingKafkaMessages(ctx)
+	require.NoError(t, err)
+	require.Len(t, claimed, 1)
+	require.Equal(t, msg.ID, claimed[0].ID)
+	entry, _ = repo.Entry(msg.ID)
+	require.Equal(t, "survivor", entry.LeaseOwner)
+}
+
+func TestMessageThatKeepsCrashingItsOwnerIsDeadLettered(t *testing.T) {
+	ctx := context.Background()
+	repo := mock.NewMessageOutboxRepoMock()
+	repo.MaxAttempts = 2
+	msg := messaging.NewInternalMessage("userservice.user.added", []byte("key"), []byte("value"))
+	require.NoError(t, repo.PutMessageInOutbox(ctx, *msg))
+	survivor := newTestOutbox(repo, "survivor")
+
+	for range 2 {
+		claimed, err := repo.ClaimPendingMessages(ctx, "crashed", time.Millisecond, allPartitions(), defaultBatchSize)
+		require.NoError(t, err)
+		require.Len(t, claimed, 1)
+		time.Sleep(10 * time.Millisecond)
+		survivor.reapExpiredLeases(ctx)
+	}
+
+	entry, _ := repo.Entry(msg.ID)
+	require.Equal(t, "dead_lettered", entry.State)
+	require.Equal(t, int64(2), entry.Retries)
+	require.False(t, entry.DeadLetteredAt.IsZero())
+	require.Empty(t, entry.LeaseOwner)
+
+	claimed, err := survivor.claimPendingKafkaMessages(ctx)
+	require.NoError(t, err)
+	require.Empty(t, claimed)
+}
+
+func TestUnexpiredLeasesAreKept(t *testing.T) {
+	ctx := context.Background()
+	repo := mock.NewMessageOutboxRepoMock()
+	msg := messaging.NewInternalMessage("userservice.user.added", []byte("key"), []byte("value"))
+	require.NoError(t, repo.PutMessageInOutbox(ctx, *msg))
+
+	o := newTestOutbox(repo, "owner")
+	_, err := o.claimPendingKafkaMessages(ctx)
+	require.NoError(t, err)
+
+	o.reapExpiredLeases(ctx)
+	entry, _ := repo.Entry(msg.ID)
+	require.Equal(t, "processing", entry.State)
+	require.Equal(t, "owner", entry.LeaseOwner)
+}
+
+func TestLateFailureDoesNotTouchReclaimedMessage(t *testing.T) {
+	ctx := context.Background()
+	repo := mock.NewMessageOutboxRepoMock()
+	msg := messaging.NewInternalMessage("userservice.user.added", []byte("key"), []byte("value"))
+	require.NoError(t, repo.PutMessageInOutbox(ctx, *msg))
+
+	// the lease of the slow instance runs out and the survivor claims the message again
+	slow := newTestOutbox(repo, "slow")
+	_, err := repo.ClaimPendingMessages(ctx, "slow", time.Millisecond, allPartitions(), defaultBatchSize)
+	require.NoError(t, err)
+	time.Sleep(10 * time.Millisecond)
+	_, err = repo.ReleaseExpiredLeases(ctx)
+	require.NoError(t, err)
+	survivor := newTestOutbox(repo, "survivor")
+	claimed, err := survivor.claimPendingKafkaMessages(ctx)
+	require.NoError(t, err)
+	require.Len(t, claimed, 1)
+
+	slow.retryKafkaMessage(ctx, *msg, metrics.RetryReasonDeliveryTimedOut, errDeliveryReportTimedOut)
+	entry, _ := repo.Entry(msg.ID)
+	require.Equal(t, "processing", entry.State)
+	require.Equal(t, "survivor", entry.LeaseOwner)
+	require.Equal(t, int64(1), entry.Retries, "only the expired lease counts")
+}
+
+func TestOnlyRetriedMessagesAreCountedAsRetries(t *testing.T) {
+	ctx := context.Background()
+	repo := mock.NewMessageOutboxRepoMock()
+	repo.MaxAttempts = 2
+	msg := messaging.NewInternalMessage("userservice.user.added", []byte("key"), []byte("value"))
+	require.NoError(t, repo.PutMessageInOutbox(ctx, *msg))
+	o := newTestOutbox(repo, "owner")
+
+	for range 2 {
+		claimed, err := o.claimPendingKafkaMessages(ctx)
+		require.NoError(t, err)
+		require.Len(t, claimed, 1)
+		o.retryKafkaMessage(ctx, *msg, metrics.RetryReasonDeliveryFailed, errors.New("broker down"))
+	}
+
+	entry, _ := repo.Entry(msg.ID)
+	require.Equal(t, "dead_lettered", entry.State)
+	recorder := httptest.NewRecorder()
+	o.metrics.Handler().ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	body, err := io.ReadAll(recorder.Body)
+	require.NoError(t, err)
+	require.Contains(t, string(body), `userservice_outbox_retries_total{reason="delivery_failed",topic="userservice.user.added"} 1`)
+}
+
+func allPartitions() []int {
+	var partitions []int
+	for partition := range messaging.DefaultOutboxPartitions {
+		partitions = append(partitions, partition)
+	}
+	return partitions
+}
+
+func TestMessagesOfAKeyAreClaimedOneAfterTheOther(t *testing.T) {
+	ctx := context.Background()
+	repo := mock.NewMessageOutboxRepoMock()
+	first := messaging.NewInternalMessage("userservice.user.added", []byte("user-1"), []byte("first"))
+	second := messaging.NewInternalMessage("userservice.user.updated", []byte("user-1"), []byte("second"))
+	other := messaging.NewInternalMessage("userservice.user.added", []byte("user-2"), []byte("other"))
+	for _, msg := range []*messaging.KafkaInternalMessage{first, second, other} {
+		require.NoError(t, repo.PutMessageInOutbox(ctx, *msg))
+	}
+
+	o := newTestOutbox(repo, "owner")
+	claimed, err := o.claimPendingKafkaMessages(ctx)
+	require.NoError(t, err)
+	require.Equal(t, []string{first.ID, other.ID}, messageIDs(claimed))
+
+	// a failed first message keeps blocking the second one
+	o.retryKafkaMessage(ctx, *first, metrics.RetryReasonDeliveryTimedOut, errDeliveryReportTimedOut)
+	claimed, err = o.claimPendingKafkaMessages(ctx)
+	require.NoError(t, err)
+	require.Equal(t, []string{first.ID}, messageIDs(claimed))
+
+	o.markKafkaMessageSent(ctx, first.ID)
+	claimed, err = o.claimPendingKafkaMessages(ctx)
+	require.NoError(t, err)
+	require.Equal(t, []string{second.ID}, messageIDs(claimed))
+}
+
+func TestDeadLetteredMessageHoldsUpItsKey(t *testing.T) {
+	ctx := context.Background()
+	repo := mock.NewMessageOutboxRepoMock()
+	repo.MaxAttempts = 1
+	first := messaging.NewInternalMessage("userservice.user.added", []byte("user-1"), []byte("first"))
+	second := messaging.NewInternalMessage("userservice.user.removed", []byte("user-1"), []byte("second"))
+	other := messaging.NewInternalMessage("userservice.user.added", []byte("user-2"), []byte("other"))
+	for _, msg := range []*messaging.KafkaInternalMessage{first, second, other} {
+		require.NoError(t, repo.PutMessageInOutbox(ctx, *msg))
+	}
+
+	o := newTestOutbox(repo, "owner")
+	claimed, err := o.claimPendingKafkaMessages(ctx)
+	require.NoError(t, err)
+	require.Equal(t, []string{first.ID, other.ID}, messageIDs(claimed))
+	o.retryKafkaMessage(ctx, *first, metrics.RetryReasonDeliveryFailed, errors.New("broker down"))
+	o.markKafkaMessageSent(ctx, other.ID)
+
+	// sending the removal now would put it before the addition that is still to come
+	claimed, err = o.claimPendingKafkaMessages(ctx)
+	require.NoError(t, err)
+	require.Empty(t, claimed)
+	entry, _ := repo.Entry(second.ID)
+	require.Equal(t, "waiting", entry.State)
+}
+
+func messageIDs(messages []messaging.KafkaInternalMessage) []string {
+	var ids []string
+	for _, message := range messages {
+		ids = append(ids, message.ID)
+	}
+	return ids
+}
+
+// startTestOutbox runs an outbox that polls every second and publishes to memory
+func startTestOutbox(t *testing.T, repo MessageOutboxRepo, memoryPublisher *publisher.MemoryPublisher) *outbox {
+	o := NewKafkaOutbox(memoryPublisher, repo, mock.NewCoordinationRepoMock(), nil, metrics.NewMetrics(), config.OutboxConfig{InstanceID: "owner", SleepIntervalSeconds: 1}).(*outbox)
+	o.coordinator.rebalance(context.Background())
+
+	done := make(chan struct{})
+	go func() {
+		o.Run()
+		close(done)
+	}()
+	t.Cleanup(func() {
+		o.CleanUp()
+		<-done
+	})
+	return o
+}
+
+func TestRunPublishesMessagesInOrder(t *testing.T) {
+	ctx := context.Background()
+	repo := mock.NewMessageOutboxRepoMock()
+	memoryPublisher := publisher.NewMemoryPublisher()
+	var expected []string
+	for i := range 5 {
+		msg := messaging.NewInternalMessage("userservice.user.updated", []byte("user-1"), []byte{byte(i)})
+		require.NoError(t, repo.PutMessageInOutbox(ctx, *msg))
+		expected = append(expected, msg.ID)
+	}
+
+	startTestOutbox(t, repo, memoryPublisher)
+
+	require.Eventually(t, func() bool {
+		return len(memoryPublisher.Published()) == len(expected)
+	}, 15*time.Second, 50*time.Millisecond)
+	require.Equal(t, expected, messageIDs(memoryPublisher.Published()))
+	for _, id := range expected {
+		entry, _ := repo.Entry(id)
+		require.Equal(t, "finished", entry.State)
+	}
+}
+
+func TestFailedPublishesAreRetried(t *testing.T) {
+	tests := []struct {
+		name string
+		fail func(memoryPublisher *publisher.MemoryPublisher, err error)
+	}{
+		{"publish", (*publisher.MemoryPublisher).FailPublishing},
+		{"delivery", (*publisher.MemoryPublisher).FailDelivery},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ctx := context.Background()
+			repo := mock.NewMessageOutboxRepoMock()
+			memoryPublisher := publisher.NewMemoryPublisher()
+			test.fail(memoryPublisher, errors.New("broker down"))
+			msg := messaging.NewInternalMessage("userservice.user.added", []byte("user-1"), []byte("value"))
+			require.NoError(t, repo.PutMessageInOutbox(ctx, *msg))
+
+			startTestOutbox(t, repo, memoryPublisher)
+
+			require.Eventually(t, func() bool {
+				entry, _ := repo.Entry(msg.ID)
+				return entry.Retries > 0
+			}, 5*time.Second, 50*time.Millisecond)
+			entry, _ := repo.Entry(msg.ID)
+			require.Contains(t, entry.LastError, "broker down")
+			require.Empty(t, memoryPublisher.Published())
+
+			test.fail(memoryPublisher, nil)
+			require.Eventually(t, func() bool {
+				entry, _ := repo.Entry(msg.ID)
+				return entry.State == "finished"
+			}, 5*time.Second, 50*time.Millisecond)
+			require.Len(t, memoryPublisher.Published(), 1)
+		})
+	}
+}
+
+func TestCleanUpWithoutRun(t *testing.T) {
+	o := newTestOutbox(mock.NewMessageOutboxRepoMock(), "owner")
+	o.CleanUp()
+	o.CleanUp()
+}
@@ -0,0 +1,37 @@
+package outbox
+
+import (
+	"fmt"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+	"userservice/internal/infrastructure/cloudevents"
+	"userservice/internal/infrastructure/messaging"
+	"userservice/internal/infrastructure/serialization"
+)
+
+var errUnknownEventType = fmt.Errorf("message has no %s header, it was stored before events were typed", messaging.HeaderEventType)
+
+// DecodePayload reads the event back from the value of entry. The type of the event is taken from its event-type
+// header, cloud events in structured mode are unwrapped first
+func DecodePayload(serializer serialization.Serializer, entry messaging.OutboxEntry) (proto.Message, error) {
+	eventType, ok := messaging.HeaderValue(entry.Headers, messaging.HeaderEventType)
+	if !ok {
+		return nil, errUnknownEventType
+	}
+	messageType, err := protoregistry.GlobalTypes.FindMessageByName(protoreflect.FullName(eventType))
+	if err != nil {
+		return nil, fmt.Errorf("event type %s: %w", eventType, err)
+	}
+
+	data, err := cloudevents.Unwrap(entry.Value, entry.Headers)
+	if err != nil {
+		return nil, fmt.Errorf("unwrapping cloud event: %w", err)
+	}
+	event := messageType.New().Interface()
+	err = serializer.Deserialize(entry.Topic, data, event)
+	if err != nil {
+		return nil, err
+	}
+	return event, nil
+}
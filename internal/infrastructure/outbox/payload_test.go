@@ -0,0 +1,53 @@
+package outbox
+
+import (
+	"context"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+	"testing"
+	"userservice/internal/config"
+	"userservice/internal/infrastructure/cloudevents"
+	"userservice/internal/infrastructure/messaging"
+	"userservice/internal/infrastructure/serialization"
+	"userservice/proto/kafkaschema"
+)
+
+func newTestEntry(t *testing.T, serializer serialization.Serializer, envelope *cloudevents.Envelope, event proto.Message) messaging.OutboxEntry {
+	value, err := serializer.Serialize("userservice.user.added", event)
+	require.NoError(t, err)
+	msg := messaging.NewInternalMessage("userservice.user.added", []byte("user-1"), value)
+	metadata := messaging.NewEventMetadata(context.Background(), msg.ID, event)
+	msg.Headers = metadata.Headers()
+	require.NoError(t, envelope.Wrap(msg, "UserAdded", "user-1", metadata.OccurredAt))
+	return messaging.OutboxEntry{ID: msg.ID, Topic: msg.TopicID, Key: string(msg.Key), Value: msg.Value, Headers: msg.Headers}
+}
+
+func TestDecodePayload(t *testing.T) {
+	event := &kafkaschema.UserAddedMessage{Id: "user-1", FirstName: "John", Country: "DK"}
+
+	for _, format := range []serialization.Format{serialization.FormatProtobuf, serialization.FormatProtoJSON} {
+		serializer, err := serialization.NewSerializer(config.SerializationConfig{DefaultFormat: string(format)}, nil)
+		require.NoError(t, err)
+		structured, err := cloudevents.NewEnvelope(config.CloudEventsConfig{
+			Source: "/userservice",
+			Topics: map[string]string{"userservice.user.added": string(cloudevents.ModeStructured)},
+		}, serializer)
+		require.NoError(t, err)
+
+		for _, envelope := range []*cloudevents.Envelope{nil, structured} {
+			decoded, err := DecodePayload(serializer, newTestEntry(t, serializer, envelope, event))
+			require.NoError(t, err)
+			require.True(t, proto.Equal(event, decoded), "%s", format)
+		}
+	}
+}
+
+func TestDecodePayloadWithoutEventType(t *testing.T) {
+	serializer, err := serialization.NewSerializer(config.SerializationConfig{}, nil)
+	require.NoError(t, err)
+	entry := newTestEntry(t, serializer, nil, &kafkaschema.UserAddedMessage{Id: "user-1"})
+	entry.Headers = nil
+
+	_, err = DecodePayload(serializer, entry)
+	require.ErrorIs(t, err, errUnknownEventType)
+}
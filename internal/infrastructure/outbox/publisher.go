@@ -0,0 +1,12 @@
+package outbox
+
+import "userservice/internal/infrastructure/messaging"
+
+// Publisher sends outbox messages to wherever the events are consumed
+type Publisher interface {
+	// Publish hands the message over without waiting for it to be delivered. When it returns without error, exactly
+	// one delivery report is sent on the returned channel, nil meaning that the message was delivered
+	Publish(msg messaging.KafkaInternalMessage) (<-chan error, error)
+	// Close waits for outstanding delivery reports and releases the publisher
+	Close()
+}
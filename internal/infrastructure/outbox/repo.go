@@ -2,14 +2,24 @@ package outbox
 
 import (
 	"context"
-	"errors"
+	"time"
 	"userservice/internal/infrastructure/messaging"
 )
 
-var ErrNoPendingMessage = errors.New("no pending message found")
-
 type MessageOutboxRepo interface {
-	GetPendingMessage(ctx context.Context) (messaging.KafkaInternalMessage, error)
-	RetryMessage(ctx context.Context, id string)
+	// ClaimPendingMessages marks up to limit due messages of the given partitions as processing, leased to owner for
+	// leaseDuration, and returns them, an empty result means that nothing is due. A message is only due when all older
+	// messages with the same key are finished
+	ClaimPendingMessages(ctx context.Context, owner string, leaseDuration time.Duration, partitions []int, limit int) ([]messaging.KafkaInternalMessage, error)
+	// RetryMessage records a failed delivery attempt and returns whether the message is waiting for another one, it is
+	// dead lettered when it ran out of attempts. Nothing happens when owner lost the message to another instance meanwhile
+	RetryMessage(ctx context.Context, owner string, id string, cause error) bool
 	MarkMessageSent(ctx context.Context, id string)
+	// ReleaseExpiredLeases moves processing messages with an expired lease back to waiting and returns how many, the
+	// expired lease counts as a failed attempt so messages that ran out of attempts are dead lettered instead
+	ReleaseExpiredLeases(ctx context.Context) (int64, error)
+	// FindFinishedMessages returns up to limit messages of the given partitions that were sent before sentBefore
+	FindFinishedMessages(ctx context.Context, partitions []int, sentBefore time.Time, limit int) ([]messaging.OutboxEntry, error)
+	// DeleteFinishedMessages deletes the given messages if they are finished and returns how many were deleted
+	DeleteFinishedMessages(ctx context.Context, ids []string) (int64, error)
 }
@@ -0,0 +1,159 @@
+package outbox
+
+import (
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/rs/zerolog/log"
+	"os"
+	"path/filepath"
+	"time"
+	"userservice/internal/infrastructure/messaging"
+)
+
+const (
+	defaultPurgeIntervalSeconds = 3600
+	defaultPurgeBatchSize       = 500
+)
+
+// archive is a gzip compressed JSONL file with one outbox entry per line, it is only created once there is something
+// to write
+type archive struct {
+	path    string
+	file    *os.File
+	gzip    *gzip.Writer
+	encoder *json.Encoder
+}
+
+func newArchive(directory string, instanceID string, now time.Time) *archive {
+	name := fmt.Sprintf("outbox-%s-%s.jsonl.gz", instanceID, now.UTC().Format("20060102T150405Z"))
+	return &archive{path: filepath.Join(directory, name)}
+}
+
+func (a *archive) write(entries []messaging.OutboxEntry) error {
+	if a.file == nil {
+		err := os.MkdirAll(filepath.Dir(a.path), 0o755)
+		if err != nil {
+			return err
+		}
+		a.file, err = os.Create(a.path)
+		if err != nil {
+			return err
+		}
+		a.gzip = gzip.NewWriter(a.file)
+		a.encoder = json.NewEncoder(a.gzip)
+	}
+
+	for _, entry := range entries {
+		err := a.encoder.Encode(entry)
+		if err != nil {
+			return err
+		}
+	}
+	// make sure the entries are on disk before they are deleted from the outbox
+	err := a.gzip.Flush()
+	if err != nil {
+		return err
+	}
+	return a.file.Sync()
+}
+
+func (a *archive) close() error {
+	if a.file == nil {
+		return nil
+	}
+	err := a.gzip.Close()
+	if err != nil {
+		_ = a.file.Close()
+		return err
+	}
+	return a.file.Close()
+}
+
+// retention purges expired messages every purge interval until ctx is cancelled
+func (o *outbox) retention(ctx context.Context) {
+	ticker := time.NewTicker(time.Duration(o.config.Retention.PurgeIntervalSeconds) * time.Second)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			o.purge(ctx)
+		}
+	}
+}
+
+// purge deletes the finished messages of the owned partitions that are past retention, archiving them first when an
+// archive directory is configured
+func (o *outbox) purge(ctx context.Context) {
+	partitions := o.coordinator.ownedPartitions()
+	if len(partitions) == 0 {
+		return
+	}
+
+	now := time.Now().UTC()
+	sentBefore := now.Add(-time.Duration(o.config.Retention.FinishedRetentionSeconds) * time.Second)
+	var currentArchive *archive
+	if o.config.Retention.ArchiveDirectory != "" {
+		currentArchive = newArchive(o.config.Retention.ArchiveDirectory, o.instanceID, now)
+	}
+
+	purged, archived, err := o.purgeBatches(ctx, partitions, sentBefore, currentArchive)
+	if currentArchive != nil {
+		closeErr := currentArchive.close()
+		if err == nil {
+			err = closeErr
+		}
+	}
+	if err != nil {
+		log.Error().Err(err).Msg("Outbox: failed to purge finished messages")
+	}
+	if purged > 0 {
+		log.Info().Msgf("Outbox: purged %d finished messages, archived %d", purged, archived)
+	}
+
+	lastArchive := ""
+	if currentArchive != nil && archived > 0 {
+		lastArchive = currentArchive.path
+	}
+	o.recordPurge(now, purged, archived, lastArchive, err)
+}
+
+func (o *outbox) purgeBatches(ctx context.Context, partitions []int, sentBefore time.Time, currentArchive *archive) (int64, int64, error) {
+	purged := int64(0)
+	archived := int64(0)
+	for {
+		entries, err := o.kafkaOutboxRepo.FindFinishedMessages(ctx, partitions, sentBefore, o.config.Retention.PurgeBatchSize)
+		if err != nil {
+			return purged, archived, err
+		}
+		if len(entries) == 0 {
+			return purged, archived, nil
+		}
+
+		if currentArchive != nil {
+			err = currentArchive.write(entries)
+			if err != nil {
+				return purged, archived, err
+			}
+			archived += int64(len(entries))
+		}
+
+		ids := make([]string, 0, len(entries))
+		for _, entry := range entries {
+			ids = append(ids, entry.ID)
+		}
+		deleted, err := o.kafkaOutboxRepo.DeleteFinishedMessages(ctx, ids)
+		purged += deleted
+		if err != nil {
+			return purged, archived, err
+		}
+
+		if len(entries) < o.config.Retention.PurgeBatchSize {
+			return purged, archived, nil
+		}
+	}
+}
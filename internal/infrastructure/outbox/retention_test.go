@@ -0,0 +1,98 @@
+package outbox
+
+import (
+	"bufio"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"github.com/stretchr/testify/require"
+	"os"
+	"testing"
+	"time"
+	"userservice/internal/config"
+	"userservice/internal/infrastructure/messaging"
+	"userservice/internal/infrastructure/metrics"
+	"userservice/internal/infrastructure/publisher"
+	"userservice/internal/mock"
+)
+
+func newTestRetentionOutbox(repo MessageOutboxRepo, retention config.OutboxRetentionConfig) *outbox {
+	o := NewKafkaOutbox(publisher.NewMemoryPublisher(), repo, mock.NewCoordinationRepoMock(), nil, metrics.NewMetrics(), config.OutboxConfig{InstanceID: "owner", Retention: retention}).(*outbox)
+	o.coordinator.rebalance(context.Background())
+	return o
+}
+
+// putFinishedMessages stores count messages that were sent age ago
+func putFinishedMessages(t *testing.T, repo *mock.MessageOutboxRepoMock, count int, age time.Duration) {
+	for range count {
+		msg := messaging.NewInternalMessage("userservice.user.added", []byte("user-1"), []byte("value"))
+		require.NoError(t, repo.PutMessageInOutbox(context.Background(), *msg))
+		repo.MarkMessageSent(context.Background(), msg.ID)
+		repo.Entries[len(repo.Entries)-1].SentAt = time.Now().UTC().Add(-age)
+	}
+}
+
+func readArchive(t *testing.T, path string) []messaging.OutboxEntry {
+	file, err := os.Open(path)
+	require.NoError(t, err)
+	defer file.Close()
+	reader, err := gzip.NewReader(file)
+	require.NoError(t, err)
+
+	var entries []messaging.OutboxEntry
+	scanner := bufio.NewScanner(reader)
+	for scanner.Scan() {
+		var entry messaging.OutboxEntry
+		require.NoError(t, json.Unmarshal(scanner.Bytes(), &entry))
+		entries = append(entries, entry)
+	}
+	require.NoError(t, scanner.Err())
+	return entries
+}
+
+func TestPurgeOnlyRemovesExpiredMessages(t *testing.T) {
+	repo := mock.NewMessageOutboxRepoMock()
+	putFinishedMessages(t, repo, 3, 2*time.Hour)
+	putFinishedMessages(t, repo, 2, time.Minute)
+	waiting := messaging.NewInternalMessage("userservice.user.added", []byte("user-1"), []byte("value"))
+	require.NoError(t, repo.PutMessageInOutbox(context.Background(), *waiting))
+
+	o := newTestRetentionOutbox(repo, config.OutboxRetentionConfig{FinishedRetentionSeconds: 3600, PurgeBatchSize: 2})
+	o.purge(context.Background())
+
+	require.Len(t, repo.Entries, 3)
+	status := o.Status()
+	require.Equal(t, int64(3), status.Purged)
+	require.Equal(t, int64(0), status.Archived)
+	require.Empty(t, status.LastArchive)
+	require.False(t, status.LastPurgeAt.IsZero())
+}
+
+func TestPurgeArchivesBeforeDeleting(t *testing.T) {
+	repo := mock.NewMessageOutboxRepoMock()
+	putFinishedMessages(t, repo, 5, 2*time.Hour)
+	expected := append([]messaging.OutboxEntry(nil), repo.Entries...)
+
+	directory := t.TempDir()
+	o := newTestRetentionOutbox(repo, config.OutboxRetentionConfig{FinishedRetentionSeconds: 3600, PurgeBatchSize: 2, ArchiveDirectory: directory})
+	o.purge(context.Background())
+
+	require.Empty(t, repo.Entries)
+	status := o.Status()
+	require.Equal(t, int64(5), status.Purged)
+	require.Equal(t, int64(5), status.Archived)
+
+	archived := readArchive(t, status.LastArchive)
+	require.Len(t, archived, 5)
+	for i, entry := range archived {
+		require.Equal(t, expected[i].ID, entry.ID)
+		require.Equal(t, expected[i].Value, entry.Value)
+		require.Equal(t, "finished", entry.State)
+	}
+
+	// nothing left to purge, so no empty archive is created
+	o.purge(context.Background())
+	files, err := os.ReadDir(directory)
+	require.NoError(t, err)
+	require.Len(t, files, 1)
+}
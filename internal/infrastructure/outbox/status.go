@@ -0,0 +1,41 @@
+package outbox
+
+import (
+	"time"
+)
+
+// Status is what the outbox of this replica has been doing since it started
+type Status struct {
+	InstanceID      string
+	OwnedPartitions []int
+	// Purged and Archived count the finished messages removed by retention
+	Purged         int64
+	Archived       int64
+	LastPurgeAt    time.Time
+	LastArchive    string
+	LastPurgeError string
+}
+
+func (o *outbox) recordPurge(at time.Time, purged int64, archived int64, lastArchive string, err error) {
+	o.statusLock.Lock()
+	defer o.statusLock.Unlock()
+	o.status.Purged += purged
+	o.status.Archived += archived
+	o.status.LastPurgeAt = at
+	if lastArchive != "" {
+		o.status.LastArchive = lastArchive
+	}
+	o.status.LastPurgeError = ""
+	if err != nil {
+		o.status.LastPurgeError = err.Error()
+	}
+}
+
+func (o *outbox) Status() Status {
+	o.statusLock.Lock()
+	defer o.statusLock.Unlock()
+	status := o.status
+	status.InstanceID = o.instanceID
+	status.OwnedPartitions = o.coordinator.ownedPartitions()
+	return status
+}
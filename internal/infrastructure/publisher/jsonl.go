@@ -0,0 +1,71 @@
+package publisher
+
+import (
+	"encoding/json"
+	"io"
+	"os"
+	"sync"
+	"userservice/internal/infrastructure/messaging"
+)
+
+// jsonlMessage is a line written by the JSONL publisher, the value is base64 encoded like every []byte in JSON
+type jsonlMessage struct {
+	ID    string `json:"id"`
+	Topic string `json:"topic"`
+	Key   string `json:"key"`
+	Value []byte `json:"value"`
+	// Headers are written as an object, keys are unique for the headers we send
+	Headers map[string]string `json:"headers,omitempty"`
+}
+
+// JSONLPublisher writes every message as a line of JSON, for local development without kafka
+type JSONLPublisher struct {
+	lock    sync.Mutex
+	file    *os.File
+	encoder *json.Encoder
+}
+
+// NewJSONLPublisher appends to the file at path, or writes to stdout when path is empty or "-"
+func NewJSONLPublisher(path string) (*JSONLPublisher, error) {
+	if path == "" || path == "-" {
+		return newJSONLPublisher(os.Stdout, nil), nil
+	}
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o644)
+	if err != nil {
+		return nil, err
+	}
+	return newJSONLPublisher(file, file), nil
+}
+
+func newJSONLPublisher(writer io.Writer, file *os.File) *JSONLPublisher {
+	return &JSONLPublisher{file: file, encoder: json.NewEncoder(writer)}
+}
+
+func (j *JSONLPublisher) Publish(msg messaging.KafkaInternalMessage) (<-chan error, error) {
+	j.lock.Lock()
+	defer j.lock.Unlock()
+
+	var headers map[string]string
+	for _, header := range msg.Headers {
+		if headers == nil {
+			headers = map[string]string{}
+		}
+		headers[header.Key] = header.Value
+	}
+	err := j.encoder.Encode(jsonlMessage{ID: msg.ID, Topic: msg.TopicID, Key: string(msg.Key), Value: msg.Value, Headers: headers})
+	if err == nil && j.file != nil {
+		err = j.file.Sync()
+	}
+
+	report := make(chan error, 1)
+	report <- err
+	return report, nil
+}
+
+func (j *JSONLPublisher) Close() {
+	j.lock.Lock()
+	defer j.lock.Unlock()
+	if j.file != nil {
+		_ = j.file.Close()
+	}
+}
@@ -0,0 +1,61 @@
+package publisher
+
+import (
+	"errors"
+	confkafka "github.com/confluentinc/confluent-kafka-go/v2/kafka"
+	"userservice/internal/infrastructure/messaging"
+)
+
+// flushTimeoutMs is how long Close waits for messages that are still on their way to the brokers
+const flushTimeoutMs = 10000
+
+var errUnexpectedDeliveryEvent = errors.New("unexpected delivery event")
+
+type KafkaPublisher struct {
+	producer *confkafka.Producer
+}
+
+func NewKafkaPublisher(producer *confkafka.Producer) *KafkaPublisher {
+	return &KafkaPublisher{producer: producer}
+}
+
+func toConfluentKafkaMessage(m messaging.KafkaInternalMessage) *confkafka.Message {
+	var headers []confkafka.Header
+	for _, header := range m.Headers {
+		headers = append(headers, confkafka.Header{Key: header.Key, Value: []byte(header.Value)})
+	}
+	return &confkafka.Message{
+		TopicPartition: confkafka.TopicPartition{
+			Topic:     &m.TopicID,
+			Partition: confkafka.PartitionAny,
+		},
+		Value:   m.Value,
+		Key:     m.Key,
+		Headers: headers,
+	}
+}
+
+func (k *KafkaPublisher) Publish(msg messaging.KafkaInternalMessage) (<-chan error, error) {
+	deliveryChan := make(chan confkafka.Event, 1)
+	err := k.producer.Produce(toConfluentKafkaMessage(msg), deliveryChan)
+	if err != nil {
+		return nil, err
+	}
+
+	report := make(chan error, 1)
+	go func() {
+		event := <-deliveryChan
+		deliveredMessage, ok := event.(*confkafka.Message)
+		if !ok {
+			report <- errUnexpectedDeliveryEvent
+			return
+		}
+		report <- deliveredMessage.TopicPartition.Error
+	}()
+	return report, nil
+}
+
+func (k *KafkaPublisher) Close() {
+	k.producer.Flush(flushTimeoutMs)
+	k.producer.Close()
+}
@@ -0,0 +1,59 @@
+package publisher
+
+import (
+	"sync"
+	"userservice/internal/infrastructure/messaging"
+)
+
+// MemoryPublisher keeps published messages in memory, for tests
+type MemoryPublisher struct {
+	lock      sync.Mutex
+	published []messaging.KafkaInternalMessage
+	// publishErr and deliveryErr make the next publishes fail, before or after handing the message over
+	publishErr  error
+	deliveryErr error
+}
+
+func NewMemoryPublisher() *MemoryPublisher {
+	return &MemoryPublisher{}
+}
+
+// FailPublishing makes Publish return err until it is called again with nil
+func (m *MemoryPublisher) FailPublishing(err error) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	m.publishErr = err
+}
+
+// FailDelivery reports err for every published message until it is called again with nil
+func (m *MemoryPublisher) FailDelivery(err error) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	m.deliveryErr = err
+}
+
+// Published returns the delivered messages in the order they were published
+func (m *MemoryPublisher) Published() []messaging.KafkaInternalMessage {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	return append([]messaging.KafkaInternalMessage(nil), m.published...)
+}
+
+func (m *MemoryPublisher) Publish(msg messaging.KafkaInternalMessage) (<-chan error, error) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	if m.publishErr != nil {
+		return nil, m.publishErr
+	}
+
+	report := make(chan error, 1)
+	if m.deliveryErr != nil {
+		report <- m.deliveryErr
+		return report, nil
+	}
+	m.published = append(m.published, msg)
+	report <- nil
+	return report, nil
+}
+
+func (m *MemoryPublisher) Close() {}
@@ -0,0 +1,87 @@
+package publisher
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"github.com/stretchr/testify/require"
+	"os"
+	"path/filepath"
+	"testing"
+	"userservice/internal/infrastructure/messaging"
+)
+
+func TestMemoryPublisher(t *testing.T) {
+	publisher := NewMemoryPublisher()
+	first := messaging.NewInternalMessage("userservice.user.added", []byte("user-1"), []byte("first"))
+
+	report, err := publisher.Publish(*first)
+	require.NoError(t, err)
+	require.NoError(t, <-report)
+
+	publisher.FailPublishing(errors.New("broker down"))
+	_, err = publisher.Publish(*first)
+	require.Error(t, err)
+	publisher.FailPublishing(nil)
+
+	publisher.FailDelivery(errors.New("message too large"))
+	report, err = publisher.Publish(*first)
+	require.NoError(t, err)
+	require.Error(t, <-report)
+
+	require.Len(t, publisher.Published(), 1)
+	require.Equal(t, first.ID, publisher.Published()[0].ID)
+}
+
+func TestJSONLPublisherAppendsLines(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "events.jsonl")
+	messages := []*messaging.KafkaInternalMessage{
+		messaging.NewInternalMessage("userservice.user.added", []byte("user-1"), []byte("first")),
+		messaging.NewInternalMessage("userservice.user.removed", []byte("user-1"), []byte("second")),
+	}
+	messages[1].Headers = []messaging.Header{{Key: messaging.HeaderEventType, Value: "kafkaschema.UserRemovedMessage"}}
+
+	for _, msg := range messages {
+		publisher, err := NewJSONLPublisher(path)
+		require.NoError(t, err)
+		report, err := publisher.Publish(*msg)
+		require.NoError(t, err)
+		require.NoError(t, <-report)
+		publisher.Close()
+	}
+
+	file, err := os.Open(path)
+	require.NoError(t, err)
+	defer file.Close()
+	var lines []jsonlMessage
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		var line jsonlMessage
+		require.NoError(t, json.Unmarshal(scanner.Bytes(), &line))
+		lines = append(lines, line)
+	}
+
+	require.Len(t, lines, 2)
+	for i, msg := range messages {
+		require.Equal(t, msg.ID, lines[i].ID)
+		require.Equal(t, msg.TopicID, lines[i].Topic)
+		require.Equal(t, string(msg.Key), lines[i].Key)
+		require.Equal(t, msg.Value, lines[i].Value)
+	}
+	require.Nil(t, lines[0].Headers)
+	require.Equal(t, map[string]string{"event-type": "kafkaschema.UserRemovedMessage"}, lines[1].Headers)
+}
+
+func TestKafkaMessageHeaders(t *testing.T) {
+	msg := messaging.NewInternalMessage("userservice.user.added", []byte("user-1"), []byte("value"))
+	msg.Headers = []messaging.Header{
+		{Key: messaging.HeaderMessageID, Value: msg.ID},
+		{Key: messaging.HeaderTraceParent, Value: "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01"},
+	}
+
+	converted := toConfluentKafkaMessage(*msg)
+	require.Len(t, converted.Headers, 2)
+	require.Equal(t, messaging.HeaderMessageID, converted.Headers[0].Key)
+	require.Equal(t, []byte(msg.ID), converted.Headers[0].Value)
+	require.Equal(t, []byte("00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01"), converted.Headers[1].Value)
+}
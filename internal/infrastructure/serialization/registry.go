@@ -0,0 +1,50 @@
+package serialization
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+)
+
+// SchemaRegistry resolves the schema id of a subject, the same way a confluent schema registry would
+type SchemaRegistry interface {
+	SchemaID(subject string) (int32, error)
+}
+
+type registeredSchema struct {
+	ID int32 `json:"id"`
+	// Schema points at the published .proto file consumers should decode with
+	Schema string `json:"schema"`
+}
+
+type fileSchemaRegistry struct {
+	Subjects map[string]registeredSchema `json:"subjects"`
+}
+
+// NewFileSchemaRegistry loads a local stand-in for a schema registry, see config/schemaregistry.json
+func NewFileSchemaRegistry(path string) (SchemaRegistry, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	registry := &fileSchemaRegistry{}
+	err = json.Unmarshal(data, registry)
+	if err != nil {
+		return nil, err
+	}
+	return registry, nil
+}
+
+func (f *fileSchemaRegistry) SchemaID(subject string) (int32, error) {
+	schema, ok := f.Subjects[subject]
+	if !ok {
+		return 0, fmt.Errorf("subject %s is not registered", subject)
+	}
+	return schema.ID, nil
+}
+
+// SubjectForTopic uses the TopicNameStrategy, which is the default of the confluent serializers
+func SubjectForTopic(topic string) string {
+	return topic + "-value"
+}
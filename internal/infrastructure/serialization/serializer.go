@@ -0,0 +1,179 @@
+package serialization
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/encoding/protowire"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"userservice/internal/config"
+)
+
+type Format string
+
+const (
+	FormatProtobuf          Format = "protobuf"
+	FormatProtoJSON         Format = "protojson"
+	FormatConfluentProtobuf Format = "confluent-protobuf"
+)
+
+// confluentMagicByte starts every message in the confluent wire format, followed by the schema id
+const confluentMagicByte = 0
+
+var errMissingSchemaRegistry = errors.New("confluent-protobuf format requires a schema registry")
+var errNotConfluentFramed = errors.New("data is not in the confluent wire format")
+
+// Serializer turns event messages into the bytes published on a topic and back again
+type Serializer interface {
+	Serialize(topic string, message proto.Message) ([]byte, error)
+	Deserialize(topic string, data []byte, message proto.Message) error
+	// FormatOf is the format the events of topic are serialized in
+	FormatOf(topic string) Format
+}
+
+type topicSerializer struct {
+	defaultFormat Format
+	topicFormats  map[string]Format
+	registry      SchemaRegistry
+}
+
+func parseFormat(raw string) (Format, error) {
+	switch Format(raw) {
+	case "":
+		return FormatProtobuf, nil
+	case FormatProtobuf, FormatProtoJSON, FormatConfluentProtobuf:
+		return Format(raw), nil
+	}
+	return "", fmt.Errorf("unknown serialization format %s", raw)
+}
+
+// NewSerializer picks the format per topic, registry is only needed when a topic uses the confluent wire format
+func NewSerializer(serializationConfig config.SerializationConfig, registry SchemaRegistry) (Serializer, error) {
+	defaultFormat, err := parseFormat(serializationConfig.DefaultFormat)
+	if err != nil {
+		return nil, err
+	}
+	usesConfluent := defaultFormat == FormatConfluentProtobuf
+
+	topicFormats := map[string]Format{}
+	for topic, rawFormat := range serializationConfig.TopicFormats {
+		format, err := parseFormat(rawFormat)
+		if err != nil {
+			return nil, fmt.Errorf("topic %s: %w", topic, err)
+		}
+		topicFormats[topic] = format
+		usesConfluent = usesConfluent || format == FormatConfluentProtobuf
+	}
+
+	if usesConfluent && registry == nil {
+		return nil, errMissingSchemaRegistry
+	}
+
+	return &topicSerializer{
+		defaultFormat: defaultFormat,
+		topicFormats:  topicFormats,
+		registry:      registry,
+	}, nil
+}
+
+func (t *topicSerializer) FormatOf(topic string) Format {
+	format, ok := t.topicFormats[topic]
+	if !ok {
+		return t.defaultFormat
+	}
+	return format
+}
+
+func (t *topicSerializer) Serialize(topic string, message proto.Message) ([]byte, error) {
+	switch t.FormatOf(topic) {
+	case FormatProtoJSON:
+		return protojson.Marshal(message)
+	case FormatConfluentProtobuf:
+		return t.serializeConfluent(topic, message)
+	}
+	return proto.Marshal(message)
+}
+
+func (t *topicSerializer) Deserialize(topic string, data []byte, message proto.Message) error {
+	switch t.FormatOf(topic) {
+	case FormatProtoJSON:
+		return protojson.Unmarshal(data, message)
+	case FormatConfluentProtobuf:
+		return t.deserializeConfluent(topic, data, message)
+	}
+	return proto.Unmarshal(data, message)
+}
+
+// serializeConfluent writes magic byte, big endian schema id, the message indexes and then the protobuf payload
+// see https://docs.confluent.io/platform/current/schema-registry/fundamentals/serdes-develop/index.html#wire-format
+func (t *topicSerializer) serializeConfluent(topic string, message proto.Message) ([]byte, error) {
+	schemaID, err := t.registry.SchemaID(SubjectForTopic(topic))
+	if err != nil {
+		return nil, err
+	}
+	payload, err := proto.Marshal(message)
+	if err != nil {
+		return nil, err
+	}
+
+	data := []byte{confluentMagicByte}
+	data = binary.BigEndian.AppendUint32(data, uint32(schemaID))
+	data = appendMessageIndexes(data, message.ProtoReflect().Descriptor())
+	return append(data, payload...), nil
+}
+
+func (t *topicSerializer) deserializeConfluent(topic string, data []byte, message proto.Message) error {
+	if len(data) < 5 || data[0] != confluentMagicByte {
+		return errNotConfluentFramed
+	}
+	schemaID, err := t.registry.SchemaID(SubjectForTopic(topic))
+	if err != nil {
+		return err
+	}
+	if writtenID := int32(binary.BigEndian.Uint32(data[1:5])); writtenID != schemaID {
+		return fmt.Errorf("data was written with schema %d, topic %s uses schema %d", writtenID, topic, schemaID)
+	}
+
+	rest := data[5:]
+	count, n := protowire.ConsumeVarint(rest)
+	if n < 0 {
+		return errNotConfluentFramed
+	}
+	rest = rest[n:]
+	// a single 0 is the short form of [0], otherwise the count is followed by that many indexes
+	if count != 0 {
+		for range protowire.DecodeZigZag(count) {
+			_, n = protowire.ConsumeVarint(rest)
+			if n < 0 {
+				return errNotConfluentFramed
+			}
+			rest = rest[n:]
+		}
+	}
+	return proto.Unmarshal(rest, message)
+}
+
+// appendMessageIndexes writes the path of the message inside its .proto file as zigzag varints
+func appendMessageIndexes(data []byte, descriptor protoreflect.MessageDescriptor) []byte {
+	var indexes []int
+	var current protoreflect.Descriptor = descriptor
+	for {
+		messageDescriptor, ok := current.(protoreflect.MessageDescriptor)
+		if !ok {
+			break
+		}
+		indexes = append([]int{messageDescriptor.Index()}, indexes...)
+		current = messageDescriptor.Parent()
+	}
+
+	if len(indexes) == 1 && indexes[0] == 0 {
+		return append(data, 0)
+	}
+	data = protowire.AppendVarint(data, protowire.EncodeZigZag(int64(len(indexes))))
+	for _, index := range indexes {
+		data = protowire.AppendVarint(data, protowire.EncodeZigZag(int64(index)))
+	}
+	return data
+}
@@ -0,0 +1,115 @@
+package serialization
+
+import (
+	"encoding/binary"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"os"
+	"path/filepath"
+	"testing"
+	"userservice/internal/config"
+	"userservice/proto/kafkaschema"
+)
+
+const (
+	addedTopic   = "userservice.user.added"
+	updatedTopic = "userservice.user.updated"
+)
+
+type staticRegistry map[string]int32
+
+func (s staticRegistry) SchemaID(subject string) (int32, error) {
+	id, ok := s[subject]
+	if !ok {
+		return 0, os.ErrNotExist
+	}
+	return id, nil
+}
+
+func newTestSerializer(t *testing.T, defaultFormat Format) Serializer {
+	serializer, err := NewSerializer(config.SerializationConfig{
+		DefaultFormat: string(defaultFormat),
+		TopicFormats:  map[string]string{updatedTopic: string(FormatConfluentProtobuf)},
+	}, staticRegistry{SubjectForTopic(addedTopic): 7, SubjectForTopic(updatedTopic): 300})
+	require.NoError(t, err)
+	return serializer
+}
+
+func TestProtobufRoundTrip(t *testing.T) {
+	serializer := newTestSerializer(t, FormatProtobuf)
+	message := &kafkaschema.UserAddedMessage{Id: "id", FirstName: "John"}
+
+	data, err := serializer.Serialize(addedTopic, message)
+	require.NoError(t, err)
+
+	// plain protobuf, readable by any protobuf runtime
+	var decoded kafkaschema.UserAddedMessage
+	require.NoError(t, proto.Unmarshal(data, &decoded))
+	require.True(t, proto.Equal(message, &decoded))
+}
+
+func TestProtoJSONUsesLowerCamelNames(t *testing.T) {
+	serializer := newTestSerializer(t, FormatProtoJSON)
+	message := &kafkaschema.UserAddedMessage{Id: "id", FirstName: "John"}
+
+	data, err := serializer.Serialize(addedTopic, message)
+	require.NoError(t, err)
+	require.Contains(t, string(data), `"firstName"`)
+
+	var decoded kafkaschema.UserAddedMessage
+	require.NoError(t, protojson.Unmarshal(data, &decoded))
+	require.True(t, proto.Equal(message, &decoded))
+}
+
+func TestConfluentWireFormat(t *testing.T) {
+	serializer := newTestSerializer(t, FormatConfluentProtobuf)
+
+	// UserAddedMessage is the first message in its file, which uses the single 0 short form
+	added := &kafkaschema.UserAddedMessage{Id: "id"}
+	data, err := serializer.Serialize(addedTopic, added)
+	require.NoError(t, err)
+	require.Equal(t, byte(0), data[0])
+	require.Equal(t, uint32(7), binary.BigEndian.Uint32(data[1:5]))
+	require.Equal(t, byte(0), data[5])
+	payload, err := proto.Marshal(added)
+	require.NoError(t, err)
+	require.Equal(t, payload, data[6:])
+
+	// UserUpdatedMessage is the second message, so the indexes are [1] written as zigzag count 1 and index 1
+	updated := &kafkaschema.UserUpdatedMessage{Id: "id", PasswordChanged: true}
+	data, err = serializer.Serialize(updatedTopic, updated)
+	require.NoError(t, err)
+	require.Equal(t, uint32(300), binary.BigEndian.Uint32(data[1:5]))
+	require.Equal(t, []byte{2, 2}, data[5:7])
+
+	var decoded kafkaschema.UserUpdatedMessage
+	require.NoError(t, serializer.Deserialize(updatedTopic, data, &decoded))
+	require.True(t, proto.Equal(updated, &decoded))
+
+	// unframed data is rejected
+	require.Error(t, serializer.Deserialize(updatedTopic, []byte{1, 2, 3}, &decoded))
+}
+
+func TestNewSerializerValidation(t *testing.T) {
+	_, err := NewSerializer(config.SerializationConfig{DefaultFormat: "xml"}, nil)
+	require.Error(t, err)
+
+	_, err = NewSerializer(config.SerializationConfig{TopicFormats: map[string]string{addedTopic: string(FormatConfluentProtobuf)}}, nil)
+	require.ErrorIs(t, err, errMissingSchemaRegistry)
+}
+
+func TestFileSchemaRegistry(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "registry.json")
+	require.NoError(t, os.WriteFile(path, []byte(`{"subjects":{"userservice.user.added-value":{"id":4,"schema":"proto/kafkaschema/user_added.proto"}}}`), 0644))
+
+	registry, err := NewFileSchemaRegistry(path)
+	require.NoError(t, err)
+
+	id, err := registry.SchemaID(SubjectForTopic(addedTopic))
+	require.NoError(t, err)
+	require.Equal(t, int32(4), id)
+
+	_, err = registry.SchemaID("unknown-value")
+	require.Error(t, err)
+}
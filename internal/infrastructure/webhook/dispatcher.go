@@ -0,0 +1,191 @@
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"github.com/rs/zerolog/log"
+	"io"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+	"userservice/internal/config"
+	"userservice/internal/domain/domainerror"
+	"userservice/internal/domain/model"
+	"userservice/internal/util/crypto"
+)
+
+const (
+	defaultPollIntervalSeconds   = 1
+	defaultBatchSize             = 20
+	defaultConcurrency           = 4
+	defaultRequestTimeoutSeconds = 10
+	defaultLeaseDurationSeconds  = 60
+)
+
+// headers sent with every delivery, the signature covers the timestamp and the body
+const (
+	HeaderDeliveryID = "X-Userservice-Delivery"
+	HeaderEventType  = "X-Userservice-Event"
+	HeaderTimestamp  = "X-Userservice-Timestamp"
+	HeaderSignature  = "X-Userservice-Signature"
+)
+
+type Dispatcher interface {
+	CleanUp()
+	Run()
+}
+
+type dispatcher struct {
+	repo            DeliveryRepo
+	client          *http.Client
+	config          config.WebhookConfig
+	shutdownLock    sync.Mutex
+	shutdownChannel chan struct{}
+	hasBeenShutDown bool
+}
+
+func NewDispatcher(repo DeliveryRepo, config config.WebhookConfig) Dispatcher {
+	if config.PollIntervalSeconds <= 0 {
+		config.PollIntervalSeconds = defaultPollIntervalSeconds
+	}
+	if config.BatchSize <= 0 {
+		config.BatchSize = defaultBatchSize
+	}
+	if config.Concurrency <= 0 {
+		config.Concurrency = defaultConcurrency
+	}
+	if config.RequestTimeoutSeconds <= 0 {
+		config.RequestTimeoutSeconds = defaultRequestTimeoutSeconds
+	}
+	if config.LeaseDurationSeconds <= config.RequestTimeoutSeconds {
+		config.LeaseDurationSeconds = max(defaultLeaseDurationSeconds, 2*config.RequestTimeoutSeconds)
+	}
+
+	return &dispatcher{
+		repo:            repo,
+		client:          &http.Client{Timeout: time.Duration(config.RequestTimeoutSeconds) * time.Second},
+		config:          config,
+		shutdownChannel: make(chan struct{}),
+	}
+}
+
+func (d *dispatcher) CleanUp() {
+	d.shutdownLock.Lock()
+	defer d.shutdownLock.Unlock()
+	if d.hasBeenShutDown {
+		return
+	}
+	close(d.shutdownChannel)
+	d.hasBeenShutDown = true
+}
+
+func (d *dispatcher) Run() {
+	ctx := context.Background()
+	ticker := time.NewTicker(time.Duration(d.config.PollIntervalSeconds) * time.Second)
+	defer ticker.Stop()
+
+	log.Info().Msg("Webhooks: starting dispatcher")
+	for {
+		select {
+		case <-d.shutdownChannel:
+			log.Info().Msg("Webhooks: stopped dispatcher")
+			return
+		case <-ticker.C:
+			d.drain(ctx)
+		}
+	}
+}
+
+// drain sends due deliveries until none is left
+func (d *dispatcher) drain(ctx context.Context) {
+	leaseDuration := time.Duration(d.config.LeaseDurationSeconds) * time.Second
+	for {
+		deliveries, err := d.repo.ClaimDueWebhookDeliveries(ctx, leaseDuration, d.config.BatchSize)
+		if err != nil {
+			log.Error().Err(err).Msg("Webhooks: failed to claim due deliveries")
+		}
+		if len(deliveries) == 0 {
+			return
+		}
+
+		slots := make(chan struct{}, d.config.Concurrency)
+		wg := sync.WaitGroup{}
+		for _, delivery := range deliveries {
+			slots <- struct{}{}
+			wg.Add(1)
+			go func() {
+				defer func() { <-slots; wg.Done() }()
+				d.deliver(ctx, delivery)
+			}()
+		}
+		wg.Wait()
+
+		if len(deliveries) < d.config.BatchSize {
+			return
+		}
+	}
+}
+
+func (d *dispatcher) deliver(ctx context.Context, delivery model.WebhookDelivery) {
+	subscription, err := d.repo.GetWebhookSubscription(ctx, delivery.SubscriptionID)
+	if domainerror.KindOf(err) == domainerror.KindNotFound {
+		log.Info().Msgf("Webhooks: subscription %s was removed, cancelling delivery %s", delivery.SubscriptionID, delivery.ID)
+		err = d.repo.CancelWebhookDelivery(ctx, delivery.ID, delivery.LeaseToken, "subscription was removed")
+		if err != nil {
+			log.Error().Err(err).Msgf("Webhooks: failed to cancel delivery %s", delivery.ID)
+		}
+		return
+	}
+	if err != nil {
+		// the lease runs out and the delivery is claimed again
+		log.Error().Err(err).Msgf("Webhooks: failed to look up subscription %s", delivery.SubscriptionID)
+		return
+	}
+
+	attempt := d.send(ctx, subscription, delivery)
+	if attempt.Error == "" {
+		err = d.repo.MarkWebhookDelivered(ctx, delivery.ID, delivery.LeaseToken, attempt)
+	} else {
+		log.Warn().Msgf("Webhooks: delivery %s to %s failed: %s", delivery.ID, subscription.URL, attempt.Error)
+		err = d.repo.RetryWebhookDelivery(ctx, delivery.ID, delivery.LeaseToken, attempt)
+	}
+	if err != nil {
+		log.Error().Err(err).Msgf("Webhooks: failed to record attempt of delivery %s", delivery.ID)
+	}
+}
+
+// send posts the payload once, anything but a 2xx answer is a failed attempt
+func (d *dispatcher) send(ctx context.Context, subscription model.WebhookSubscription, delivery model.WebhookDelivery) model.WebhookAttempt {
+	attempt := model.WebhookAttempt{AttemptedAt: time.Now().UTC()}
+
+	request, err := http.NewRequestWithContext(ctx, http.MethodPost, subscription.URL, bytes.NewReader(delivery.Payload))
+	if err != nil {
+		attempt.Error = err.Error()
+		return attempt
+	}
+	timestamp := attempt.AttemptedAt.Unix()
+	request.Header.Set("Content-Type", "application/json")
+	request.Header.Set(HeaderDeliveryID, delivery.ID)
+	request.Header.Set(HeaderEventType, delivery.EventType)
+	request.Header.Set(HeaderTimestamp, strconv.FormatInt(timestamp, 10))
+	request.Header.Set(HeaderSignature, crypto.SignWebhookPayload(subscription.Secret, timestamp, delivery.Payload))
+
+	response, err := d.client.Do(request)
+	if err != nil {
+		attempt.Error = err.Error()
+		attempt.Duration = time.Since(attempt.AttemptedAt)
+		return attempt
+	}
+	defer response.Body.Close()
+	// drained so the connection can be reused
+	_, _ = io.Copy(io.Discard, io.LimitReader(response.Body, 64*1024))
+
+	attempt.StatusCode = response.StatusCode
+	if response.StatusCode < 200 || response.StatusCode > 299 {
+		attempt.Error = fmt.Sprintf("endpoint answered %d", response.StatusCode)
+	}
+	attempt.Duration = time.Since(attempt.AttemptedAt)
+	return attempt
+}
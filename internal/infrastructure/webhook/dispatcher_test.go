@@ -0,0 +1,182 @@
+package webhook
+
+import (
+	"context"
+	"github.com/stretchr/testify/require"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync"
+	"testing"
+	"userservice/internal/config"
+	"userservice/internal/domain/model"
+	"userservice/internal/mock"
+	"userservice/internal/util/crypto"
+)
+
+const testSecret = "top secret"
+
+type receivedRequest struct {
+	header http.Header
+	body   []byte
+}
+
+// testEndpoint answers with the next status of statuses, the last one is repeated
+type testEndpoint struct {
+	lock     sync.Mutex
+	statuses []int
+	received []receivedRequest
+}
+
+func (e *testEndpoint) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	e.lock.Lock()
+	defer e.lock.Unlock()
+	body, _ := io.ReadAll(r.Body)
+	e.received = append(e.received, receivedRequest{header: r.Header.Clone(), body: body})
+	status := e.statuses[0]
+	if len(e.statuses) > 1 {
+		e.statuses = e.statuses[1:]
+	}
+	w.WriteHeader(status)
+}
+
+func setUp(t *testing.T, statuses ...int) (*dispatcher, *mock.WebhookRepoMock, *testEndpoint) {
+	endpoint := &testEndpoint{statuses: statuses}
+	server := httptest.NewServer(endpoint)
+	t.Cleanup(server.Close)
+
+	repo := mock.NewWebhookRepoMock()
+	_, err := repo.AddWebhookSubscription(context.Background(), model.WebhookSubscription{
+		ID:     "subscription",
+		URL:    server.URL,
+		Secret: testSecret,
+	})
+	require.NoError(t, err)
+	repo.AddDelivery(model.WebhookDelivery{
+		ID:             "delivery",
+		SubscriptionID: "subscription",
+		EventID:        "event",
+		EventType:      model.EventTypeUserAdded,
+		Payload:        []byte(`{"id":"event","type":"UserAdded"}`),
+	})
+
+	d := NewDispatcher(repo, config.WebhookConfig{}).(*dispatcher)
+	return d, repo, endpoint
+}
+
+func TestDeliveryIsSigned(t *testing.T) {
+	d, repo, endpoint := setUp(t, http.StatusNoContent)
+
+	d.drain(context.Background())
+
+	require.Len(t, endpoint.received, 1)
+	received := endpoint.received[0]
+	require.Equal(t, `{"id":"event","type":"UserAdded"}`, string(received.body))
+	require.Equal(t, "application/json", received.header.Get("Content-Type"))
+	require.Equal(t, "delivery", received.header.Get(HeaderDeliveryID))
+	require.Equal(t, model.EventTypeUserAdded, received.header.Get(HeaderEventType))
+
+	timestamp, err := strconv.ParseInt(received.header.Get(HeaderTimestamp), 10, 64)
+	require.NoError(t, err)
+	require.True(t, crypto.VerifyWebhookSignature(testSecret, timestamp, received.body, received.header.Get(HeaderSignature)))
+	require.False(t, crypto.VerifyWebhookSignature("other secret", timestamp, received.body, received.header.Get(HeaderSignature)))
+	require.False(t, crypto.VerifyWebhookSignature(testSecret, timestamp+1, received.body, received.header.Get(HeaderSignature)))
+
+	delivery := repo.Delivery("delivery")
+	require.Equal(t, "delivered", delivery.State)
+	require.Len(t, delivery.Attempts, 1)
+	require.Equal(t, http.StatusNoContent, delivery.Attempts[0].StatusCode)
+	require.Empty(t, delivery.Attempts[0].Error)
+}
+
+func TestFailedDeliveryIsRetried(t *testing.T) {
+	d, repo, endpoint := setUp(t, http.StatusInternalServerError, http.StatusBadGateway, http.StatusOK)
+
+	for range 3 {
+		d.drain(context.Background())
+	}
+
+	require.Len(t, endpoint.received, 3)
+	delivery := repo.Delivery("delivery")
+	require.Equal(t, "delivered", delivery.State)
+	require.Len(t, delivery.Attempts, 3)
+	require.Equal(t, http.StatusInternalServerError, delivery.Attempts[0].StatusCode)
+	require.Equal(t, "endpoint answered 500", delivery.Attempts[0].Error)
+	require.Equal(t, http.StatusBadGateway, delivery.Attempts[1].StatusCode)
+	require.Equal(t, http.StatusOK, delivery.Attempts[2].StatusCode)
+}
+
+func TestDeliveryGivesUpAfterMaxAttempts(t *testing.T) {
+	d, repo, endpoint := setUp(t, http.StatusServiceUnavailable)
+	repo.MaxAttempts = 2
+
+	for range 3 {
+		d.drain(context.Background())
+	}
+
+	require.Len(t, endpoint.received, 2)
+	delivery := repo.Delivery("delivery")
+	require.Equal(t, "failed", delivery.State)
+	require.Equal(t, "endpoint answered 503", delivery.LastError)
+}
+
+func TestUnreachableEndpointIsRetried(t *testing.T) {
+	d, repo, _ := setUp(t, http.StatusOK)
+	repo.Subscriptions[0].URL = "http://127.0.0.1:1"
+
+	d.drain(context.Background())
+
+	delivery := repo.Delivery("delivery")
+	require.Equal(t, "waiting", delivery.State)
+	require.Len(t, delivery.Attempts, 1)
+	require.Zero(t, delivery.Attempts[0].StatusCode)
+	require.NotEmpty(t, delivery.Attempts[0].Error)
+}
+
+func TestDeliveryOfRemovedSubscriptionIsCancelled(t *testing.T) {
+	d, repo, endpoint := setUp(t, http.StatusOK)
+	_, err := repo.RemoveWebhookSubscription(context.Background(), "subscription")
+	require.NoError(t, err)
+
+	d.drain(context.Background())
+
+	require.Empty(t, endpoint.received)
+	require.Equal(t, "cancelled", repo.Delivery("delivery").State)
+}
+
+func TestStaleSenderCannotFinishReclaimedDelivery(t *testing.T) {
+	ctx := context.Background()
+	d, repo, _ := setUp(t, http.StatusOK)
+
+	// the first sender stalls past its lease and another instance claims the delivery again
+	stale, err := repo.ClaimDueWebhookDeliveries(ctx, 0, 1)
+	require.NoError(t, err)
+	require.Len(t, stale, 1)
+	d.drain(ctx)
+	require.Equal(t, "delivered", repo.Delivery("delivery").State)
+
+	err = repo.RetryWebhookDelivery(ctx, "delivery", stale[0].LeaseToken, model.WebhookAttempt{Error: "late failure"})
+	require.Error(t, err)
+	delivery := repo.Delivery("delivery")
+	require.Equal(t, "delivered", delivery.State)
+	// the lost lease and the successful attempt
+	require.Len(t, delivery.Attempts, 2)
+}
+
+func TestDeliveryThatKeepsLosingItsLeaseFails(t *testing.T) {
+	ctx := context.Background()
+	_, repo, endpoint := setUp(t, http.StatusOK)
+	repo.MaxAttempts = 2
+
+	for range 3 {
+		_, err := repo.ClaimDueWebhookDeliveries(ctx, 0, 1)
+		require.NoError(t, err)
+	}
+
+	require.Empty(t, endpoint.received)
+	delivery := repo.Delivery("delivery")
+	require.Equal(t, "failed", delivery.State)
+	require.Len(t, delivery.Attempts, 2)
+	require.Equal(t, "lease expired before the attempt was recorded", delivery.LastError)
+}
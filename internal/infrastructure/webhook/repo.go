@@ -0,0 +1,17 @@
+package webhook
+
+import (
+	"context"
+	"time"
+	"userservice/internal/domain/model"
+)
+
+type DeliveryRepo interface {
+	ClaimDueWebhookDeliveries(ctx context.Context, leaseDuration time.Duration, limit int) ([]model.WebhookDelivery, error)
+	GetWebhookSubscription(ctx context.Context, id string) (model.WebhookSubscription, error)
+	// MarkWebhookDelivered, RetryWebhookDelivery and CancelWebhookDelivery only record the outcome while the claim of
+	// leaseToken holds, a delivery that was claimed again in the meantime belongs to its new sender
+	MarkWebhookDelivered(ctx context.Context, deliveryID string, leaseToken string, attempt model.WebhookAttempt) error
+	RetryWebhookDelivery(ctx context.Context, deliveryID string, leaseToken string, attempt model.WebhookAttempt) error
+	CancelWebhookDelivery(ctx context.Context, deliveryID string, leaseToken string, reason string) error
+}
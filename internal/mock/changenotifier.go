@@ -0,0 +1,23 @@
+package mock
+
+import "context"
+
+// ChangeNotifierMock forwards every value sent on Changes as a notification
+type ChangeNotifierMock struct {
+	Changes chan struct{}
+}
+
+func NewChangeNotifierMock() *ChangeNotifierMock {
+	return &ChangeNotifierMock{Changes: make(chan struct{})}
+}
+
+func (c *ChangeNotifierMock) WatchMessages(ctx context.Context, notify func()) error {
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-c.Changes:
+			notify()
+		}
+	}
+}
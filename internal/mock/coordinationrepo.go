@@ -0,0 +1,79 @@
+package mock
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+)
+
+type lease struct {
+	owner     string
+	expiresAt time.Time
+}
+
+// CoordinationRepoMock is shared by several outboxes in a test, like the lease collection in mongodb
+type CoordinationRepoMock struct {
+	lock       sync.Mutex
+	members    map[string]time.Time
+	partitions map[int]lease
+}
+
+func NewCoordinationRepoMock() *CoordinationRepoMock {
+	return &CoordinationRepoMock{
+		members:    map[string]time.Time{},
+		partitions: map[int]lease{},
+	}
+}
+
+func (c *CoordinationRepoMock) Heartbeat(ctx context.Context, owner string, ttl time.Duration) error {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	c.members[owner] = time.Now().Add(ttl)
+	return nil
+}
+
+func (c *CoordinationRepoMock) LiveMembers(ctx context.Context) ([]string, error) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	var members []string
+	for member, expiresAt := range c.members {
+		if expiresAt.After(time.Now()) {
+			members = append(members, member)
+		}
+	}
+	sort.Strings(members)
+	return members, nil
+}
+
+func (c *CoordinationRepoMock) AcquirePartition(ctx context.Context, partition int, owner string, ttl time.Duration) (bool, error) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	current, ok := c.partitions[partition]
+	if ok && current.owner != owner && current.expiresAt.After(time.Now()) {
+		return false, nil
+	}
+	c.partitions[partition] = lease{owner: owner, expiresAt: time.Now().Add(ttl)}
+	return true, nil
+}
+
+func (c *CoordinationRepoMock) ReleasePartition(ctx context.Context, partition int, owner string) error {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	if c.partitions[partition].owner == owner {
+		delete(c.partitions, partition)
+	}
+	return nil
+}
+
+func (c *CoordinationRepoMock) Leave(ctx context.Context, owner string) error {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	delete(c.members, owner)
+	for partition, current := range c.partitions {
+		if current.owner == owner {
+			delete(c.partitions, partition)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,168 @@
+package mock
+
+import (
+	"context"
+	"time"
+	"userservice/internal/domain/domainerror"
+	"userservice/internal/infrastructure/messaging"
+)
+
+const (
+	waitingState      = "waiting"
+	finishedState     = "finished"
+	deadLetteredState = "dead_lettered"
+)
+
+var errOutboxMessageNotFound = domainerror.NotFound("outbox message not found")
+var errOutboxMessageNotDeadLettered = domainerror.FailedPrecondition("outbox message is not dead lettered")
+var errOutboxMessageNotWaiting = domainerror.FailedPrecondition("only waiting outbox messages can be retried")
+var errOutboxMessageNotFinished = domainerror.FailedPrecondition("only finished outbox messages can be republished")
+var errNewerOutboxMessages = domainerror.FailedPrecondition("newer messages of the key are in the outbox, the republished message would be sent after them")
+
+type OutboxAdminRepoMock struct {
+	Entries []messaging.OutboxEntry
+}
+
+func NewOutboxAdminRepoMock(entries ...messaging.OutboxEntry) *OutboxAdminRepoMock {
+	return &OutboxAdminRepoMock{Entries: entries}
+}
+
+func (o *OutboxAdminRepoMock) indexOf(id string) (int, error) {
+	return o.indexInState(id, deadLetteredState, errOutboxMessageNotDeadLettered)
+}
+
+// indexInState finds the message with id, wrongState is returned when it is not in state
+func (o *OutboxAdminRepoMock) indexInState(id string, state string, wrongState error) (int, error) {
+	for i, entry := range o.Entries {
+		if entry.ID != id {
+			continue
+		}
+		if state != "" && entry.State != state {
+			return 0, wrongState
+		}
+		return i, nil
+	}
+	return 0, errOutboxMessageNotFound
+}
+
+// ListDeadLetteredMessages returns everything in one page
+func (o *OutboxAdminRepoMock) ListDeadLetteredMessages(ctx context.Context, limit int64, cursor string) ([]messaging.OutboxEntry, string, error) {
+	var deadLettered []messaging.OutboxEntry
+	for _, entry := range o.Entries {
+		if entry.State == deadLetteredState {
+			deadLettered = append(deadLettered, entry)
+		}
+	}
+	return deadLettered, "", nil
+}
+
+func (o *OutboxAdminRepoMock) GetDeadLetteredMessage(ctx context.Context, id string) (messaging.OutboxEntry, error) {
+	i, err := o.indexOf(id)
+	if err != nil {
+		return messaging.OutboxEntry{}, err
+	}
+	return o.Entries[i], nil
+}
+
+func (o *OutboxAdminRepoMock) RequeueDeadLetteredMessage(ctx context.Context, id string) (messaging.OutboxEntry, error) {
+	i, err := o.indexOf(id)
+	if err != nil {
+		return messaging.OutboxEntry{}, err
+	}
+	o.Entries[i].State = waitingState
+	o.Entries[i].Retries = 0
+	return o.Entries[i], nil
+}
+
+func (o *OutboxAdminRepoMock) DiscardDeadLetteredMessage(ctx context.Context, id string) (messaging.OutboxEntry, error) {
+	i, err := o.indexOf(id)
+	if err != nil {
+		return messaging.OutboxEntry{}, err
+	}
+	discarded := o.Entries[i]
+	o.Entries = append(o.Entries[:i], o.Entries[i+1:]...)
+	return discarded, nil
+}
+
+func matchesOutboxFilter(entry messaging.OutboxEntry, filter messaging.OutboxFilter) bool {
+	if filter.State != "" && entry.State != filter.State {
+		return false
+	}
+	if filter.Topic != "" && entry.Topic != filter.Topic {
+		return false
+	}
+	if filter.Key != "" && entry.Key != filter.Key {
+		return false
+	}
+	if !filter.CreatedAfter.IsZero() && !entry.CreatedAt.After(filter.CreatedAfter) {
+		return false
+	}
+	if !filter.CreatedBefore.IsZero() && !entry.CreatedAt.Before(filter.CreatedBefore) {
+		return false
+	}
+	return true
+}
+
+// ListOutboxMessages returns everything in one page
+func (o *OutboxAdminRepoMock) ListOutboxMessages(ctx context.Context, filter messaging.OutboxFilter, limit int64, cursor string) ([]messaging.OutboxEntry, string, error) {
+	var matching []messaging.OutboxEntry
+	for _, entry := range o.Entries {
+		if matchesOutboxFilter(entry, filter) {
+			matching = append(matching, entry)
+		}
+	}
+	return matching, "", nil
+}
+
+func (o *OutboxAdminRepoMock) GetOutboxMessage(ctx context.Context, id string) (messaging.OutboxEntry, error) {
+	i, err := o.indexInState(id, "", nil)
+	if err != nil {
+		return messaging.OutboxEntry{}, err
+	}
+	return o.Entries[i], nil
+}
+
+func (o *OutboxAdminRepoMock) RetryOutboxMessage(ctx context.Context, id string) (messaging.OutboxEntry, error) {
+	i, err := o.indexInState(id, waitingState, errOutboxMessageNotWaiting)
+	if err != nil {
+		return messaging.OutboxEntry{}, err
+	}
+	o.Entries[i].NextRetry = time.Now().UTC()
+	return o.Entries[i], nil
+}
+
+func (o *OutboxAdminRepoMock) RepublishOutboxMessage(ctx context.Context, id string) (messaging.OutboxEntry, error) {
+	i, err := o.indexInState(id, finishedState, errOutboxMessageNotFinished)
+	if err != nil {
+		return messaging.OutboxEntry{}, err
+	}
+	original := o.Entries[i]
+	// entries are in insertion order
+	for _, entry := range o.Entries[i+1:] {
+		if original.Key != "" && entry.Key == original.Key {
+			return messaging.OutboxEntry{}, errNewerOutboxMessages
+		}
+	}
+
+	republished := messaging.KafkaInternalMessage{ID: original.ID, TopicID: original.Topic, Key: []byte(original.Key), Value: original.Value, Headers: original.Headers}.Republished()
+	o.Entries = append(o.Entries, messaging.OutboxEntry{
+		ID:        republished.ID,
+		Topic:     republished.TopicID,
+		Key:       original.Key,
+		Partition: original.Partition,
+		Value:     republished.Value,
+		Headers:   republished.Headers,
+		State:     waitingState,
+		CreatedAt: time.Now().UTC(),
+		NextRetry: time.Now().UTC(),
+	})
+	return o.Entries[len(o.Entries)-1], nil
+}
+
+func (o *OutboxAdminRepoMock) CountOutboxMessages(ctx context.Context) (map[string]int64, error) {
+	counts := map[string]int64{}
+	for _, entry := range o.Entries {
+		counts[entry.State]++
+	}
+	return counts, nil
+}
@@ -0,0 +1,174 @@
+package mock
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"sync"
+	"time"
+	"userservice/internal/infrastructure/messaging"
+)
+
+var errLeaseExpired = errors.New("lease expired before the delivery was confirmed")
+
+// defaultMaxAttempts matches the default of the mongodb outbox
+const defaultMaxAttempts = 10
+
+// MessageOutboxRepoMock keeps the outbox in memory, with the same state transitions as the mongodb outbox
+type MessageOutboxRepoMock struct {
+	lock    sync.Mutex
+	Entries []messaging.OutboxEntry
+	// MaxAttempts is how many failed attempts dead letter a message
+	MaxAttempts int64
+}
+
+func NewMessageOutboxRepoMock() *MessageOutboxRepoMock {
+	return &MessageOutboxRepoMock{MaxAttempts: defaultMaxAttempts}
+}
+
+// recordFailedAttempt must be called with lock held, the entry is due again at nextRetry unless it ran out of attempts.
+// It returns whether the entry is waiting again
+func (o *MessageOutboxRepoMock) recordFailedAttempt(entry *messaging.OutboxEntry, cause error, nextRetry time.Time) bool {
+	entry.Retries++
+	entry.LastError = cause.Error()
+	entry.LeaseOwner = ""
+	entry.LeaseExpiresAt = time.Time{}
+	if entry.Retries >= o.MaxAttempts {
+		entry.State = "dead_lettered"
+		entry.DeadLetteredAt = time.Now().UTC()
+		return false
+	}
+	entry.State = "waiting"
+	entry.NextRetry = nextRetry
+	return true
+}
+
+func (o *MessageOutboxRepoMock) PutMessageInOutbox(ctx context.Context, msg messaging.KafkaInternalMessage) error {
+	o.lock.Lock()
+	defer o.lock.Unlock()
+	o.Entries = append(o.Entries, messaging.OutboxEntry{
+		ID:        msg.ID,
+		Topic:     msg.TopicID,
+		Key:       string(msg.Key),
+		Partition: messaging.PartitionForKey(msg.Key, messaging.DefaultOutboxPartitions),
+		Value:     msg.Value,
+		State:     "waiting",
+		CreatedAt: time.Now().UTC(),
+		NextRetry: time.Now().UTC(),
+	})
+	return nil
+}
+
+// Entry returns a copy of the entry with the given id
+func (o *MessageOutboxRepoMock) Entry(id string) (messaging.OutboxEntry, bool) {
+	o.lock.Lock()
+	defer o.lock.Unlock()
+	for _, entry := range o.Entries {
+		if entry.ID == id {
+			return entry, true
+		}
+	}
+	return messaging.OutboxEntry{}, false
+}
+
+func (o *MessageOutboxRepoMock) ClaimPendingMessages(ctx context.Context, owner string, leaseDuration time.Duration, partitions []int, limit int) ([]messaging.KafkaInternalMessage, error) {
+	o.lock.Lock()
+	defer o.lock.Unlock()
+
+	// entries are in insertion order, so the first unfinished entry of a key is the head of its line, dead lettered ones
+	// included
+	blockedKeys := map[string]bool{}
+	var claimed []messaging.KafkaInternalMessage
+	for i := range o.Entries {
+		entry := &o.Entries[i]
+		if len(claimed) == limit {
+			break
+		}
+		if !slices.Contains(partitions, entry.Partition) || entry.State == "finished" {
+			continue
+		}
+		headOfLine := entry.Key == "" || !blockedKeys[entry.Key]
+		blockedKeys[entry.Key] = true
+		if !headOfLine || entry.State != "waiting" || entry.NextRetry.After(time.Now().UTC()) {
+			continue
+		}
+		entry.State = "processing"
+		entry.LeaseOwner = owner
+		entry.LeaseExpiresAt = time.Now().UTC().Add(leaseDuration)
+		claimed = append(claimed, messaging.KafkaInternalMessage{ID: entry.ID, TopicID: entry.Topic, Key: []byte(entry.Key), Value: entry.Value, Headers: entry.Headers})
+	}
+	return claimed, nil
+}
+
+func (o *MessageOutboxRepoMock) RetryMessage(ctx context.Context, owner string, id string, cause error) bool {
+	o.lock.Lock()
+	defer o.lock.Unlock()
+	for i := range o.Entries {
+		entry := &o.Entries[i]
+		if entry.ID != id || entry.State != "processing" || entry.LeaseOwner != owner {
+			continue
+		}
+		// without backoff, so tests can claim the message again right away
+		return o.recordFailedAttempt(entry, cause, entry.NextRetry)
+	}
+	return false
+}
+
+func (o *MessageOutboxRepoMock) MarkMessageSent(ctx context.Context, id string) {
+	o.lock.Lock()
+	defer o.lock.Unlock()
+	for i := range o.Entries {
+		entry := &o.Entries[i]
+		if entry.ID != id {
+			continue
+		}
+		entry.State = "finished"
+		entry.SentAt = time.Now().UTC()
+		entry.LeaseOwner = ""
+		entry.LeaseExpiresAt = time.Time{}
+	}
+}
+
+func (o *MessageOutboxRepoMock) ReleaseExpiredLeases(ctx context.Context) (int64, error) {
+	o.lock.Lock()
+	defer o.lock.Unlock()
+	released := int64(0)
+	for i := range o.Entries {
+		entry := &o.Entries[i]
+		if entry.State != "processing" || entry.LeaseExpiresAt.After(time.Now().UTC()) {
+			continue
+		}
+		o.recordFailedAttempt(entry, errLeaseExpired, time.Now().UTC())
+		released++
+	}
+	return released, nil
+}
+
+func (o *MessageOutboxRepoMock) FindFinishedMessages(ctx context.Context, partitions []int, sentBefore time.Time, limit int) ([]messaging.OutboxEntry, error) {
+	o.lock.Lock()
+	defer o.lock.Unlock()
+	var finished []messaging.OutboxEntry
+	for _, entry := range o.Entries {
+		if len(finished) == limit {
+			break
+		}
+		if entry.State == "finished" && slices.Contains(partitions, entry.Partition) && entry.SentAt.Before(sentBefore) {
+			finished = append(finished, entry)
+		}
+	}
+	return finished, nil
+}
+
+func (o *MessageOutboxRepoMock) DeleteFinishedMessages(ctx context.Context, ids []string) (int64, error) {
+	o.lock.Lock()
+	defer o.lock.Unlock()
+	kept := o.Entries[:0]
+	for _, entry := range o.Entries {
+		if entry.State != "finished" || !slices.Contains(ids, entry.ID) {
+			kept = append(kept, entry)
+		}
+	}
+	deleted := int64(len(o.Entries) - len(kept))
+	o.Entries = kept
+	return deleted, nil
+}
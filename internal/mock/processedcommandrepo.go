@@ -0,0 +1,49 @@
+package mock
+
+import (
+	"context"
+	"sync"
+)
+
+// ProcessedCommandRepoMock remembers processed command ids by topic
+type ProcessedCommandRepoMock struct {
+	lock      sync.Mutex
+	Processed map[string]string
+	// Err is returned by every call while it is set
+	Err error
+}
+
+func NewProcessedCommandRepoMock(commandIDs ...string) *ProcessedCommandRepoMock {
+	processed := map[string]string{}
+	for _, id := range commandIDs {
+		processed[id] = ""
+	}
+	return &ProcessedCommandRepoMock{Processed: processed}
+}
+
+func (p *ProcessedCommandRepoMock) IsCommandProcessed(ctx context.Context, commandID string) (bool, error) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	if p.Err != nil {
+		return false, p.Err
+	}
+	_, ok := p.Processed[commandID]
+	return ok, nil
+}
+
+func (p *ProcessedCommandRepoMock) MarkCommandProcessed(ctx context.Context, commandID string, topic string) error {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	if p.Err != nil {
+		return p.Err
+	}
+	p.Processed[commandID] = topic
+	return nil
+}
+
+// SetErr makes every call fail with err until it is called again with nil
+func (p *ProcessedCommandRepoMock) SetErr(err error) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	p.Err = err
+}
@@ -0,0 +1,118 @@
+package mock
+
+import (
+	"context"
+	"sync"
+	"time"
+	"userservice/internal/domain/domainerror"
+	"userservice/internal/domain/model"
+	"userservice/internal/domain/model/listusers"
+	"userservice/internal/domain/model/replayusers"
+)
+
+var errReplayNotFound = domainerror.NotFound("replay not found")
+var errUnableToEnqueue = domainerror.Internal("unable to enqueue")
+var errReplayCheckpointMoved = domainerror.FailedPrecondition("replay checkpoint was moved by another run of the replay")
+
+// ReplayRepoMock walks Users in order, the cursor is the id of the last enqueued user. Only equality filters are
+// supported
+type ReplayRepoMock struct {
+	lock        sync.Mutex
+	Users       []model.User
+	Checkpoints map[string]replayusers.Checkpoint
+	// Enqueued are the users snapshots were enqueued for, in order
+	Enqueued []model.User
+	// FailAfter makes enqueueing fail once that many users were enqueued, 0 never fails
+	FailAfter int
+}
+
+func NewReplayRepoMock(users ...model.User) *ReplayRepoMock {
+	return &ReplayRepoMock{Users: users, Checkpoints: map[string]replayusers.Checkpoint{}}
+}
+
+func (r *ReplayRepoMock) GetReplayCheckpoint(ctx context.Context, id string) (replayusers.Checkpoint, error) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	checkpoint, ok := r.Checkpoints[id]
+	if !ok {
+		return replayusers.Checkpoint{}, errReplayNotFound
+	}
+	return checkpoint, nil
+}
+
+func (r *ReplayRepoMock) CreateReplayCheckpoint(ctx context.Context, checkpoint replayusers.Checkpoint) (replayusers.Checkpoint, error) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	r.Checkpoints[checkpoint.ID] = checkpoint
+	return checkpoint, nil
+}
+
+func (r *ReplayRepoMock) ListUnfinishedReplayCheckpoints(ctx context.Context) ([]replayusers.Checkpoint, error) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	var checkpoints []replayusers.Checkpoint
+	for _, checkpoint := range r.Checkpoints {
+		if !checkpoint.Finished() {
+			checkpoints = append(checkpoints, checkpoint)
+		}
+	}
+	return checkpoints, nil
+}
+
+func matchesFilter(user model.User, filter *listusers.FilterInfo) bool {
+	if filter == nil || filter.Comparer != listusers.ComparerEqual {
+		return true
+	}
+	switch filter.Left {
+	case listusers.UserFieldFirstName:
+		return user.FirstName == filter.Right
+	case listusers.UserFieldSecondName:
+		return user.LastName == filter.Right
+	case listusers.UserFieldNickname:
+		return user.Nickname == filter.Right
+	case listusers.UserFieldEmail:
+		return user.Email == filter.Right
+	case listusers.UserFieldCountry:
+		return user.Country == filter.Right
+	}
+	return true
+}
+
+func (r *ReplayRepoMock) EnqueueReplayBatch(ctx context.Context, checkpoint replayusers.Checkpoint, limit int64) (replayusers.Checkpoint, error) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	if stored := r.Checkpoints[checkpoint.ID]; stored.Cursor != checkpoint.Cursor || stored.Finished() {
+		return checkpoint, errReplayCheckpointMoved
+	}
+
+	start := 0
+	if checkpoint.Cursor != "" {
+		for i, user := range r.Users {
+			if user.ID == checkpoint.Cursor {
+				start = i + 1
+			}
+		}
+	}
+	var batch []model.User
+	for _, user := range r.Users[start:] {
+		if int64(len(batch)) == limit {
+			break
+		}
+		if r.FailAfter > 0 && len(r.Enqueued)+len(batch) >= r.FailAfter {
+			return checkpoint, errUnableToEnqueue
+		}
+		if matchesFilter(user, checkpoint.Filtering) {
+			batch = append(batch, user)
+		}
+		checkpoint.Cursor = user.ID
+	}
+
+	r.Enqueued = append(r.Enqueued, batch...)
+	checkpoint.Enqueued += int64(len(batch))
+	checkpoint.UpdatedAt = time.Now().UTC()
+	if int64(len(batch)) < limit {
+		checkpoint.FinishedAt = checkpoint.UpdatedAt
+	}
+	r.Checkpoints[checkpoint.ID] = checkpoint
+	return checkpoint, nil
+}
@@ -2,7 +2,8 @@ package mock
 
 import (
 	"context"
-	"errors"
+	"time"
+	"userservice/internal/domain/domainerror"
 	"userservice/internal/domain/model"
 	"userservice/internal/domain/model/listusers"
 	"userservice/internal/domain/model/updateuser"
@@ -10,6 +11,8 @@ import (
 
 const userNotFoundIndex = -1
 
+var errUserNotFound = domainerror.NotFound("user not found")
+
 type UserRepoMock struct {
 	Users         []model.User
 	mockListUsers []model.User
@@ -29,7 +32,7 @@ func (u *UserRepoMock) UpdateUser(ctx context.Context, userID string, updateUser
 		storedUserIndex = i
 	}
 	if storedUserIndex == userNotFoundIndex {
-		return model.User{}, errors.New("user not found")
+		return model.User{}, errUserNotFound
 	}
 	return model.User{}, nil
 }
@@ -53,7 +56,21 @@ func (u *UserRepoMock) RemoveUser(ctx context.Context, id string) (model.User, e
 		u.Users = append(u.Users[:i], u.Users[i+1:]...)
 		return toRemove, nil
 	}
-	return model.User{}, errors.New("user not found")
+	return model.User{}, errUserNotFound
+}
+
+func (u *UserRepoMock) SuspendUser(ctx context.Context, userID string, reason string) (model.User, error) {
+	for i := range u.Users {
+		if u.Users[i].ID != userID {
+			continue
+		}
+		if !u.Users[i].Suspended {
+			u.Users[i].Suspended = true
+			u.Users[i].SuspendedAt = time.Now().UTC()
+		}
+		return u.Users[i], nil
+	}
+	return model.User{}, errUserNotFound
 }
 
 func (u *UserRepoMock) GetUser(ctx context.Context, id string) (model.User, error) {
@@ -64,7 +81,22 @@ func (u *UserRepoMock) GetUser(ctx context.Context, id string) (model.User, erro
 		}
 		return storedUser, nil
 	}
-	return model.User{}, errors.New("user not found")
+	return model.User{}, errUserNotFound
+}
+
+func (u *UserRepoMock) GetUsers(ctx context.Context, ids []string) ([]model.User, error) {
+
+	var foundUsers []model.User
+	for _, storedUser := range u.Users {
+		for _, id := range ids {
+			if storedUser.ID != id {
+				continue
+			}
+			foundUsers = append(foundUsers, storedUser)
+			break
+		}
+	}
+	return foundUsers, nil
 }
 
 func (u *UserRepoMock) ListUsers(ctx context.Context, listRequest listusers.Request) (listusers.Response, error) {
@@ -0,0 +1,184 @@
+package mock
+
+import (
+	"context"
+	"errors"
+	"github.com/google/uuid"
+	"sync"
+	"time"
+	"userservice/internal/domain/domainerror"
+	"userservice/internal/domain/model"
+)
+
+var errWebhookSubscriptionNotFound = domainerror.NotFound("webhook subscription not found")
+var errWebhookDeliveryNotProcessing = errors.New("webhook delivery is not processing")
+var errWebhookLeaseExpired = errors.New("lease expired before the attempt was recorded")
+
+// WebhookRepoMock keeps subscriptions and deliveries in memory, failed deliveries are due again right away
+type WebhookRepoMock struct {
+	lock          sync.Mutex
+	Subscriptions []model.WebhookSubscription
+	Deliveries    []model.WebhookDelivery
+	// MaxAttempts fails a delivery after that many attempts, 0 retries forever
+	MaxAttempts int
+}
+
+func NewWebhookRepoMock() *WebhookRepoMock {
+	return &WebhookRepoMock{}
+}
+
+func (w *WebhookRepoMock) AddWebhookSubscription(ctx context.Context, subscription model.WebhookSubscription) (model.WebhookSubscription, error) {
+	w.lock.Lock()
+	defer w.lock.Unlock()
+	w.Subscriptions = append(w.Subscriptions, subscription)
+	return subscription, nil
+}
+
+func (w *WebhookRepoMock) ListWebhookSubscriptions(ctx context.Context) ([]model.WebhookSubscription, error) {
+	w.lock.Lock()
+	defer w.lock.Unlock()
+	return append([]model.WebhookSubscription{}, w.Subscriptions...), nil
+}
+
+func (w *WebhookRepoMock) GetWebhookSubscription(ctx context.Context, id string) (model.WebhookSubscription, error) {
+	w.lock.Lock()
+	defer w.lock.Unlock()
+	for _, subscription := range w.Subscriptions {
+		if subscription.ID == id {
+			return subscription, nil
+		}
+	}
+	return model.WebhookSubscription{}, errWebhookSubscriptionNotFound
+}
+
+func (w *WebhookRepoMock) RemoveWebhookSubscription(ctx context.Context, id string) (model.WebhookSubscription, error) {
+	w.lock.Lock()
+	defer w.lock.Unlock()
+	for i, subscription := range w.Subscriptions {
+		if subscription.ID == id {
+			w.Subscriptions = append(w.Subscriptions[:i], w.Subscriptions[i+1:]...)
+			return subscription, nil
+		}
+	}
+	return model.WebhookSubscription{}, errWebhookSubscriptionNotFound
+}
+
+// ListWebhookDeliveries returns everything in one page
+func (w *WebhookRepoMock) ListWebhookDeliveries(ctx context.Context, subscriptionID string, limit int64, cursor string) ([]model.WebhookDelivery, string, error) {
+	w.lock.Lock()
+	defer w.lock.Unlock()
+	var deliveries []model.WebhookDelivery
+	for _, delivery := range w.Deliveries {
+		if delivery.SubscriptionID == subscriptionID {
+			deliveries = append(deliveries, delivery)
+		}
+	}
+	return deliveries, "", nil
+}
+
+// AddDelivery schedules a delivery that is due right away
+func (w *WebhookRepoMock) AddDelivery(delivery model.WebhookDelivery) {
+	w.lock.Lock()
+	defer w.lock.Unlock()
+	delivery.State = "waiting"
+	delivery.NextRetry = time.Now().UTC()
+	w.Deliveries = append(w.Deliveries, delivery)
+}
+
+// Delivery returns a copy of the delivery with the given id
+func (w *WebhookRepoMock) Delivery(id string) model.WebhookDelivery {
+	w.lock.Lock()
+	defer w.lock.Unlock()
+	for _, delivery := range w.Deliveries {
+		if delivery.ID == id {
+			delivery.Attempts = append([]model.WebhookAttempt{}, delivery.Attempts...)
+			return delivery
+		}
+	}
+	return model.WebhookDelivery{}
+}
+
+// outOfAttempts must be called with lock held
+func (w *WebhookRepoMock) outOfAttempts(delivery *model.WebhookDelivery) bool {
+	return w.MaxAttempts > 0 && len(delivery.Attempts) >= w.MaxAttempts
+}
+
+// ClaimDueWebhookDeliveries claims waiting deliveries and processing ones whose lease expired, which counts as an attempt
+func (w *WebhookRepoMock) ClaimDueWebhookDeliveries(ctx context.Context, leaseDuration time.Duration, limit int) ([]model.WebhookDelivery, error) {
+	w.lock.Lock()
+	defer w.lock.Unlock()
+	var claimed []model.WebhookDelivery
+	now := time.Now().UTC()
+	for i := range w.Deliveries {
+		delivery := &w.Deliveries[i]
+		if len(claimed) == limit {
+			break
+		}
+		due := delivery.State == "waiting" && !delivery.NextRetry.After(now)
+		expired := delivery.State == "processing" && !delivery.LeaseExpiresAt.After(now)
+		if !due && !expired {
+			continue
+		}
+		if expired {
+			delivery.Attempts = append(delivery.Attempts, model.WebhookAttempt{AttemptedAt: now, Error: errWebhookLeaseExpired.Error()})
+			delivery.LastError = errWebhookLeaseExpired.Error()
+			if w.outOfAttempts(delivery) {
+				delivery.State = "failed"
+				delivery.LeaseToken = ""
+				delivery.LeaseExpiresAt = time.Time{}
+				continue
+			}
+		}
+		delivery.State = "processing"
+		delivery.LeaseToken = uuid.NewString()
+		delivery.LeaseExpiresAt = now.Add(leaseDuration)
+		claimed = append(claimed, *delivery)
+	}
+	return claimed, nil
+}
+
+// finish only records the outcome while the claim of leaseToken holds
+func (w *WebhookRepoMock) finish(deliveryID string, leaseToken string, attempt *model.WebhookAttempt, update func(delivery *model.WebhookDelivery)) error {
+	w.lock.Lock()
+	defer w.lock.Unlock()
+	for i := range w.Deliveries {
+		delivery := &w.Deliveries[i]
+		if delivery.ID != deliveryID || delivery.State != "processing" || delivery.LeaseToken != leaseToken {
+			continue
+		}
+		if attempt != nil {
+			delivery.Attempts = append(delivery.Attempts, *attempt)
+		}
+		delivery.LeaseToken = ""
+		delivery.LeaseExpiresAt = time.Time{}
+		update(delivery)
+		return nil
+	}
+	return errWebhookDeliveryNotProcessing
+}
+
+func (w *WebhookRepoMock) MarkWebhookDelivered(ctx context.Context, deliveryID string, leaseToken string, attempt model.WebhookAttempt) error {
+	return w.finish(deliveryID, leaseToken, &attempt, func(delivery *model.WebhookDelivery) {
+		delivery.State = "delivered"
+		delivery.DeliveredAt = time.Now().UTC()
+	})
+}
+
+func (w *WebhookRepoMock) RetryWebhookDelivery(ctx context.Context, deliveryID string, leaseToken string, attempt model.WebhookAttempt) error {
+	return w.finish(deliveryID, leaseToken, &attempt, func(delivery *model.WebhookDelivery) {
+		delivery.LastError = attempt.Error
+		if w.outOfAttempts(delivery) {
+			delivery.State = "failed"
+			return
+		}
+		delivery.State = "waiting"
+		delivery.NextRetry = time.Now().UTC()
+	})
+}
+
+func (w *WebhookRepoMock) CancelWebhookDelivery(ctx context.Context, deliveryID string, leaseToken string, reason string) error {
+	return w.finish(deliveryID, leaseToken, nil, func(delivery *model.WebhookDelivery) {
+		delivery.State = "cancelled"
+		delivery.LastError = reason
+	})
+}
@@ -2,6 +2,7 @@ package converter
 
 import (
 	"google.golang.org/protobuf/types/known/timestamppb"
+	"strconv"
 	"userservice/internal/domain/model"
 	"userservice/internal/domain/model/listusers"
 	"userservice/proto/grpc"
@@ -18,6 +19,7 @@ func FromDomainUserToResponseUser(user model.User) *grpc.ResponseUser {
 		Country:   user.Country,
 		CreatedAt: timestamppb.New(user.CreatedAt),
 		UpdatedAt: timestamppb.New(user.UpdatedAt),
+		Suspended: user.Suspended,
 	}
 }
 
@@ -48,3 +50,36 @@ func FromRepoUserToKafkaAddedUserMessage(user model.User) *kafkaschema.UserAdded
 		Country:   user.Country,
 	}
 }
+
+// FromUserUpdateToKafkaUpdatedUserMessage lists the fields that differ between before and after, the password is only
+// reported as changed since neither the hash nor the plain text should leave the service
+func FromUserUpdateToKafkaUpdatedUserMessage(before model.User, after model.User) *kafkaschema.UserUpdatedMessage {
+	message := &kafkaschema.UserUpdatedMessage{
+		Id:              after.ID,
+		PasswordChanged: before.Password != after.Password,
+	}
+
+	fields := []struct {
+		name     string
+		oldValue string
+		newValue string
+	}{
+		{"first_name", before.FirstName, after.FirstName},
+		{"last_name", before.LastName, after.LastName},
+		{"nickname", before.Nickname, after.Nickname},
+		{"email", before.Email, after.Email},
+		{"country", before.Country, after.Country},
+		{"suspended", strconv.FormatBool(before.Suspended), strconv.FormatBool(after.Suspended)},
+	}
+	for _, field := range fields {
+		if field.oldValue == field.newValue {
+			continue
+		}
+		message.Changes = append(message.Changes, &kafkaschema.UserFieldChange{
+			Field:    field.name,
+			OldValue: field.oldValue,
+			NewValue: field.newValue,
+		})
+	}
+	return message
+}
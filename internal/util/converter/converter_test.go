@@ -0,0 +1,40 @@
+package converter
+
+import (
+	"github.com/stretchr/testify/require"
+	"testing"
+	"userservice/internal/domain/model"
+)
+
+func TestFromUserUpdateToKafkaUpdatedUserMessage(t *testing.T) {
+	before := model.User{
+		ID:        "id",
+		FirstName: "John",
+		LastName:  "Smith",
+		Nickname:  "js",
+		Password:  "oldHash",
+		Email:     "john@example.com",
+		Country:   "GB",
+	}
+	after := before
+	after.Email = "smith@example.com"
+	after.Country = "DK"
+	after.Password = "newHash"
+
+	message := FromUserUpdateToKafkaUpdatedUserMessage(before, after)
+	require.Equal(t, "id", message.Id)
+	require.True(t, message.PasswordChanged)
+	require.Len(t, message.Changes, 2)
+	require.Equal(t, "email", message.Changes[0].Field)
+	require.Equal(t, "john@example.com", message.Changes[0].OldValue)
+	require.Equal(t, "smith@example.com", message.Changes[0].NewValue)
+	require.Equal(t, "country", message.Changes[1].Field)
+	for _, change := range message.Changes {
+		require.NotContains(t, change.OldValue, "Hash")
+		require.NotContains(t, change.NewValue, "Hash")
+	}
+
+	unchanged := FromUserUpdateToKafkaUpdatedUserMessage(before, before)
+	require.Empty(t, unchanged.Changes)
+	require.False(t, unchanged.PasswordChanged)
+}
@@ -0,0 +1,26 @@
+package converter
+
+import (
+	"google.golang.org/grpc/codes"
+	"userservice/internal/domain/model/batchgetusers"
+	"userservice/proto/grpc"
+)
+
+func FromBatchGetUsersToResponse(results []batchgetusers.Result) *grpc.BatchGetUsersResponse {
+
+	grpcResults := make([]*grpc.BatchGetUsersResult, 0, len(results))
+	for _, result := range results {
+		grpcResult := &grpc.BatchGetUsersResult{UserID: result.ID}
+		if result.User != nil {
+			grpcResult.Result = &grpc.BatchGetUsersResult_User{User: FromDomainUserToResponseUser(*result.User)}
+		} else {
+			grpcResult.Result = &grpc.BatchGetUsersResult_Error{Error: &grpc.ResultError{
+				Code:    int32(codes.NotFound),
+				Message: "user not found",
+			}}
+		}
+		grpcResults = append(grpcResults, grpcResult)
+	}
+
+	return &grpc.BatchGetUsersResponse{Results: grpcResults}
+}
@@ -0,0 +1,99 @@
+package converter
+
+import (
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"time"
+	"userservice/internal/infrastructure/messaging"
+	"userservice/internal/infrastructure/outbox"
+	"userservice/proto/grpc"
+)
+
+// toOptionalTimestamp leaves out times that were never set, instead of sending year 1
+func toOptionalTimestamp(t time.Time) *timestamppb.Timestamp {
+	if t.IsZero() {
+		return nil
+	}
+	return timestamppb.New(t)
+}
+
+func FromOutboxEntryToOutboxMessage(entry messaging.OutboxEntry) *grpc.OutboxMessage {
+	return &grpc.OutboxMessage{
+		Id:             entry.ID,
+		Topic:          entry.Topic,
+		Key:            entry.Key,
+		State:          entry.State,
+		Retries:        entry.Retries,
+		LastError:      entry.LastError,
+		CreatedAt:      toOptionalTimestamp(entry.CreatedAt),
+		NextRetry:      toOptionalTimestamp(entry.NextRetry),
+		DeadLetteredAt: toOptionalTimestamp(entry.DeadLetteredAt),
+		Value:          entry.Value,
+		Headers:        fromHeaders(entry.Headers),
+	}
+}
+
+func fromHeaders(headers []messaging.Header) map[string]string {
+	if len(headers) == 0 {
+		return nil
+	}
+	converted := make(map[string]string, len(headers))
+	for _, header := range headers {
+		converted[header.Key] = header.Value
+	}
+	return converted
+}
+
+func FromOutboxEntriesToListDeadLetteredResponse(entries []messaging.OutboxEntry, limit int64, nextCursor string) *grpc.ListDeadLetteredMessagesResponse {
+	messages := make([]*grpc.OutboxMessage, 0, len(entries))
+	for _, entry := range entries {
+		messages = append(messages, FromOutboxEntryToOutboxMessage(entry))
+	}
+	return &grpc.ListDeadLetteredMessagesResponse{
+		Next:     &grpc.PageInfo{Limit: limit, Cursor: nextCursor},
+		Messages: messages,
+	}
+}
+
+func FromOutboxEntriesToListOutboxMessagesResponse(entries []messaging.OutboxEntry, limit int64, nextCursor string) *grpc.ListOutboxMessagesResponse {
+	messages := make([]*grpc.OutboxMessage, 0, len(entries))
+	for _, entry := range entries {
+		messages = append(messages, FromOutboxEntryToOutboxMessage(entry))
+	}
+	return &grpc.ListOutboxMessagesResponse{
+		Next:     &grpc.PageInfo{Limit: limit, Cursor: nextCursor},
+		Messages: messages,
+	}
+}
+
+// ToOutboxFilter turns the ages of the request into creation times, counted back from now
+func ToOutboxFilter(request *grpc.ListOutboxMessagesRequest, now time.Time) messaging.OutboxFilter {
+	filter := messaging.OutboxFilter{
+		State: request.State,
+		Topic: request.Topic,
+		Key:   request.Key,
+	}
+	if request.MinAgeSeconds > 0 {
+		filter.CreatedBefore = now.Add(-time.Duration(request.MinAgeSeconds) * time.Second)
+	}
+	if request.MaxAgeSeconds > 0 {
+		filter.CreatedAfter = now.Add(-time.Duration(request.MaxAgeSeconds) * time.Second)
+	}
+	return filter
+}
+
+func FromOutboxStatusToResponse(status outbox.Status, messagesByState map[string]int64) *grpc.GetOutboxStatusResponse {
+	ownedPartitions := make([]int32, 0, len(status.OwnedPartitions))
+	for _, partition := range status.OwnedPartitions {
+		ownedPartitions = append(ownedPartitions, int32(partition))
+	}
+	return &grpc.GetOutboxStatusResponse{Status: &grpc.OutboxStatus{
+		InstanceId:      status.InstanceID,
+		OwnedPartitions: ownedPartitions,
+		Purged:          status.Purged,
+		Archived:        status.Archived,
+		LastPurgeAt:     toOptionalTimestamp(status.LastPurgeAt),
+		LastArchive:     status.LastArchive,
+		LastPurgeError:  status.LastPurgeError,
+		MessagesByState: messagesByState,
+	}}
+}
@@ -0,0 +1,39 @@
+package converter
+
+import (
+	"userservice/internal/domain/model/listusers"
+	"userservice/internal/domain/model/replayusers"
+	"userservice/proto/grpc"
+)
+
+func ToReplayUsersRequest(request *grpc.StartReplayRequest) replayusers.Request {
+	return replayusers.Request{
+		ID:             request.ReplayID,
+		Filtering:      ConvertFilterInfo(request.Filtering),
+		BatchSize:      request.BatchSize,
+		UsersPerSecond: request.UsersPerSecond,
+	}
+}
+
+func fromFilterInfo(filterInfo *listusers.FilterInfo) *grpc.FilterInfo {
+	if filterInfo == nil {
+		return nil
+	}
+	return &grpc.FilterInfo{
+		Left:     grpc.UserField(filterInfo.Left),
+		Comparer: grpc.Comparer(filterInfo.Comparer),
+		Right:    filterInfo.Right,
+	}
+}
+
+func FromReplayCheckpointToReplay(checkpoint replayusers.Checkpoint) *grpc.Replay {
+	return &grpc.Replay{
+		Id:         checkpoint.ID,
+		Filtering:  fromFilterInfo(checkpoint.Filtering),
+		Cursor:     checkpoint.Cursor,
+		Enqueued:   checkpoint.Enqueued,
+		StartedAt:  toOptionalTimestamp(checkpoint.StartedAt),
+		UpdatedAt:  toOptionalTimestamp(checkpoint.UpdatedAt),
+		FinishedAt: toOptionalTimestamp(checkpoint.FinishedAt),
+	}
+}
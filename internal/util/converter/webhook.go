@@ -0,0 +1,68 @@
+package converter
+
+import (
+	"userservice/internal/domain/model"
+	"userservice/proto/grpc"
+)
+
+func FromCreateWebhookSubscriptionRequestToModel(request *grpc.CreateWebhookSubscriptionRequest) model.WebhookSubscription {
+	return model.WebhookSubscription{
+		URL:        request.Url,
+		EventTypes: request.EventTypes,
+		Secret:     request.Secret,
+	}
+}
+
+// FromWebhookSubscriptionToResponse leaves out the secret, it is only handed out once on creation
+func FromWebhookSubscriptionToResponse(subscription model.WebhookSubscription) *grpc.WebhookSubscription {
+	return &grpc.WebhookSubscription{
+		Id:         subscription.ID,
+		Url:        subscription.URL,
+		EventTypes: subscription.EventTypes,
+		CreatedAt:  toOptionalTimestamp(subscription.CreatedAt),
+	}
+}
+
+func FromWebhookSubscriptionsToListResponse(subscriptions []model.WebhookSubscription) *grpc.ListWebhookSubscriptionsResponse {
+	grpcSubscriptions := make([]*grpc.WebhookSubscription, 0, len(subscriptions))
+	for _, subscription := range subscriptions {
+		grpcSubscriptions = append(grpcSubscriptions, FromWebhookSubscriptionToResponse(subscription))
+	}
+	return &grpc.ListWebhookSubscriptionsResponse{Subscriptions: grpcSubscriptions}
+}
+
+func FromWebhookDeliveryToResponse(delivery model.WebhookDelivery) *grpc.WebhookDelivery {
+	attempts := make([]*grpc.WebhookAttempt, 0, len(delivery.Attempts))
+	for _, attempt := range delivery.Attempts {
+		attempts = append(attempts, &grpc.WebhookAttempt{
+			AttemptedAt: toOptionalTimestamp(attempt.AttemptedAt),
+			StatusCode:  int32(attempt.StatusCode),
+			Error:       attempt.Error,
+			DurationMs:  attempt.Duration.Milliseconds(),
+		})
+	}
+	return &grpc.WebhookDelivery{
+		Id:             delivery.ID,
+		SubscriptionId: delivery.SubscriptionID,
+		EventId:        delivery.EventID,
+		EventType:      delivery.EventType,
+		State:          delivery.State,
+		CreatedAt:      toOptionalTimestamp(delivery.CreatedAt),
+		NextRetry:      toOptionalTimestamp(delivery.NextRetry),
+		DeliveredAt:    toOptionalTimestamp(delivery.DeliveredAt),
+		LastError:      delivery.LastError,
+		Attempts:       attempts,
+		Payload:        delivery.Payload,
+	}
+}
+
+func FromWebhookDeliveriesToListResponse(deliveries []model.WebhookDelivery, limit int64, nextCursor string) *grpc.ListWebhookDeliveriesResponse {
+	grpcDeliveries := make([]*grpc.WebhookDelivery, 0, len(deliveries))
+	for _, delivery := range deliveries {
+		grpcDeliveries = append(grpcDeliveries, FromWebhookDeliveryToResponse(delivery))
+	}
+	return &grpc.ListWebhookDeliveriesResponse{
+		Next:       &grpc.PageInfo{Limit: limit, Cursor: nextCursor},
+		Deliveries: grpcDeliveries,
+	}
+}
@@ -0,0 +1,33 @@
+package crypto
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+)
+
+// GenerateWebhookSecret returns a random secret for signing webhook payloads
+func GenerateWebhookSecret() string {
+	randomBytes := make([]byte, 32)
+	_, err := rand.Read(randomBytes)
+	if err != nil {
+		panic(err)
+	}
+	return hex.EncodeToString(randomBytes)
+}
+
+// SignWebhookPayload signs the timestamp together with the body, so a captured request can not be replayed later with
+// a new timestamp
+func SignWebhookPayload(secret string, timestamp int64, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	_, _ = fmt.Fprintf(mac, "%d.", timestamp)
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// VerifyWebhookSignature is what receivers do to check a payload came from us
+func VerifyWebhookSignature(secret string, timestamp int64, body []byte, signature string) bool {
+	return hmac.Equal([]byte(SignWebhookPayload(secret, timestamp, body)), []byte(signature))
+}
@@ -0,0 +1,118 @@
+package tracing
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"strings"
+)
+
+// header names, the same in grpc metadata, http and kafka
+const (
+	CorrelationIDHeader = "x-correlation-id"
+	TraceParentHeader   = "traceparent"
+)
+
+const (
+	traceParentVersion = "00"
+	// sampledFlags asks the services after us to record their part of the trace, we start the trace so the decision
+	// is ours
+	sampledFlags = "01"
+	traceIDBytes = 16
+	spanIDBytes  = 8
+)
+
+type metadataKey struct{}
+
+// Metadata follows a request through the service into the events it causes
+type Metadata struct {
+	CorrelationID string
+	// TraceParent is the W3C trace context of the span handling the request, https://www.w3.org/TR/trace-context/
+	TraceParent string
+}
+
+func NewContext(ctx context.Context, metadata Metadata) context.Context {
+	return context.WithValue(ctx, metadataKey{}, metadata)
+}
+
+func FromContext(ctx context.Context) (Metadata, bool) {
+	metadata, ok := ctx.Value(metadataKey{}).(Metadata)
+	return metadata, ok
+}
+
+// ForRequest continues the trace of the caller with a new span, or starts a new trace when the caller sent none or an
+// invalid one. The correlation id of the caller is kept, a new one is made up when there is none
+func ForRequest(correlationID string, traceParent string) Metadata {
+	if correlationID == "" {
+		correlationID = randomHex(traceIDBytes)
+	}
+	child, ok := ChildTraceParent(traceParent)
+	if !ok {
+		child = NewTraceParent()
+	}
+	return Metadata{CorrelationID: correlationID, TraceParent: child}
+}
+
+// FromContextOrNew is for work that may or may not be caused by a request, like events. Without a request there is no
+// correlation id, and the work starts a trace of its own
+func FromContextOrNew(ctx context.Context) Metadata {
+	metadata, _ := FromContext(ctx)
+	if metadata.TraceParent == "" {
+		metadata.TraceParent = NewTraceParent()
+	}
+	return metadata
+}
+
+func NewTraceParent() string {
+	return formatTraceParent(randomHex(traceIDBytes), randomHex(spanIDBytes), sampledFlags)
+}
+
+// ChildTraceParent keeps the trace id and flags of parent and replaces the span id with a new one
+func ChildTraceParent(parent string) (string, bool) {
+	traceID, flags, ok := parseTraceParent(parent)
+	if !ok {
+		return "", false
+	}
+	return formatTraceParent(traceID, randomHex(spanIDBytes), flags), true
+}
+
+// ValidTraceParent checks the format of a version 00 traceparent
+func ValidTraceParent(traceParent string) bool {
+	_, _, ok := parseTraceParent(traceParent)
+	return ok
+}
+
+func parseTraceParent(traceParent string) (traceID string, flags string, ok bool) {
+	parts := strings.Split(traceParent, "-")
+	if len(parts) != 4 || parts[0] != traceParentVersion {
+		return "", "", false
+	}
+	if !isLowerHex(parts[1], traceIDBytes) || !isLowerHex(parts[2], spanIDBytes) || !isLowerHex(parts[3], 1) {
+		return "", "", false
+	}
+	// all zero ids are invalid
+	if strings.Trim(parts[1], "0") == "" || strings.Trim(parts[2], "0") == "" {
+		return "", "", false
+	}
+	return parts[1], parts[3], true
+}
+
+func isLowerHex(value string, bytes int) bool {
+	if len(value) != 2*bytes || strings.ToLower(value) != value {
+		return false
+	}
+	_, err := hex.DecodeString(value)
+	return err == nil
+}
+
+func formatTraceParent(traceID string, spanID string, flags string) string {
+	return fmt.Sprintf("%s-%s-%s-%s", traceParentVersion, traceID, spanID, flags)
+}
+
+func randomHex(bytes int) string {
+	buffer := make([]byte, bytes)
+	// crypto/rand does not fail on the platforms we run on
+	_, _ = rand.Read(buffer)
+	return hex.EncodeToString(buffer)
+}
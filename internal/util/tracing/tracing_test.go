@@ -0,0 +1,59 @@
+package tracing
+
+import (
+	"context"
+	"github.com/stretchr/testify/require"
+	"strings"
+	"testing"
+)
+
+const callerTraceParent = "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01"
+
+func TestChildTraceParent(t *testing.T) {
+	child, ok := ChildTraceParent(callerTraceParent)
+	require.True(t, ok)
+	require.True(t, ValidTraceParent(child))
+	require.True(t, strings.HasPrefix(child, "00-4bf92f3577b34da6a3ce929d0e0e4736-"))
+	require.True(t, strings.HasSuffix(child, "-01"))
+	require.NotEqual(t, callerTraceParent, child)
+}
+
+func TestValidTraceParent(t *testing.T) {
+	require.True(t, ValidTraceParent(callerTraceParent))
+	require.True(t, ValidTraceParent(NewTraceParent()))
+
+	invalid := []string{
+		"",
+		"01-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01",
+		"00-4BF92F3577B34DA6A3CE929D0E0E4736-00f067aa0ba902b7-01",
+		"00-00000000000000000000000000000000-00f067aa0ba902b7-01",
+		"00-4bf92f3577b34da6a3ce929d0e0e4736-0000000000000000-01",
+		"00-4bf92f3577b34da6a3ce929d0e0e47-00f067aa0ba902b7-01",
+		"00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7",
+		"00-4bf92f3577b34da6a3ce929d0e0e473g-00f067aa0ba902b7-01",
+	}
+	for _, traceParent := range invalid {
+		require.False(t, ValidTraceParent(traceParent), traceParent)
+	}
+}
+
+func TestForRequest(t *testing.T) {
+	metadata := ForRequest("correlation", callerTraceParent)
+	require.Equal(t, "correlation", metadata.CorrelationID)
+	require.True(t, strings.HasPrefix(metadata.TraceParent, "00-4bf92f3577b34da6a3ce929d0e0e4736-"))
+
+	// callers without a trace or correlation id get new ones
+	fresh := ForRequest("", "garbage")
+	require.Len(t, fresh.CorrelationID, 32)
+	require.True(t, ValidTraceParent(fresh.TraceParent))
+	require.False(t, strings.Contains(fresh.TraceParent, "4bf92f3577b34da6a3ce929d0e0e4736"))
+}
+
+func TestFromContextOrNew(t *testing.T) {
+	ctx := NewContext(context.Background(), Metadata{CorrelationID: "correlation", TraceParent: callerTraceParent})
+	require.Equal(t, Metadata{CorrelationID: "correlation", TraceParent: callerTraceParent}, FromContextOrNew(ctx))
+
+	background := FromContextOrNew(context.Background())
+	require.Empty(t, background.CorrelationID)
+	require.True(t, ValidTraceParent(background.TraceParent))
+}
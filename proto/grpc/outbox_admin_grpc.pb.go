@@ -0,0 +1,485 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.2.0
+// - protoc             v5.27.2
+// source: proto/grpc/outbox_admin.proto
+
+package grpc
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.32.0 or later.
+const _ = grpc.SupportPackageIsVersion7
+
+// OutboxAdminServiceClient is the client API for OutboxAdminService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type OutboxAdminServiceClient interface {
+	ListDeadLetteredMessages(ctx context.Context, in *ListDeadLetteredMessagesRequest, opts ...grpc.CallOption) (*ListDeadLetteredMessagesResponse, error)
+	GetDeadLetteredMessage(ctx context.Context, in *GetDeadLetteredMessageRequest, opts ...grpc.CallOption) (*GetDeadLetteredMessageResponse, error)
+	RequeueDeadLetteredMessage(ctx context.Context, in *RequeueDeadLetteredMessageRequest, opts ...grpc.CallOption) (*RequeueDeadLetteredMessageResponse, error)
+	DiscardDeadLetteredMessage(ctx context.Context, in *DiscardDeadLetteredMessageRequest, opts ...grpc.CallOption) (*DiscardDeadLetteredMessageResponse, error)
+	GetOutboxStatus(ctx context.Context, in *GetOutboxStatusRequest, opts ...grpc.CallOption) (*GetOutboxStatusResponse, error)
+	// ListOutboxMessages lists the messages in any state, oldest first
+	ListOutboxMessages(ctx context.Context, in *ListOutboxMessagesRequest, opts ...grpc.CallOption) (*ListOutboxMessagesResponse, error)
+	// GetOutboxMessage returns a message together with its decoded event
+	GetOutboxMessage(ctx context.Context, in *GetOutboxMessageRequest, opts ...grpc.CallOption) (*GetOutboxMessageResponse, error)
+	// RetryOutboxMessage makes a waiting message due right away instead of waiting for its backoff
+	RetryOutboxMessage(ctx context.Context, in *RetryOutboxMessageRequest, opts ...grpc.CallOption) (*RetryOutboxMessageResponse, error)
+	// RepublishOutboxMessage sends a copy of a finished message, with an id of its own so consumers that dedupe on the
+	// message-id header do not drop it, and the original id in the republished-from header. It fails with
+	// FAILED_PRECONDITION while newer messages of the same key are in the outbox, as consumers would get the old event
+	// after the newer ones
+	RepublishOutboxMessage(ctx context.Context, in *RepublishOutboxMessageRequest, opts ...grpc.CallOption) (*RepublishOutboxMessageResponse, error)
+	// StartReplay enqueues a snapshot of every user, or of the filtered ones, in the background. Starting a replay with
+	// the id of an unfinished one resumes it, unless this instance is running it already. Unfinished replays are
+	// resumed when the service restarts
+	StartReplay(ctx context.Context, in *StartReplayRequest, opts ...grpc.CallOption) (*StartReplayResponse, error)
+	GetReplay(ctx context.Context, in *GetReplayRequest, opts ...grpc.CallOption) (*GetReplayResponse, error)
+}
+
+type outboxAdminServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewOutboxAdminServiceClient(cc grpc.ClientConnInterface) OutboxAdminServiceClient {
+	return &outboxAdminServiceClient{cc}
+}
+
+func (c *outboxAdminServiceClient) ListDeadLetteredMessages(ctx context.Context, in *ListDeadLetteredMessagesRequest, opts ...grpc.CallOption) (*ListDeadLetteredMessagesResponse, error) {
+	out := new(ListDeadLetteredMessagesResponse)
+	err := c.cc.Invoke(ctx, "/OutboxAdminService/ListDeadLetteredMessages", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *outboxAdminServiceClient) GetDeadLetteredMessage(ctx context.Context, in *GetDeadLetteredMessageRequest, opts ...grpc.CallOption) (*GetDeadLetteredMessageResponse, error) {
+	out := new(GetDeadLetteredMessageResponse)
+	err := c.cc.Invoke(ctx, "/OutboxAdminService/GetDeadLetteredMessage", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *outboxAdminServiceClient) RequeueDeadLetteredMessage(ctx context.Context, in *RequeueDeadLetteredMessageRequest, opts ...grpc.CallOption) (*RequeueDeadLetteredMessageResponse, error) {
+	out := new(RequeueDeadLetteredMessageResponse)
+	err := c.cc.Invoke(ctx, "/OutboxAdminService/RequeueDeadLetteredMessage", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *outboxAdminServiceClient) DiscardDeadLetteredMessage(ctx context.Context, in *DiscardDeadLetteredMessageRequest, opts ...grpc.CallOption) (*DiscardDeadLetteredMessageResponse, error) {
+	out := new(DiscardDeadLetteredMessageResponse)
+	err := c.cc.Invoke(ctx, "/OutboxAdminService/DiscardDeadLetteredMessage", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *outboxAdminServiceClient) GetOutboxStatus(ctx context.Context, in *GetOutboxStatusRequest, opts ...grpc.CallOption) (*GetOutboxStatusResponse, error) {
+	out := new(GetOutboxStatusResponse)
+	err := c.cc.Invoke(ctx, "/OutboxAdminService/GetOutboxStatus", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *outboxAdminServiceClient) ListOutboxMessages(ctx context.Context, in *ListOutboxMessagesRequest, opts ...grpc.CallOption) (*ListOutboxMessagesResponse, error) {
+	out := new(ListOutboxMessagesResponse)
+	err := c.cc.Invoke(ctx, "/OutboxAdminService/ListOutboxMessages", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *outboxAdminServiceClient) GetOutboxMessage(ctx context.Context, in *GetOutboxMessageRequest, opts ...grpc.CallOption) (*GetOutboxMessageResponse, error) {
+	out := new(GetOutboxMessageResponse)
+	err := c.cc.Invoke(ctx, "/OutboxAdminService/GetOutboxMessage", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *outboxAdminServiceClient) RetryOutboxMessage(ctx context.Context, in *RetryOutboxMessageRequest, opts ...grpc.CallOption) (*RetryOutboxMessageResponse, error) {
+	out := new(RetryOutboxMessageResponse)
+	err := c.cc.Invoke(ctx, "/OutboxAdminService/RetryOutboxMessage", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *outboxAdminServiceClient) RepublishOutboxMessage(ctx context.Context, in *RepublishOutboxMessageRequest, opts ...grpc.CallOption) (*RepublishOutboxMessageResponse, error) {
+	out := new(RepublishOutboxMessageResponse)
+	err := c.cc.Invoke(ctx, "/OutboxAdminService/RepublishOutboxMessage", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *outboxAdminServiceClient) StartReplay(ctx context.Context, in *StartReplayRequest, opts ...grpc.CallOption) (*StartReplayResponse, error) {
+	out := new(StartReplayResponse)
+	err := c.cc.Invoke(ctx, "/OutboxAdminService/StartReplay", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *outboxAdminServiceClient) GetReplay(ctx context.Context, in *GetReplayRequest, opts ...grpc.CallOption) (*GetReplayResponse, error) {
+	out := new(GetReplayResponse)
+	err := c.cc.Invoke(ctx, "/OutboxAdminService/GetReplay", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// OutboxAdminServiceServer is the server API for OutboxAdminService service.
+// All implementations must embed UnimplementedOutboxAdminServiceServer
+// for forward compatibility
+type OutboxAdminServiceServer interface {
+	ListDeadLetteredMessages(context.Context, *ListDeadLetteredMessagesRequest) (*ListDeadLetteredMessagesResponse, error)
+	GetDeadLetteredMessage(context.Context, *GetDeadLetteredMessageRequest) (*GetDeadLetteredMessageResponse, error)
+	RequeueDeadLetteredMessage(context.Context, *RequeueDeadLetteredMessageRequest) (*RequeueDeadLetteredMessageResponse, error)
+	DiscardDeadLetteredMessage(context.Context, *DiscardDeadLetteredMessageRequest) (*DiscardDeadLetteredMessageResponse, error)
+	GetOutboxStatus(context.Context, *GetOutboxStatusRequest) (*GetOutboxStatusResponse, error)
+	// ListOutboxMessages lists the messages in any state, oldest first
+	ListOutboxMessages(context.Context, *ListOutboxMessagesRequest) (*ListOutboxMessagesResponse, error)
+	// GetOutboxMessage returns a message together with its decoded event
+	GetOutboxMessage(context.Context, *GetOutboxMessageRequest) (*GetOutboxMessageResponse, error)
+	// RetryOutboxMessage makes a waiting message due right away instead of waiting for its backoff
+	RetryOutboxMessage(context.Context, *RetryOutboxMessageRequest) (*RetryOutboxMessageResponse, error)
+	// RepublishOutboxMessage sends a copy of a finished message, with an id of its own so consumers that dedupe on the
+	// message-id header do not drop it, and the original id in the republished-from header. It fails with
+	// FAILED_PRECONDITION while newer messages of the same key are in the outbox, as consumers would get the old event
+	// after the newer ones
+	RepublishOutboxMessage(context.Context, *RepublishOutboxMessageRequest) (*RepublishOutboxMessageResponse, error)
+	// StartReplay enqueues a snapshot of every user, or of the filtered ones, in the background. Starting a replay with
+	// the id of an unfinished one resumes it, unless this instance is running it already. Unfinished replays are
+	// resumed when the service restarts
+	StartReplay(context.Context, *StartReplayRequest) (*StartReplayResponse, error)
+	GetReplay(context.Context, *GetReplayRequest) (*GetReplayResponse, error)
+	mustEmbedUnimplementedOutboxAdminServiceServer()
+}
+
+// UnimplementedOutboxAdminServiceServer must be embedded to have forward compatible implementations.
+type UnimplementedOutboxAdminServiceServer struct {
+}
+
+func (UnimplementedOutboxAdminServiceServer) ListDeadLetteredMessages(context.Context, *ListDeadLetteredMessagesRequest) (*ListDeadLetteredMessagesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListDeadLetteredMessages not implemented")
+}
+func (UnimplementedOutboxAdminServiceServer) GetDeadLetteredMessage(context.Context, *GetDeadLetteredMessageRequest) (*GetDeadLetteredMessageResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetDeadLetteredMessage not implemented")
+}
+func (UnimplementedOutboxAdminServiceServer) RequeueDeadLetteredMessage(context.Context, *RequeueDeadLetteredMessageRequest) (*RequeueDeadLetteredMessageResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RequeueDeadLetteredMessage not implemented")
+}
+func (UnimplementedOutboxAdminServiceServer) DiscardDeadLetteredMessage(context.Context, *DiscardDeadLetteredMessageRequest) (*DiscardDeadLetteredMessageResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DiscardDeadLetteredMessage not implemented")
+}
+func (UnimplementedOutboxAdminServiceServer) GetOutboxStatus(context.Context, *GetOutboxStatusRequest) (*GetOutboxStatusResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetOutboxStatus not implemented")
+}
+func (UnimplementedOutboxAdminServiceServer) ListOutboxMessages(context.Context, *ListOutboxMessagesRequest) (*ListOutboxMessagesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListOutboxMessages not implemented")
+}
+func (UnimplementedOutboxAdminServiceServer) GetOutboxMessage(context.Context, *GetOutboxMessageRequest) (*GetOutboxMessageResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetOutboxMessage not implemented")
+}
+func (UnimplementedOutboxAdminServiceServer) RetryOutboxMessage(context.Context, *RetryOutboxMessageRequest) (*RetryOutboxMessageResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RetryOutboxMessage not implemented")
+}
+func (UnimplementedOutboxAdminServiceServer) RepublishOutboxMessage(context.Context, *RepublishOutboxMessageRequest) (*RepublishOutboxMessageResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RepublishOutboxMessage not implemented")
+}
+func (UnimplementedOutboxAdminServiceServer) StartReplay(context.Context, *StartReplayRequest) (*StartReplayResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method StartReplay not implemented")
+}
+func (UnimplementedOutboxAdminServiceServer) GetReplay(context.Context, *GetReplayRequest) (*GetReplayResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetReplay not implemented")
+}
+func (UnimplementedOutboxAdminServiceServer) mustEmbedUnimplementedOutboxAdminServiceServer() {}
+
+// UnsafeOutboxAdminServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to OutboxAdminServiceServer will
+// result in compilation errors.
+type UnsafeOutboxAdminServiceServer interface {
+	mustEmbedUnimplementedOutboxAdminServiceServer()
+}
+
+func RegisterOutboxAdminServiceServer(s grpc.ServiceRegistrar, srv OutboxAdminServiceServer) {
+	s.RegisterService(&OutboxAdminService_ServiceDesc, srv)
+}
+
+func _OutboxAdminService_ListDeadLetteredMessages_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListDeadLetteredMessagesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OutboxAdminServiceServer).ListDeadLetteredMessages(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/OutboxAdminService/ListDeadLetteredMessages",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OutboxAdminServiceServer).ListDeadLetteredMessages(ctx, req.(*ListDeadLetteredMessagesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _OutboxAdminService_GetDeadLetteredMessage_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetDeadLetteredMessageRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OutboxAdminServiceServer).GetDeadLetteredMessage(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/OutboxAdminService/GetDeadLetteredMessage",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OutboxAdminServiceServer).GetDeadLetteredMessage(ctx, req.(*GetDeadLetteredMessageRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _OutboxAdminService_RequeueDeadLetteredMessage_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RequeueDeadLetteredMessageRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OutboxAdminServiceServer).RequeueDeadLetteredMessage(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/OutboxAdminService/RequeueDeadLetteredMessage",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OutboxAdminServiceServer).RequeueDeadLetteredMessage(ctx, req.(*RequeueDeadLetteredMessageRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _OutboxAdminService_DiscardDeadLetteredMessage_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DiscardDeadLetteredMessageRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OutboxAdminServiceServer).DiscardDeadLetteredMessage(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/OutboxAdminService/DiscardDeadLetteredMessage",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OutboxAdminServiceServer).DiscardDeadLetteredMessage(ctx, req.(*DiscardDeadLetteredMessageRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _OutboxAdminService_GetOutboxStatus_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetOutboxStatusRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OutboxAdminServiceServer).GetOutboxStatus(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/OutboxAdminService/GetOutboxStatus",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OutboxAdminServiceServer).GetOutboxStatus(ctx, req.(*GetOutboxStatusRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _OutboxAdminService_ListOutboxMessages_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListOutboxMessagesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OutboxAdminServiceServer).ListOutboxMessages(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/OutboxAdminService/ListOutboxMessages",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OutboxAdminServiceServer).ListOutboxMessages(ctx, req.(*ListOutboxMessagesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _OutboxAdminService_GetOutboxMessage_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetOutboxMessageRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OutboxAdminServiceServer).GetOutboxMessage(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/OutboxAdminService/GetOutboxMessage",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OutboxAdminServiceServer).GetOutboxMessage(ctx, req.(*GetOutboxMessageRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _OutboxAdminService_RetryOutboxMessage_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RetryOutboxMessageRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OutboxAdminServiceServer).RetryOutboxMessage(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/OutboxAdminService/RetryOutboxMessage",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OutboxAdminServiceServer).RetryOutboxMessage(ctx, req.(*RetryOutboxMessageRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _OutboxAdminService_RepublishOutboxMessage_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RepublishOutboxMessageRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OutboxAdminServiceServer).RepublishOutboxMessage(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/OutboxAdminService/RepublishOutboxMessage",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OutboxAdminServiceServer).RepublishOutboxMessage(ctx, req.(*RepublishOutboxMessageRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _OutboxAdminService_StartReplay_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(StartReplayRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OutboxAdminServiceServer).StartReplay(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/OutboxAdminService/StartReplay",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OutboxAdminServiceServer).StartReplay(ctx, req.(*StartReplayRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _OutboxAdminService_GetReplay_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetReplayRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OutboxAdminServiceServer).GetReplay(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/OutboxAdminService/GetReplay",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OutboxAdminServiceServer).GetReplay(ctx, req.(*GetReplayRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// OutboxAdminService_ServiceDesc is the grpc.ServiceDesc for OutboxAdminService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var OutboxAdminService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "OutboxAdminService",
+	HandlerType: (*OutboxAdminServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "ListDeadLetteredMessages",
+			Handler:    _OutboxAdminService_ListDeadLetteredMessages_Handler,
+		},
+		{
+			MethodName: "GetDeadLetteredMessage",
+			Handler:    _OutboxAdminService_GetDeadLetteredMessage_Handler,
+		},
+		{
+			MethodName: "RequeueDeadLetteredMessage",
+			Handler:    _OutboxAdminService_RequeueDeadLetteredMessage_Handler,
+		},
+		{
+			MethodName: "DiscardDeadLetteredMessage",
+			Handler:    _OutboxAdminService_DiscardDeadLetteredMessage_Handler,
+		},
+		{
+			MethodName: "GetOutboxStatus",
+			Handler:    _OutboxAdminService_GetOutboxStatus_Handler,
+		},
+		{
+			MethodName: "ListOutboxMessages",
+			Handler:    _OutboxAdminService_ListOutboxMessages_Handler,
+		},
+		{
+			MethodName: "GetOutboxMessage",
+			Handler:    _OutboxAdminService_GetOutboxMessage_Handler,
+		},
+		{
+			MethodName: "RetryOutboxMessage",
+			Handler:    _OutboxAdminService_RetryOutboxMessage_Handler,
+		},
+		{
+			MethodName: "RepublishOutboxMessage",
+			Handler:    _OutboxAdminService_RepublishOutboxMessage_Handler,
+		},
+		{
+			MethodName: "StartReplay",
+			Handler:    _OutboxAdminService_StartReplay_Handler,
+		},
+		{
+			MethodName: "GetReplay",
+			Handler:    _OutboxAdminService_GetReplay_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "proto/grpc/outbox_admin.proto",
+}
@@ -0,0 +1,252 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.28.1
+// 	protoc        v5.27.2
+// source: proto/kafkaschema/user_updated.proto
+
+package kafkaschema
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// field uses the same snake case names as the user schema, e.g. first_name
+type UserFieldChange struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Field    string `protobuf:"bytes,1,opt,name=field,proto3" json:"field,omitempty"`
+	OldValue string `protobuf:"bytes,2,opt,name=old_value,json=oldValue,proto3" json:"old_value,omitempty"`
+	NewValue string `protobuf:"bytes,3,opt,name=new_value,json=newValue,proto3" json:"new_value,omitempty"`
+}
+
+func (x *UserFieldChange) Reset() {
+	*x = UserFieldChange{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_kafkaschema_user_updated_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *UserFieldChange) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UserFieldChange) ProtoMessage() {}
+
+func (x *UserFieldChange) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_kafkaschema_user_updated_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UserFieldChange.ProtoReflect.Descriptor instead.
+func (*UserFieldChange) Descriptor() ([]byte, []int) {
+	return file_proto_kafkaschema_user_updated_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *UserFieldChange) GetField() string {
+	if x != nil {
+		return x.Field
+	}
+	return ""
+}
+
+func (x *UserFieldChange) GetOldValue() string {
+	if x != nil {
+		return x.OldValue
+	}
+	return ""
+}
+
+func (x *UserFieldChange) GetNewValue() string {
+	if x != nil {
+		return x.NewValue
+	}
+	return ""
+}
+
+// password material is never part of the changes, password_changed only tells that it was modified
+type UserUpdatedMessage struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id              string             `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Changes         []*UserFieldChange `protobuf:"bytes,2,rep,name=changes,proto3" json:"changes,omitempty"`
+	PasswordChanged bool               `protobuf:"varint,3,opt,name=password_changed,json=passwordChanged,proto3" json:"password_changed,omitempty"`
+}
+
+func (x *UserUpdatedMessage) Reset() {
+	*x = UserUpdatedMessage{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_kafkaschema_user_updated_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *UserUpdatedMessage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UserUpdatedMessage) ProtoMessage() {}
+
+func (x *UserUpdatedMessage) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_kafkaschema_user_updated_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UserUpdatedMessage.ProtoReflect.Descriptor instead.
+func (*UserUpdatedMessage) Descriptor() ([]byte, []int) {
+	return file_proto_kafkaschema_user_updated_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *UserUpdatedMessage) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *UserUpdatedMessage) GetChanges() []*UserFieldChange {
+	if x != nil {
+		return x.Changes
+	}
+	return nil
+}
+
+func (x *UserUpdatedMessage) GetPasswordChanged() bool {
+	if x != nil {
+		return x.PasswordChanged
+	}
+	return false
+}
+
+var File_proto_kafkaschema_user_updated_proto protoreflect.FileDescriptor
+
+var file_proto_kafkaschema_user_updated_proto_rawDesc = []byte{
+	0x0a, 0x24, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2f, 0x6b, 0x61, 0x66, 0x6b, 0x61, 0x73, 0x63, 0x68,
+	0x65, 0x6d, 0x61, 0x2f, 0x75, 0x73, 0x65, 0x72, 0x5f, 0x75, 0x70, 0x64, 0x61, 0x74, 0x65, 0x64,
+	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x0b, 0x6b, 0x61, 0x66, 0x6b, 0x61, 0x73, 0x63, 0x68,
+	0x65, 0x6d, 0x61, 0x22, 0x61, 0x0a, 0x0f, 0x55, 0x73, 0x65, 0x72, 0x46, 0x69, 0x65, 0x6c, 0x64,
+	0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x66, 0x69, 0x65, 0x6c, 0x64, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x66, 0x69, 0x65, 0x6c, 0x64, 0x12, 0x1b, 0x0a, 0x09,
+	0x6f, 0x6c, 0x64, 0x5f, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x08, 0x6f, 0x6c, 0x64, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x12, 0x1b, 0x0a, 0x09, 0x6e, 0x65, 0x77,
+	0x5f, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x6e, 0x65,
+	0x77, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x22, 0x87, 0x01, 0x0a, 0x12, 0x55, 0x73, 0x65, 0x72, 0x55,
+	0x70, 0x64, 0x61, 0x74, 0x65, 0x64, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x0e, 0x0a,
+	0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x12, 0x36, 0x0a,
+	0x07, 0x63, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1c,
+	0x2e, 0x6b, 0x61, 0x66, 0x6b, 0x61, 0x73, 0x63, 0x68, 0x65, 0x6d, 0x61, 0x2e, 0x55, 0x73, 0x65,
+	0x72, 0x46, 0x69, 0x65, 0x6c, 0x64, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x52, 0x07, 0x63, 0x68,
+	0x61, 0x6e, 0x67, 0x65, 0x73, 0x12, 0x29, 0x0a, 0x10, 0x70, 0x61, 0x73, 0x73, 0x77, 0x6f, 0x72,
+	0x64, 0x5f, 0x63, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x08, 0x52,
+	0x0f, 0x70, 0x61, 0x73, 0x73, 0x77, 0x6f, 0x72, 0x64, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x64,
+	0x42, 0x1f, 0x5a, 0x1d, 0x75, 0x73, 0x65, 0x72, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x2f,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2f, 0x6b, 0x61, 0x66, 0x6b, 0x61, 0x73, 0x63, 0x68, 0x65, 0x6d,
+	0x61, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_proto_kafkaschema_user_updated_proto_rawDescOnce sync.Once
+	file_proto_kafkaschema_user_updated_proto_rawDescData = file_proto_kafkaschema_user_updated_proto_rawDesc
+)
+
+func file_proto_kafkaschema_user_updated_proto_rawDescGZIP() []byte {
+	file_proto_kafkaschema_user_updated_proto_rawDescOnce.Do(func() {
+		file_proto_kafkaschema_user_updated_proto_rawDescData = protoimpl.X.CompressGZIP(file_proto_kafkaschema_user_updated_proto_rawDescData)
+	})
+	return file_proto_kafkaschema_user_updated_proto_rawDescData
+}
+
+var file_proto_kafkaschema_user_updated_proto_msgTypes = make([]protoimpl.MessageInfo, 2)
+var file_proto_kafkaschema_user_updated_proto_goTypes = []interface{}{
+	(*UserFieldChange)(nil),    // 0: kafkaschema.UserFieldChange
+	(*UserUpdatedMessage)(nil), // 1: kafkaschema.UserUpdatedMessage
+}
+var file_proto_kafkaschema_user_updated_proto_depIdxs = []int32{
+	0, // 0: kafkaschema.UserUpdatedMessage.changes:type_name -> kafkaschema.UserFieldChange
+	1, // [1:1] is the sub-list for method output_type
+	1, // [1:1] is the sub-list for method input_type
+	1, // [1:1] is the sub-list for extension type_name
+	1, // [1:1] is the sub-list for extension extendee
+	0, // [0:1] is the sub-list for field type_name
+}
+
+func init() { file_proto_kafkaschema_user_updated_proto_init() }
+func file_proto_kafkaschema_user_updated_proto_init() {
+	if File_proto_kafkaschema_user_updated_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_proto_kafkaschema_user_updated_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*UserFieldChange); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_kafkaschema_user_updated_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*UserUpdatedMessage); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_proto_kafkaschema_user_updated_proto_rawDesc,
+			NumEnums:      0,
+			NumMessages:   2,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_proto_kafkaschema_user_updated_proto_goTypes,
+		DependencyIndexes: file_proto_kafkaschema_user_updated_proto_depIdxs,
+		MessageInfos:      file_proto_kafkaschema_user_updated_proto_msgTypes,
+	}.Build()
+	File_proto_kafkaschema_user_updated_proto = out.File
+	file_proto_kafkaschema_user_updated_proto_rawDesc = nil
+	file_proto_kafkaschema_user_updated_proto_goTypes = nil
+	file_proto_kafkaschema_user_updated_proto_depIdxs = nil
+}